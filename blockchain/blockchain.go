@@ -41,6 +41,7 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrChainNotEmpty        = errors.New("blockchain contains blocks after the genesis")
 )
 
 // Blockchain is a blockchain reference
@@ -935,6 +936,90 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	return nil
 }
 
+// WriteTrustedBlocks writes the consecutive blocks on top of the genesis without executing them,
+// the state of the last block is expected to be synced separately. The blocks are trusted
+// by the caller, only their linkage and bodies are verified. The blocks before the first one
+// are not written, so the total difficulty doesn't include their difficulty,
+// and the receipts of the written blocks are not available
+func (b *Blockchain) WriteTrustedBlocks(blocks []*types.Block, source string) error {
+	if len(blocks) == 0 {
+		return ErrNoBlock
+	}
+
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if b.Header().Number != 0 {
+		return ErrChainNotEmpty
+	}
+
+	for i, block := range blocks {
+		if block.Number() == 0 {
+			return ErrInvalidBlockSequence
+		}
+
+		if i > 0 {
+			if block.Number()-1 != blocks[i-1].Number() {
+				return ErrInvalidBlockSequence
+			}
+
+			if block.ParentHash() != blocks[i-1].Hash() {
+				return ErrParentHashMismatch
+			}
+		}
+
+		if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
+			return ErrInvalidSha3Uncles
+		}
+
+		if hash := buildroot.CalculateTransactionsRoot(block.Transactions); hash != block.Header.TxRoot {
+			return ErrInvalidTxRoot
+		}
+	}
+
+	td := b.CurrentTD()
+	headers := make([]*types.Header, len(blocks))
+	batchWriter := storage.NewBatchWriter(b.db)
+	evnt := &Event{Source: source, Type: EventHead}
+
+	for i, block := range blocks {
+		if err := b.writeBody(batchWriter, block); err != nil {
+			return err
+		}
+
+		td = new(big.Int).Add(td, new(big.Int).SetUint64(block.Header.Difficulty))
+
+		batchWriter.PutCanonicalHeader(block.Header, td)
+
+		headers[i] = block.Header
+
+		evnt.AddNewHeader(block.Header)
+	}
+
+	// update snapshot
+	if err := b.consensus.ProcessHeaders(headers); err != nil {
+		return err
+	}
+
+	head := headers[len(headers)-1]
+
+	if err := b.writeBatchAndUpdate(batchWriter, head, td, true); err != nil {
+		return err
+	}
+
+	evnt.SetDifficulty(td)
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("trusted blocks written",
+		"from", headers[0].Number,
+		"to", head.Number,
+		"hash", head.Hash,
+		"source", source,
+	)
+
+	return nil
+}
+
 // GetCachedReceipts retrieves cached receipts for given headerHash
 func (b *Blockchain) GetCachedReceipts(headerHash types.Hash) ([]*types.Receipt, error) {
 	receipts, found := b.receiptsCache.Get(headerHash)
@@ -1516,3 +1516,80 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.CANONICAL, common.EncodeUint64ToBytes(header.Number)))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.RECEIPTS, header.Hash.Bytes()))])
 }
+
+func TestBlockchain_WriteTrustedBlocks(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+
+	newTestGenesisBlockchain := func(t *testing.T, genesis *types.Header) *Blockchain {
+		t.Helper()
+
+		b := NewTestBlockchain(t, nil)
+
+		batchWriter := storage.NewBatchWriter(b.db)
+		td := new(big.Int).SetUint64(genesis.Difficulty)
+
+		batchWriter.PutCanonicalHeader(genesis, td)
+
+		require.NoError(t, b.writeBatchAndUpdate(batchWriter, genesis, td, true))
+
+		return b
+	}
+
+	t.Run("should write the blocks on top of the genesis", func(t *testing.T) {
+		t.Parallel()
+
+		b := newTestGenesisBlockchain(t, headers[0])
+		sub := b.SubscribeEvents()
+
+		defer sub.Close()
+
+		require.NoError(t, b.WriteTrustedBlocks(HeadersToBlocks(headers[5:]), "syncer"))
+
+		require.Equal(t, headers[9].Hash, b.Header().Hash)
+
+		for _, header := range headers[5:] {
+			block, ok := b.GetBlockByNumber(header.Number, true)
+			require.True(t, ok)
+			require.Equal(t, header.Hash, block.Hash())
+		}
+
+		_, ok := b.GetHeaderByNumber(4)
+		require.False(t, ok)
+
+		ev := <-sub.GetEventCh()
+		require.Equal(t, EventHead, ev.Type)
+		require.Len(t, ev.NewChain, 5)
+		require.Equal(t, headers[9].Hash, ev.Header().Hash)
+
+		// the chain isn't empty anymore
+		require.ErrorIs(t, b.WriteTrustedBlocks(HeadersToBlocks(headers[5:]), "syncer"), ErrChainNotEmpty)
+	})
+
+	t.Run("should reject the blocks not linked together", func(t *testing.T) {
+		t.Parallel()
+
+		b := newTestGenesisBlockchain(t, headers[0])
+
+		blocks := HeadersToBlocks([]*types.Header{headers[3], headers[5]})
+		require.ErrorIs(t, b.WriteTrustedBlocks(blocks, "syncer"), ErrInvalidBlockSequence)
+
+		forked := AppendNewTestheadersWithSeed(headers[:5], 1, 1)
+		blocks = HeadersToBlocks([]*types.Header{forked[5], headers[6]})
+		require.ErrorIs(t, b.WriteTrustedBlocks(blocks, "syncer"), ErrParentHashMismatch)
+
+		require.Equal(t, headers[0].Hash, b.Header().Hash)
+	})
+
+	t.Run("should reject the block with invalid body", func(t *testing.T) {
+		t.Parallel()
+
+		b := newTestGenesisBlockchain(t, headers[0])
+
+		blocks := HeadersToBlocks(headers[5:])
+		blocks[2].Transactions = []*types.Transaction{{Nonce: 1}}
+
+		require.ErrorIs(t, b.WriteTrustedBlocks(blocks, "syncer"), ErrInvalidTxRoot)
+	})
+}
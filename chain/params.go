@@ -83,7 +83,10 @@ const (
 	Constantinople = "constantinople"
 	Petersburg     = "petersburg"
 	Istanbul       = "istanbul"
+	Berlin         = "berlin"
 	London         = "london"
+	Shanghai       = "shanghai"
+	Cancun         = "cancun"
 	EIP150         = "EIP150"
 	EIP158         = "EIP158"
 	EIP155         = "EIP155"
@@ -116,7 +119,10 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Constantinople: f.IsActive(Constantinople, block),
 		Petersburg:     f.IsActive(Petersburg, block),
 		Istanbul:       f.IsActive(Istanbul, block),
+		Berlin:         f.IsActive(Berlin, block),
 		London:         f.IsActive(London, block),
+		Shanghai:       f.IsActive(Shanghai, block),
+		Cancun:         f.IsActive(Cancun, block),
 		EIP150:         f.IsActive(EIP150, block),
 		EIP158:         f.IsActive(EIP158, block),
 		EIP155:         f.IsActive(EIP155, block),
@@ -161,7 +167,10 @@ type ForksInTime struct {
 	Constantinople,
 	Petersburg,
 	Istanbul,
+	Berlin,
 	London,
+	Shanghai,
+	Cancun,
 	EIP150,
 	EIP158,
 	EIP155 bool
@@ -177,5 +186,8 @@ var AllForksEnabled = &Forks{
 	Constantinople: NewFork(0),
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
+	Berlin:         NewFork(0),
 	London:         NewFork(0),
+	Shanghai:       NewFork(0),
+	Cancun:         NewFork(0),
 }
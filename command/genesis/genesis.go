@@ -235,6 +235,22 @@ func setFlags(cmd *cobra.Command) {
 			defaultBlockTimeDrift,
 			"configuration for block time drift value (in seconds)",
 		)
+
+		cmd.Flags().Uint64Var(
+			&params.livenessWindow,
+			livenessWindowFlag,
+			0,
+			"the number of the latest blocks the liveness of the validators is tracked over "+
+				"(the default window is used if not set)",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.livenessThreshold,
+			livenessThresholdFlag,
+			0,
+			"the minimum percentage of the tracked blocks a validator has to sign to stay in the validator set "+
+				"(the validators are never excluded if not set)",
+		)
 	}
 
 	// Access Control Lists
@@ -63,6 +63,7 @@ var (
 		"(<name:symbol:decimals count:mintable flag:[mintable token owner address]>)")
 	errRewardWalletAmountZero   = errors.New("reward wallet amount can not be zero or negative")
 	errReserveAccMustBePremined = errors.New("it is mandatory to premine reserve account (0x0 address)")
+	errInvalidLivenessThreshold = errors.New("liveness threshold must be a percentage between 0 and 100")
 )
 
 type genesisParams struct {
@@ -105,6 +106,8 @@ type genesisParams struct {
 	blockTime            time.Duration
 	epochReward          uint64
 	blockTimeDrift       uint64
+	livenessWindow       uint64
+	livenessThreshold    uint64
 
 	initialStateRoot string
 
@@ -165,6 +168,10 @@ func (p *genesisParams) validateFlags() error {
 		if err := p.validatePremineInfo(); err != nil {
 			return err
 		}
+
+		if p.livenessThreshold > 100 {
+			return errInvalidLivenessThreshold
+		}
 	}
 
 	// Check if the genesis file already exists
@@ -37,6 +37,9 @@ const (
 
 	blockTimeDriftFlag = "block-time-drift"
 
+	livenessWindowFlag    = "liveness-window"
+	livenessThresholdFlag = "liveness-threshold"
+
 	defaultEpochSize        = uint64(10)
 	defaultSprintSize       = uint64(5)
 	defaultValidatorSetSize = 100
@@ -149,6 +152,10 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 			WalletAmount:  walletPremineInfo.amount,
 		},
 		BlockTimeDrift: p.blockTimeDrift,
+		Liveness: &polybft.LivenessConfig{
+			Window:    p.livenessWindow,
+			Threshold: p.livenessThreshold,
+		},
 	}
 
 	// Disable london hardfork if burn contract address is not provided
@@ -0,0 +1,58 @@
+package ban
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+var (
+	params = &banParams{}
+)
+
+const (
+	peerIDFlag   = "peer-id"
+	durationFlag = "duration"
+	reasonFlag   = "reason"
+)
+
+type banParams struct {
+	peerID   string
+	duration time.Duration
+	reason   string
+}
+
+func (p *banParams) getRequiredFlags() []string {
+	return []string{
+		peerIDFlag,
+	}
+}
+
+func (p *banParams) banPeer(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = systemClient.PeersBan(
+		context.Background(),
+		&proto.PeersBanRequest{
+			Id:       p.peerID,
+			Duration: uint64(p.duration.Seconds()),
+			Reason:   p.reason,
+		},
+	)
+
+	return err
+}
+
+func (p *banParams) getResult() command.CommandResult {
+	return &PeersBanResult{
+		ID:       p.peerID,
+		Duration: p.duration.String(),
+		Reason:   p.reason,
+	}
+}
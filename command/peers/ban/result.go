@@ -0,0 +1,28 @@
+package ban
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PeersBanResult struct {
+	ID       string `json:"id"`
+	Duration string `json:"duration"`
+	Reason   string `json:"reason"`
+}
+
+func (r *PeersBanResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEER BANNED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("ID|%s", r.ID),
+		fmt.Sprintf("Duration|%s", r.Duration),
+		fmt.Sprintf("Reason|%s", r.Reason),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
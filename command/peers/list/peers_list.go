@@ -13,7 +13,7 @@ import (
 func GetCommand() *cobra.Command {
 	peersListCmd := &cobra.Command{
 		Use:   "list",
-		Short: "Returns the list of connected peers, including the current node",
+		Short: "Returns the list of connected peers and their classification, including the current node",
 		Run:   runCommand,
 	}
 
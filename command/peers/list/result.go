@@ -3,24 +3,66 @@ package list
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 )
 
 type PeersListResult struct {
-	Peers []string `json:"peers"`
+	Peers        []string `json:"peers"`
+	StaticPeers  []string `json:"static_peers,omitempty"`
+	TrustedPeers []string `json:"trusted_peers,omitempty"`
+	PrivatePeers []string `json:"private_peers,omitempty"`
 }
 
 func newPeersListResult(peers []*proto.Peer) *PeersListResult {
-	resultPeers := make([]string, len(peers))
+	result := &PeersListResult{
+		Peers: make([]string, len(peers)),
+	}
+
 	for i, p := range peers {
-		resultPeers[i] = p.Id
+		result.Peers[i] = p.Id
+
+		if p.Static {
+			result.StaticPeers = append(result.StaticPeers, p.Id)
+		}
+
+		if p.Trusted {
+			result.TrustedPeers = append(result.TrustedPeers, p.Id)
+		}
+
+		if p.Private {
+			result.PrivatePeers = append(result.PrivatePeers, p.Id)
+		}
 	}
 
-	return &PeersListResult{
-		Peers: resultPeers,
+	return result
+}
+
+// peerClasses returns the classification of the peer, empty for the regular peers
+func (r *PeersListResult) peerClasses(id string) string {
+	classes := make([]string, 0, 3)
+
+	for _, static := range r.StaticPeers {
+		if static == id {
+			classes = append(classes, "static")
+		}
+	}
+
+	for _, trusted := range r.TrustedPeers {
+		if trusted == id {
+			classes = append(classes, "trusted")
+		}
 	}
+
+	for _, private := range r.PrivatePeers {
+		if private == id {
+			classes = append(classes, "private")
+		}
+	}
+
+	return strings.Join(classes, ", ")
 }
 
 func (r *PeersListResult) GetOutput() string {
@@ -35,7 +77,7 @@ func (r *PeersListResult) GetOutput() string {
 
 		rows := make([]string, len(r.Peers))
 		for i, p := range r.Peers {
-			rows[i] = fmt.Sprintf("[%d]|%s", i, p)
+			rows[i] = fmt.Sprintf("[%d]|%s|%s", i, p, r.peerClasses(p))
 		}
 		buffer.WriteString(helper.FormatKV(rows))
 	}
@@ -0,0 +1,46 @@
+package listbans
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	peersListBansCmd := &cobra.Command{
+		Use:   "list-bans",
+		Short: "Returns the list of the banned peers, including the expiry and the reason of the bans",
+		Run:   runCommand,
+	}
+
+	return peersListBansCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	bansList, err := getBansList(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(
+		newPeersListBansResult(bansList.Bans),
+	)
+}
+
+func getBansList(grpcAddress string) (*proto.PeersListBansResponse, error) {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.PeersListBans(context.Background(), &empty.Empty{})
+}
@@ -0,0 +1,57 @@
+package listbans
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type PeerBanResult struct {
+	ID     string `json:"id"`
+	Expiry string `json:"expiry"`
+	Reason string `json:"reason"`
+}
+
+type PeersListBansResult struct {
+	Bans []PeerBanResult `json:"bans"`
+}
+
+func newPeersListBansResult(bans []*proto.PeerBan) *PeersListBansResult {
+	resultBans := make([]PeerBanResult, len(bans))
+	for i, b := range bans {
+		resultBans[i] = PeerBanResult{
+			ID:     b.Id,
+			Expiry: time.Unix(b.Expiry, 0).UTC().Format(time.RFC3339),
+			Reason: b.Reason,
+		}
+	}
+
+	return &PeersListBansResult{
+		Bans: resultBans,
+	}
+}
+
+func (r *PeersListBansResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BANNED PEERS]\n")
+
+	if len(r.Bans) == 0 {
+		buffer.WriteString("No banned peers found")
+	} else {
+		buffer.WriteString(fmt.Sprintf("Number of banned peers: %d\n\n", len(r.Bans)))
+
+		rows := make([]string, len(r.Bans))
+		for i, b := range r.Bans {
+			rows[i] = fmt.Sprintf("[%d]|%s|expires %s|%s", i, b.ID, b.Expiry, b.Reason)
+		}
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
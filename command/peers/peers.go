@@ -3,8 +3,11 @@ package peers
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/peers/add"
+	"github.com/0xPolygon/polygon-edge/command/peers/ban"
 	"github.com/0xPolygon/polygon-edge/command/peers/list"
+	"github.com/0xPolygon/polygon-edge/command/peers/listbans"
 	"github.com/0xPolygon/polygon-edge/command/peers/status"
+	"github.com/0xPolygon/polygon-edge/command/peers/unban"
 	"github.com/spf13/cobra"
 )
 
@@ -29,5 +32,11 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		list.GetCommand(),
 		// peers add
 		add.GetCommand(),
+		// peers ban
+		ban.GetCommand(),
+		// peers unban
+		unban.GetCommand(),
+		// peers list-bans
+		listbans.GetCommand(),
 	)
 }
@@ -54,5 +54,8 @@ func (p *statusParams) getResult() command.CommandResult {
 		ID:        p.peerStatus.Id,
 		Protocols: p.peerStatus.Protocols,
 		Addresses: p.peerStatus.Addrs,
+		Static:    p.peerStatus.Static,
+		Trusted:   p.peerStatus.Trusted,
+		Private:   p.peerStatus.Private,
 	}
 }
@@ -11,6 +11,9 @@ type PeersStatusResult struct {
 	ID        string   `json:"id"`
 	Protocols []string `json:"protocols"`
 	Addresses []string `json:"addresses"`
+	Static    bool     `json:"static"`
+	Trusted   bool     `json:"trusted"`
+	Private   bool     `json:"private"`
 }
 
 func (r *PeersStatusResult) GetOutput() string {
@@ -21,6 +24,9 @@ func (r *PeersStatusResult) GetOutput() string {
 		fmt.Sprintf("ID|%s", r.ID),
 		fmt.Sprintf("Protocols|%s", r.Protocols),
 		fmt.Sprintf("Addresses|%s", r.Addresses),
+		fmt.Sprintf("Static|%t", r.Static),
+		fmt.Sprintf("Trusted|%t", r.Trusted),
+		fmt.Sprintf("Private|%t", r.Private),
 	}))
 	buffer.WriteString("\n")
 
@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/rootchain/validators"
 	"github.com/0xPolygon/polygon-edge/command/rootchain/whitelist"
 	"github.com/0xPolygon/polygon-edge/command/rootchain/withdraw"
+	"github.com/0xPolygon/polygon-edge/command/sidechain/liveness"
 	"github.com/0xPolygon/polygon-edge/command/sidechain/rewards"
 	"github.com/0xPolygon/polygon-edge/command/sidechain/unstaking"
 	sidechainWithdraw "github.com/0xPolygon/polygon-edge/command/sidechain/withdraw"
@@ -27,6 +28,8 @@ func GetCommand() *cobra.Command {
 		sidechainWithdraw.GetCommand(),
 		// sidechain (reward pool) command to withdraw pending rewards
 		rewards.GetCommand(),
+		// sidechain command that queries the liveness of the validators
+		liveness.GetCommand(),
 		// rootchain (stake manager) command to withdraw stake
 		withdraw.GetCommand(),
 		// rootchain (supernet manager) command that queries validator info
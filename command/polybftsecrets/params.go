@@ -20,6 +20,8 @@ const (
 	networkFlag            = "network"
 	numFlag                = "num"
 	outputFlag             = "output"
+	importFlag             = "import"
+	newPasswordFileFlag    = "new-password-file"
 
 	// maxInitNum is the maximum value for "num" flag
 	maxInitNum = 30
@@ -38,6 +40,10 @@ type initParams struct {
 
 	insecureLocalStore bool
 
+	passwordFile    string
+	newPasswordFile string
+	importLocal     bool
+
 	output bool
 }
 
@@ -50,6 +56,10 @@ func (ip *initParams) validateFlags() error {
 		return ErrInvalidParams
 	}
 
+	if (ip.importLocal || ip.newPasswordFile != "") && ip.passwordFile == "" {
+		return ErrPasswordFileNotSet
+	}
+
 	return nil
 }
 
@@ -110,6 +120,33 @@ func (ip *initParams) setFlags(cmd *cobra.Command) {
 		"the flag indicating should the secrets stored on the local storage be encrypted",
 	)
 
+	cmd.Flags().StringVar(
+		&ip.passwordFile,
+		PasswordFileFlag,
+		"",
+		PasswordFileFlagDesc,
+	)
+
+	cmd.Flags().BoolVar(
+		&ip.importLocal,
+		importFlag,
+		false,
+		"the flag indicating whether the existing plaintext secrets in the data directory are encrypted "+
+			"by the password and removed, only with the password-file flag",
+	)
+
+	cmd.Flags().StringVar(
+		&ip.newPasswordFile,
+		newPasswordFileFlag,
+		"",
+		"the path to the file with the new password which the existing secrets are re-encrypted with, "+
+			"only with the password-file flag",
+	)
+
+	// the password file is about the local FS as secrets storage, the same as data-dir.
+	cmd.MarkFlagsMutuallyExclusive(PasswordFileFlag, AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(PasswordFileFlag, insecureLocalStoreFlag)
+
 	cmd.Flags().BoolVar(
 		&ip.output,
 		outputFlag,
@@ -132,7 +169,7 @@ func (ip *initParams) Execute() (Results, error) {
 			configDir = fmt.Sprintf("%s%d", ip.accountConfig, i+1)
 		}
 
-		secretManager, err := GetSecretsManager(dataDir, configDir, ip.insecureLocalStore)
+		secretManager, imported, err := ip.getSecretsManager(dataDir, configDir)
 		if err != nil {
 			return results, err
 		}
@@ -145,7 +182,7 @@ func (ip *initParams) Execute() (Results, error) {
 			}
 		}
 
-		res, err := ip.getResult(secretManager, gen)
+		res, err := ip.getResult(secretManager, gen, imported)
 		if err != nil {
 			return results, err
 		}
@@ -156,6 +193,37 @@ func (ip *initParams) Execute() (Results, error) {
 	return results, nil
 }
 
+// getSecretsManager returns the secrets manager, and imports the plaintext secrets
+// and changes the password of the encrypted local secrets, if requested
+func (ip *initParams) getSecretsManager(dataDir, configDir string) (secrets.SecretsManager, []string, error) {
+	if ip.passwordFile == "" {
+		secretManager, err := GetSecretsManager(dataDir, configDir, ip.insecureLocalStore)
+
+		return secretManager, nil, err
+	}
+
+	secretManager, err := GetEncryptedSecretsManager(dataDir, ip.passwordFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var imported []string
+
+	if ip.importLocal {
+		if imported, err = helper.ImportLocalSecrets(dataDir, secretManager); err != nil {
+			return nil, imported, fmt.Errorf("error importing secrets: %w", err)
+		}
+	}
+
+	if ip.newPasswordFile != "" {
+		if err := ChangeSecretsPassword(secretManager, ip.newPasswordFile); err != nil {
+			return nil, imported, fmt.Errorf("error re-encrypting secrets: %w", err)
+		}
+	}
+
+	return secretManager, imported, nil
+}
+
 func (ip *initParams) initKeys(secretsManager secrets.SecretsManager) ([]string, error) {
 	var generated []string
 
@@ -196,6 +264,7 @@ func (ip *initParams) initKeys(secretsManager secrets.SecretsManager) ([]string,
 func (ip *initParams) getResult(
 	secretsManager secrets.SecretsManager,
 	generated []string,
+	imported []string,
 ) (command.CommandResult, error) {
 	var (
 		res = &SecretsInitResult{}
@@ -236,6 +305,7 @@ func (ip *initParams) getResult(
 		}
 	}
 
+	res.Imported = strings.Join(imported, ", ")
 	res.Insecure = ip.insecureLocalStore
 
 	return res, nil
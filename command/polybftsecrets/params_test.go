@@ -85,7 +85,7 @@ func Test_getResult(t *testing.T) {
 	_, err = ip.initKeys(sm)
 	require.NoError(t, err)
 
-	res, err := ip.getResult(sm, []string{})
+	res, err := ip.getResult(sm, []string{}, nil)
 	require.NoError(t, err)
 
 	sir := res.(*SecretsInitResult) //nolint:forcetypeassert
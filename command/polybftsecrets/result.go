@@ -30,6 +30,7 @@ type SecretsInitResult struct {
 	BLSPrivateKey string        `json:"bls_private_key"`
 	Insecure      bool          `json:"insecure"`
 	Generated     string        `json:"generated"`
+	Imported      string        `json:"imported"`
 }
 
 func (r *SecretsInitResult) GetOutput() string {
@@ -69,6 +70,12 @@ func (r *SecretsInitResult) GetOutput() string {
 		buffer.WriteString("\n[WARNING: INSECURE LOCAL SECRETS - SHOULD NOT BE RUN IN PRODUCTION]\n")
 	}
 
+	if r.Imported != "" {
+		buffer.WriteString("\n[SECRETS IMPORTED]\n")
+		buffer.WriteString(r.Imported)
+		buffer.WriteString("\n")
+	}
+
 	if r.Generated != "" {
 		buffer.WriteString("\n[SECRETS GENERATED]\n")
 		buffer.WriteString(r.Generated)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
 	"github.com/0xPolygon/polygon-edge/secrets/helper"
 )
 
@@ -14,11 +15,13 @@ const (
 	AccountConfigFlag = "config"
 	PrivateKeyFlag    = "private-key"
 	ChainIDFlag       = "chain-id"
+	PasswordFileFlag  = "password-file"
 
 	AccountDirFlagDesc    = "the directory for the Polygon Edge data if the local FS is used"
 	AccountConfigFlagDesc = "the path to the SecretsManager config file, if omitted, the local FS secrets manager is used"
 	PrivateKeyFlagDesc    = "hex-encoded private key of the account which executes rootchain commands"
 	ChainIDFlagDesc       = "ID of child chain"
+	PasswordFileFlagDesc  = "the path to the file with the password which encrypts the secrets stored on the local FS"
 )
 
 // common errors for all polybft commands
@@ -26,6 +29,8 @@ var (
 	ErrInvalidNum                     = fmt.Errorf("num flag value should be between 1 and %d", maxInitNum)
 	ErrInvalidParams                  = errors.New("no config file or data directory passed in")
 	ErrUnsupportedType                = errors.New("unsupported secrets manager")
+	ErrPasswordFileNotSet             = errors.New("the secrets can be imported or re-encrypted only with a password file")
+	ErrNotEncryptedSecretsManager     = errors.New("the secrets manager doesn't support encrypted secrets")
 	ErrSecureLocalStoreNotImplemented = errors.New(
 		"use a secrets backend, or supply an --insecure flag " +
 			"to store the private keys locally on the filesystem, " +
@@ -57,3 +62,30 @@ func GetSecretsManager(dataPath, configPath string, insecureLocalStore bool) (se
 
 	return helper.SetupLocalSecretsManager(dataPath)
 }
+
+// GetEncryptedSecretsManager returns the secrets manager which stores the secrets on the local FS,
+// encrypted by the password read from the password file
+func GetEncryptedSecretsManager(dataPath, passwordFile string) (secrets.SecretsManager, error) {
+	password, err := encryptedlocal.ReadPasswordFile(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.SetupEncryptedLocalSecretsManager(dataPath, password)
+}
+
+// ChangeSecretsPassword re-encrypts the secrets of the secrets manager
+// with the password read from the new password file
+func ChangeSecretsPassword(secretsManager secrets.SecretsManager, newPasswordFile string) error {
+	encrypted, ok := secretsManager.(*encryptedlocal.EncryptedLocalSecretsManager)
+	if !ok {
+		return ErrNotEncryptedSecretsManager
+	}
+
+	newPassword, err := encryptedlocal.ReadPasswordFile(newPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	return encrypted.ChangePassword(newPassword)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
 	"github.com/0xPolygon/polygon-edge/secrets/helper"
 )
 
@@ -16,12 +17,16 @@ const (
 	networkFlag            = "network"
 	numFlag                = "num"
 	insecureLocalStoreFlag = "insecure"
+	passwordFileFlag       = "password-file"
+	newPasswordFileFlag    = "new-password-file"
+	importFlag             = "import"
 )
 
 var (
 	errInvalidConfig                  = errors.New("invalid secrets configuration")
 	errInvalidParams                  = errors.New("no config file or data directory passed in")
 	errUnsupportedType                = errors.New("unsupported secrets manager")
+	errPasswordFileNotSet             = errors.New("the secrets can be imported or re-encrypted only with a password file")
 	errSecureLocalStoreNotImplemented = errors.New(
 		"use a secrets backend, or supply an --insecure flag " +
 			"to store the private keys locally on the filesystem, " +
@@ -36,6 +41,10 @@ type initParams struct {
 	generatesNetwork   bool
 	insecureLocalStore bool
 
+	passwordFile    string
+	newPasswordFile string
+	importLocal     bool
+
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
 }
@@ -45,6 +54,10 @@ func (ip *initParams) validateFlags() error {
 		return errInvalidParams
 	}
 
+	if (ip.importLocal || ip.newPasswordFile != "") && ip.passwordFile == "" {
+		return errPasswordFileNotSet
+	}
+
 	return nil
 }
 
@@ -53,6 +66,11 @@ func (ip *initParams) initSecrets() error {
 		return err
 	}
 
+	// the existing secrets are only encrypted, no new secrets are generated
+	if ip.importLocal || ip.newPasswordFile != "" {
+		return ip.encryptSecrets()
+	}
+
 	if err := ip.initValidatorKey(); err != nil {
 		return err
 	}
@@ -72,6 +90,10 @@ func (ip *initParams) initSecretsManager() error {
 		return err
 	}
 
+	if ip.passwordFile != "" {
+		return ip.initEncryptedLocalSecretsManager()
+	}
+
 	return ip.initLocalSecretsManager()
 }
 
@@ -113,6 +135,49 @@ func (ip *initParams) initLocalSecretsManager() error {
 	return nil
 }
 
+func (ip *initParams) initEncryptedLocalSecretsManager() error {
+	password, err := encryptedlocal.ReadPasswordFile(ip.passwordFile)
+	if err != nil {
+		return err
+	}
+
+	// setup encrypted local secrets manager
+	encryptedLocal, err := helper.SetupEncryptedLocalSecretsManager(ip.dataDir, password)
+	if err != nil {
+		return err
+	}
+
+	ip.secretsManager = encryptedLocal
+
+	return nil
+}
+
+// encryptSecrets imports the plaintext secrets of the data directory,
+// and re-encrypts the secrets with the new password, if requested
+func (ip *initParams) encryptSecrets() error {
+	if ip.importLocal {
+		if _, err := helper.ImportLocalSecrets(ip.dataDir, ip.secretsManager); err != nil {
+			return err
+		}
+	}
+
+	if ip.newPasswordFile == "" {
+		return nil
+	}
+
+	encryptedLocal, ok := ip.secretsManager.(*encryptedlocal.EncryptedLocalSecretsManager)
+	if !ok {
+		return errUnsupportedType
+	}
+
+	newPassword, err := encryptedlocal.ReadPasswordFile(ip.newPasswordFile)
+	if err != nil {
+		return err
+	}
+
+	return encryptedLocal.ChangePassword(newPassword)
+}
+
 func (ip *initParams) initValidatorKey() error {
 	var err error
 
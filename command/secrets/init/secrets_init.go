@@ -91,6 +91,33 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"the flag indicating should the secrets stored on the local storage be encrypted",
 	)
+
+	cmd.Flags().StringVar(
+		&basicParams.passwordFile,
+		passwordFileFlag,
+		"",
+		"the path to the file with the password which encrypts the secrets stored on the local FS",
+	)
+
+	cmd.Flags().BoolVar(
+		&basicParams.importLocal,
+		importFlag,
+		false,
+		"the flag indicating whether the existing plaintext secrets in the data directory are encrypted "+
+			"by the password and removed, only with the password-file flag",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.newPasswordFile,
+		newPasswordFileFlag,
+		"",
+		"the path to the file with the new password which the existing secrets are re-encrypted with, "+
+			"only with the password-file flag",
+	)
+
+	// the password file is about the local FS as secrets storage, the same as data-dir.
+	cmd.MarkFlagsMutuallyExclusive(passwordFileFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(passwordFileFlag, insecureLocalStoreFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -144,6 +171,9 @@ func getParamsList() []initParams {
 			generatesBLS:       basicParams.generatesBLS,
 			generatesNetwork:   basicParams.generatesNetwork,
 			insecureLocalStore: basicParams.insecureLocalStore,
+			passwordFile:       basicParams.passwordFile,
+			newPasswordFile:    basicParams.newPasswordFile,
+			importLocal:        basicParams.importLocal,
 		}
 	}
 
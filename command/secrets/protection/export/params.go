@@ -0,0 +1,79 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fileFlag    = "file"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errInvalidDataDir = errors.New("no slashing protection database found in the data directory")
+)
+
+type exportParams struct {
+	dataDir string
+	file    string
+}
+
+func (ep *exportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		fileFlag,
+	}
+}
+
+func (ep *exportParams) validateFlags() error {
+	if !common.FileExists(ep.dbPath()) {
+		return errInvalidDataDir
+	}
+
+	return nil
+}
+
+func (ep *exportParams) dbPath() string {
+	return filepath.Join(ep.dataDir, secrets.ConsensusFolderLocal, slashingprotection.FileName)
+}
+
+func (ep *exportParams) exportData() error {
+	db, err := slashingprotection.Open(ep.dbPath())
+	if err != nil {
+		return fmt.Errorf("unable to open slashing protection database: %w", err)
+	}
+
+	defer db.Close()
+
+	file, err := os.OpenFile(ep.file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create interchange file: %w", err)
+	}
+
+	if err := db.Export(file); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("unable to export slashing protection data: %w", err)
+	}
+
+	return file.Close()
+}
+
+func (ep *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		DataDir: ep.dataDir,
+		File:    ep.file,
+	}
+}
@@ -0,0 +1,27 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ExportResult struct {
+	DataDir string `json:"data_dir"`
+	File    string `json:"file"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SLASHING PROTECTION EXPORT]\n")
+	buffer.WriteString("Exported slashing protection data successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data Dir|%s", r.DataDir),
+		fmt.Sprintf("File|%s", r.File),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,78 @@
+package protectionimport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fileFlag    = "file"
+)
+
+var (
+	params = &importParams{}
+)
+
+type importParams struct {
+	dataDir string
+	file    string
+
+	imported int
+}
+
+func (ip *importParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		fileFlag,
+	}
+}
+
+func (ip *importParams) validateFlags() error {
+	if !common.FileExists(ip.file) {
+		return fmt.Errorf("interchange file not found: %s", ip.file)
+	}
+
+	return nil
+}
+
+func (ip *importParams) importData() error {
+	consensusDir := filepath.Join(ip.dataDir, secrets.ConsensusFolderLocal)
+	if err := common.CreateDirSafe(consensusDir, 0750); err != nil {
+		return err
+	}
+
+	db, err := slashingprotection.Open(filepath.Join(consensusDir, slashingprotection.FileName))
+	if err != nil {
+		return fmt.Errorf("unable to open slashing protection database: %w", err)
+	}
+
+	defer db.Close()
+
+	file, err := os.Open(ip.file)
+	if err != nil {
+		return fmt.Errorf("unable to open interchange file: %w", err)
+	}
+
+	defer file.Close()
+
+	if ip.imported, err = db.Import(file); err != nil {
+		return fmt.Errorf("unable to import slashing protection data: %w", err)
+	}
+
+	return nil
+}
+
+func (ip *importParams) getResult() command.CommandResult {
+	return &ImportResult{
+		DataDir:  ip.dataDir,
+		File:     ip.file,
+		Imported: ip.imported,
+	}
+}
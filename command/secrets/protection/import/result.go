@@ -0,0 +1,29 @@
+package protectionimport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ImportResult struct {
+	DataDir  string `json:"data_dir"`
+	File     string `json:"file"`
+	Imported int    `json:"imported"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SLASHING PROTECTION IMPORT]\n")
+	buffer.WriteString("Imported slashing protection data successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data Dir|%s", r.DataDir),
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Imported Keys|%d", r.Imported),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,28 @@
+package protection
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/secrets/protection/export"
+	protectionimport "github.com/0xPolygon/polygon-edge/command/secrets/protection/import"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	protectionCmd := &cobra.Command{
+		Use: "slashing-protection",
+		Short: "Top level command for migrating the slashing protection data of the validator key. " +
+			"Only accepts subcommands.",
+	}
+
+	registerSubcommands(protectionCmd)
+
+	return protectionCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// secrets slashing-protection export
+		export.GetCommand(),
+		// secrets slashing-protection import
+		protectionimport.GetCommand(),
+	)
+}
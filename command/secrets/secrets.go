@@ -5,6 +5,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/secrets/generate"
 	initCmd "github.com/0xPolygon/polygon-edge/command/secrets/init"
 	"github.com/0xPolygon/polygon-edge/command/secrets/output"
+	"github.com/0xPolygon/polygon-edge/command/secrets/protection"
 	"github.com/spf13/cobra"
 )
 
@@ -29,5 +30,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		generate.GetCommand(),
 		// secrets output public data
 		output.GetCommand(),
+		// secrets slashing-protection
+		protection.GetCommand(),
 	)
 }
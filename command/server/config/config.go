@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/network"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
 )
@@ -29,11 +30,19 @@ type Config struct {
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCIPCPath           string     `json:"json_rpc_ipc_path" yaml:"json_rpc_ipc_path"`
+	JSONRPCIPCDisable        bool       `json:"json_rpc_ipc_disable" yaml:"json_rpc_ipc_disable"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
 	CorsAllowedOrigins       []string   `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
 
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+
+	NodeMode  string     `json:"node_mode" yaml:"node_mode"`
+	Pruning   *Pruning   `json:"pruning" yaml:"pruning"`
+	StateSync *StateSync `json:"state_sync" yaml:"state_sync"`
+
+	StateSnapshot *StateSnapshot `json:"state_snapshot" yaml:"state_snapshot"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -50,6 +59,12 @@ type Network struct {
 	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
 	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
 	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+
+	StaticPeers  []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
+	TrustedPeers []string `json:"trusted_peers,omitempty" yaml:"trusted_peers,omitempty"`
+
+	PrivatePeerIDs []string `json:"private_peer_ids,omitempty" yaml:"private_peer_ids,omitempty"`
+	PrivateMode    bool     `json:"private_mode" yaml:"private_mode"`
 }
 
 // TxPool defines the TxPool configuration params
@@ -57,6 +72,28 @@ type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	Journal            bool   `json:"journal" yaml:"journal"`
+	JournalRotation    uint64 `json:"journal_rotation" yaml:"journal_rotation"`
+}
+
+// Pruning defines the state pruning configuration params of the full and the pruned node modes
+type Pruning struct {
+	RetainBlocks       uint64 `json:"retain_blocks" yaml:"retain_blocks"`
+	CheckpointInterval uint64 `json:"checkpoint_interval" yaml:"checkpoint_interval"`
+	Interval           uint64 `json:"interval" yaml:"interval"`
+}
+
+// StateSync defines the state sync configuration params,
+// the state sync is disabled if the trusted block number is zero
+type StateSync struct {
+	TrustedBlockNumber uint64 `json:"trusted_block_number" yaml:"trusted_block_number"`
+	TrustedBlockHash   string `json:"trusted_block_hash" yaml:"trusted_block_hash"`
+}
+
+// StateSnapshot defines the flat state snapshot configuration params
+type StateSnapshot struct {
+	Enabled    bool   `json:"enabled" yaml:"enabled"`
+	DiffLayers uint64 `json:"diff_layers" yaml:"diff_layers"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -79,6 +116,22 @@ const (
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
+
+	// DefaultTxPoolJournalRotation number of seconds between two rotations of the txpool journal
+	DefaultTxPoolJournalRotation uint64 = 3600
+
+	// DefaultPruningRetainBlocks number of the latest blocks whose state is kept by the state pruning
+	DefaultPruningRetainBlocks uint64 = 128
+
+	// DefaultPruningCheckpointInterval number of blocks between two checkpoint blocks,
+	// whose state is kept by the full node
+	DefaultPruningCheckpointInterval uint64 = 10000
+
+	// DefaultPruningInterval number of blocks between two runs of the state pruning
+	DefaultPruningInterval uint64 = 1000
+
+	// DefaultStateSnapshotDiffLayers number of the latest blocks whose flat state is kept in the memory
+	DefaultStateSnapshotDiffLayers uint64 = 128
 )
 
 // DefaultConfig returns the default server configuration
@@ -105,6 +158,8 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			Journal:            true,
+			JournalRotation:    DefaultTxPoolJournalRotation,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -116,6 +171,17 @@ func DefaultConfig() *Config {
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
 		Relayer:                  false,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		NodeMode:                 string(itrie.ArchiveNodeMode),
+		Pruning: &Pruning{
+			RetainBlocks:       DefaultPruningRetainBlocks,
+			CheckpointInterval: DefaultPruningCheckpointInterval,
+			Interval:           DefaultPruningInterval,
+		},
+		StateSync: &StateSync{},
+		StateSnapshot: &StateSnapshot{
+			Enabled:    false,
+			DiffLayers: DefaultStateSnapshotDiffLayers,
+		},
 	}
 }
 
@@ -1,10 +1,14 @@
 package server
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math"
 	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 
@@ -12,14 +16,22 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var (
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errInvalidPruningInterval = errors.New("pruning interval must be greater than zero")
+	errInvalidCheckpoint      = errors.New("pruning checkpoint interval must be greater than zero in the full node mode")
+	errInvalidStateSyncHash   = errors.New("invalid state sync trusted block hash")
+	errInvalidSnapshotLayers  = errors.New("state snapshot diff layers must be greater than zero")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -49,6 +61,22 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initNodeMode(); err != nil {
+		return err
+	}
+
+	if err := p.initPruning(); err != nil {
+		return err
+	}
+
+	if err := p.initStateSync(); err != nil {
+		return err
+	}
+
+	if err := p.initStateSnapshot(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
@@ -69,6 +97,70 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initNodeMode() error {
+	if p.rawConfig.NodeMode == "" {
+		p.rawConfig.NodeMode = config.DefaultConfig().NodeMode
+	}
+
+	var parseErr error
+
+	if p.nodeMode, parseErr = itrie.ParseNodeMode(p.rawConfig.NodeMode); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
+func (p *serverParams) initPruning() error {
+	if p.rawConfig.Pruning == nil {
+		p.rawConfig.Pruning = config.DefaultConfig().Pruning
+	}
+
+	if !p.nodeMode.IsPruned() {
+		return nil
+	}
+
+	if p.rawConfig.Pruning.Interval == 0 {
+		return errInvalidPruningInterval
+	}
+
+	if p.nodeMode == itrie.FullNodeMode && p.rawConfig.Pruning.CheckpointInterval == 0 {
+		return errInvalidCheckpoint
+	}
+
+	return nil
+}
+
+func (p *serverParams) initStateSync() error {
+	if p.rawConfig.StateSync == nil || p.rawConfig.StateSync.TrustedBlockNumber == 0 {
+		return nil
+	}
+
+	hash, err := hex.DecodeHex(p.rawConfig.StateSync.TrustedBlockHash)
+	if err != nil || len(hash) != types.HashLength {
+		return errInvalidStateSyncHash
+	}
+
+	p.stateSync = &syncer.StateSyncConfig{
+		TrustedBlockNumber: p.rawConfig.StateSync.TrustedBlockNumber,
+		TrustedBlockHash:   types.BytesToHash(hash),
+	}
+
+	return nil
+}
+
+func (p *serverParams) initStateSnapshot() error {
+	if p.rawConfig.StateSnapshot == nil {
+		p.rawConfig.StateSnapshot = config.DefaultConfig().StateSnapshot
+	}
+
+	if p.rawConfig.StateSnapshot.Enabled && p.rawConfig.StateSnapshot.DiffLayers == 0 {
+		return errInvalidSnapshotLayers
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
@@ -216,10 +308,16 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initPeerClasses(); err != nil {
+		return err
+	}
+
 	if err := p.initJSONRPCAddress(); err != nil {
 		return err
 	}
 
+	p.initJSONRPCIPCPath()
+
 	return p.initGRPCAddress()
 }
 
@@ -283,6 +381,47 @@ func (p *serverParams) initDNSAddress() error {
 	return nil
 }
 
+func (p *serverParams) initPeerClasses() error {
+	var parseErr error
+
+	if p.staticPeers, parseErr = parsePeerAddrs(p.rawConfig.Network.StaticPeers); parseErr != nil {
+		return fmt.Errorf("invalid static peer: %w", parseErr)
+	}
+
+	if p.trustedPeers, parseErr = parsePeerAddrs(p.rawConfig.Network.TrustedPeers); parseErr != nil {
+		return fmt.Errorf("invalid trusted peer: %w", parseErr)
+	}
+
+	p.privatePeerIDs = make([]peer.ID, 0, len(p.rawConfig.Network.PrivatePeerIDs))
+
+	for _, rawID := range p.rawConfig.Network.PrivatePeerIDs {
+		id, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid private peer ID %s: %w", rawID, err)
+		}
+
+		p.privatePeerIDs = append(p.privatePeerIDs, id)
+	}
+
+	return nil
+}
+
+// parsePeerAddrs parses the libp2p addresses of the peers, including their IDs
+func parsePeerAddrs(rawAddrs []string) ([]*peer.AddrInfo, error) {
+	peers := make([]*peer.AddrInfo, 0, len(rawAddrs))
+
+	for _, rawAddr := range rawAddrs {
+		info, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rawAddr, err)
+		}
+
+		peers = append(peers, info)
+	}
+
+	return peers, nil
+}
+
 func (p *serverParams) initJSONRPCAddress() error {
 	var parseErr error
 
@@ -296,6 +435,36 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+// initJSONRPCIPCPath sets the path of the json-rpc IPC socket,
+// which is placed inside the data directory unless it's set explicitly
+func (p *serverParams) initJSONRPCIPCPath() {
+	switch {
+	case p.rawConfig.JSONRPCIPCDisable:
+		p.jsonRPCIPCPath = ""
+	case p.rawConfig.JSONRPCIPCPath != "":
+		p.jsonRPCIPCPath = p.rawConfig.JSONRPCIPCPath
+	case runtime.GOOS == "windows":
+		// the IPC on windows is served over a named pipe
+		p.jsonRPCIPCPath = `\\.\pipe\` + jsonRPCIPCPipeName(p.rawConfig.DataDir)
+	default:
+		p.jsonRPCIPCPath = filepath.Join(p.rawConfig.DataDir, defaultJSONRPCIPCFile)
+	}
+}
+
+// jsonRPCIPCPipeName returns the name of the json-rpc named pipe of the data directory.
+// The named pipes are shared by the whole host, so the name is derived from the data directory,
+// which keeps apart the pipes of the nodes running on the same host
+func jsonRPCIPCPipeName(dataDir string) string {
+	if absDataDir, err := filepath.Abs(dataDir); err == nil {
+		dataDir = absDataDir
+	}
+
+	// the paths on windows are case insensitive
+	dataDirHash := sha256.Sum256([]byte(strings.ToLower(filepath.Clean(dataDir))))
+
+	return fmt.Sprintf("%x-%s", dataDirHash[:8], defaultJSONRPCIPCFile)
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
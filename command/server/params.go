@@ -3,13 +3,17 @@ package server
 import (
 	"errors"
 	"net"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -25,11 +29,19 @@ const (
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	staticPeersFlag              = "static-peers"
+	trustedPeersFlag             = "trusted-peers"
+	privatePeerIDsFlag           = "private-peer-ids"
+	privateModeFlag              = "private-mode"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCIPCPathFlag           = "json-rpc-ipc-path"
+	jsonRPCIPCDisableFlag        = "json-rpc-ipc-disable"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	txPoolJournalFlag            = "txpool-journal"
+	txPoolJournalRotationFlag    = "txpool-journal-rotation"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -40,6 +52,17 @@ const (
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+
+	nodeModeFlag                  = "node-mode"
+	pruningRetainBlocksFlag       = "prune-retain-blocks"
+	pruningCheckpointIntervalFlag = "prune-checkpoint-interval"
+	pruningIntervalFlag           = "prune-interval"
+
+	stateSyncBlockNumberFlag = "state-sync-block-number"
+	stateSyncBlockHashFlag   = "state-sync-block-hash"
+
+	stateSnapshotFlag           = "state-snapshot"
+	stateSnapshotDiffLayersFlag = "state-snapshot-diff-layers"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -50,6 +73,9 @@ const (
 
 const (
 	unsetPeersValue = -1
+
+	// defaultJSONRPCIPCFile is the name of the json-rpc IPC socket
+	defaultJSONRPCIPCFile = "jsonrpc.ipc"
 )
 
 var (
@@ -58,6 +84,10 @@ var (
 			Telemetry: &config.Telemetry{},
 			Network:   &config.Network{},
 			TxPool:    &config.TxPool{},
+			Pruning:   &config.Pruning{},
+			StateSync: &config.StateSync{},
+
+			StateSnapshot: &config.StateSnapshot{},
 		},
 	}
 )
@@ -74,8 +104,12 @@ type serverParams struct {
 	prometheusAddress *net.TCPAddr
 	natAddress        net.IP
 	dnsAddress        multiaddr.Multiaddr
+	staticPeers       []*peer.AddrInfo
+	trustedPeers      []*peer.AddrInfo
+	privatePeerIDs    []peer.ID
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	jsonRPCIPCPath    string
 
 	blockGasTarget uint64
 	devInterval    uint64
@@ -89,6 +123,9 @@ type serverParams struct {
 	logFileLocation string
 
 	relayer bool
+
+	nodeMode  itrie.NodeMode
+	stateSync *syncer.StateSyncConfig
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -144,6 +181,22 @@ func (p *serverParams) setJSONLogFormat(jsonLogFormat bool) {
 	p.rawConfig.JSONLogFormat = jsonLogFormat
 }
 
+// generatePruningConfig returns the state pruning config of the node mode,
+// the pruned node doesn't keep the states of the checkpoint blocks
+func (p *serverParams) generatePruningConfig() *server.Pruning {
+	pruning := &server.Pruning{
+		RetainBlocks:       p.rawConfig.Pruning.RetainBlocks,
+		CheckpointInterval: p.rawConfig.Pruning.CheckpointInterval,
+		Interval:           p.rawConfig.Pruning.Interval,
+	}
+
+	if p.nodeMode == itrie.PrunedNodeMode {
+		pruning.CheckpointInterval = 0
+	}
+
+	return pruning
+}
+
 func (p *serverParams) generateConfig() *server.Config {
 	return &server.Config{
 		Chain: p.genesisConfig,
@@ -152,6 +205,7 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.rawConfig.CorsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			IPCPath:                  p.jsonRPCIPCPath,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -168,19 +222,35 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
+			StaticPeers:      p.staticPeers,
+			TrustedPeers:     p.trustedPeers,
+			PrivatePeerIDs:   p.privatePeerIDs,
+			PrivateMode:      p.rawConfig.Network.PrivateMode,
 		},
-		DataDir:            p.rawConfig.DataDir,
-		Seal:               p.rawConfig.ShouldSeal,
-		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
-		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
-		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
-		SecretsManager:     p.secretsConfig,
-		RestoreFile:        p.getRestoreFilePath(),
-		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
-		JSONLogFormat:      p.rawConfig.JSONLogFormat,
-		LogFilePath:        p.logFileLocation,
+		DataDir:               p.rawConfig.DataDir,
+		Seal:                  p.rawConfig.ShouldSeal,
+		PriceLimit:            p.rawConfig.TxPool.PriceLimit,
+		MaxSlots:              p.rawConfig.TxPool.MaxSlots,
+		MaxAccountEnqueued:    p.rawConfig.TxPool.MaxAccountEnqueued,
+		TxPoolJournal:         p.rawConfig.TxPool.Journal,
+		TxPoolJournalRotation: time.Duration(p.rawConfig.TxPool.JournalRotation) * time.Second,
+		SecretsManager:        p.secretsConfig,
+		RestoreFile:           p.getRestoreFilePath(),
+		LogLevel:              hclog.LevelFromString(p.rawConfig.LogLevel),
+		JSONLogFormat:         p.rawConfig.JSONLogFormat,
+		LogFilePath:           p.logFileLocation,
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+
+		NodeMode: p.nodeMode,
+		Pruning:  p.generatePruningConfig(),
+
+		StateSync: p.stateSync,
+
+		StateSnapshot: &server.StateSnapshot{
+			Enabled:    p.rawConfig.StateSnapshot.Enabled,
+			DiffLayers: p.rawConfig.StateSnapshot.DiffLayers,
+		},
 	}
 }
@@ -161,6 +161,37 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeersFlag,
+		defaultConfig.Network.StaticPeers,
+		"the libp2p addresses of the peers the connections are always kept with, "+
+			"regardless of the max peers limits",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		defaultConfig.Network.TrustedPeers,
+		"the libp2p addresses of the peers which are exempt from the max peers limits and the bans",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.PrivatePeerIDs,
+		privatePeerIDsFlag,
+		defaultConfig.Network.PrivatePeerIDs,
+		"the libp2p IDs of the peers which are never advertised to the other peers, "+
+			"used by the sentry nodes for their private validators",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Network.PrivateMode,
+		privateModeFlag,
+		defaultConfig.Network.PrivateMode,
+		"the flag indicating that the client connects only to its static and trusted peers, "+
+			"with the inbound connections and the peer discovery disabled",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -185,6 +216,20 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.Journal,
+		txPoolJournalFlag,
+		defaultConfig.TxPool.Journal,
+		"keep the journal of the local transactions, so they are added back to the pool after a restart",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.JournalRotation,
+		txPoolJournalRotationFlag,
+		defaultConfig.TxPool.JournalRotation,
+		"interval in seconds between two rewrites of the txpool journal without the mined transactions",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -207,6 +252,21 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCIPCPath,
+		jsonRPCIPCPathFlag,
+		defaultConfig.JSONRPCIPCPath,
+		"the path of the json-rpc IPC socket (default: <data-dir>/jsonrpc.ipc, "+
+			"the named pipe of the data directory on windows)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCIPCDisable,
+		jsonRPCIPCDisableFlag,
+		defaultConfig.JSONRPCIPCDisable,
+		"the flag indicating that the json-rpc IPC server is disabled",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -228,6 +288,64 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.NodeMode,
+		nodeModeFlag,
+		defaultConfig.NodeMode,
+		"the historical states kept by the node: archive (all), full (the recent and the checkpoint blocks) "+
+			"or pruned (the recent blocks only)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Pruning.RetainBlocks,
+		pruningRetainBlocksFlag,
+		defaultConfig.Pruning.RetainBlocks,
+		"number of the latest blocks whose state is kept by the state pruning",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Pruning.CheckpointInterval,
+		pruningCheckpointIntervalFlag,
+		defaultConfig.Pruning.CheckpointInterval,
+		"keep the state of every block whose number is a multiple of the interval in the full node mode",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Pruning.Interval,
+		pruningIntervalFlag,
+		defaultConfig.Pruning.Interval,
+		"number of blocks between two runs of the state pruning",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateSync.TrustedBlockNumber,
+		stateSyncBlockNumberFlag,
+		defaultConfig.StateSync.TrustedBlockNumber,
+		"number of the trusted block whose state is synced from the peers instead of executing the blocks, "+
+			"value of 0 disables the state sync",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.StateSync.TrustedBlockHash,
+		stateSyncBlockHashFlag,
+		defaultConfig.StateSync.TrustedBlockHash,
+		"hash of the trusted block whose state is synced from the peers",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSnapshot.Enabled,
+		stateSnapshotFlag,
+		defaultConfig.StateSnapshot.Enabled,
+		"keep the flat snapshot of the state, which is read instead of the state trie",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateSnapshot.DiffLayers,
+		stateSnapshotDiffLayersFlag,
+		defaultConfig.StateSnapshot.DiffLayers,
+		"number of the latest blocks whose flat state is kept in the memory before it's written to the disk",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -0,0 +1,42 @@
+package liveness
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+var params livenessParams
+
+func GetCommand() *cobra.Command {
+	livenessCmd := &cobra.Command{
+		Use:     "liveness",
+		Short:   "Returns the number of the latest blocks signed by each validator on child chain",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(livenessCmd)
+
+	return livenessCmd
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := params.getLiveness()
+	if err != nil {
+		return err
+	}
+
+	outputter.WriteCommandResult(result)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package liveness
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+// getLivenessFn is JSON RPC endpoint which returns the liveness of the validators
+const getLivenessFn = "validator_getLiveness"
+
+type livenessParams struct {
+	jsonRPC string
+}
+
+// livenessResponse is the response of the liveness JSON RPC endpoint
+type livenessResponse struct {
+	FromBlock  string `json:"fromBlock"`
+	ToBlock    string `json:"toBlock"`
+	Threshold  string `json:"threshold"`
+	Validators []struct {
+		Address        types.Address `json:"address"`
+		SignedBlocks   string        `json:"signedBlocks"`
+		ExpectedBlocks string        `json:"expectedBlocks"`
+	} `json:"validators"`
+}
+
+func (lp *livenessParams) getLiveness() (*LivenessResult, error) {
+	client, err := jsonrpc.NewClient(lp.jsonRPC)
+	if err != nil {
+		return nil, fmt.Errorf("could not create JSON RPC client: %w", err)
+	}
+
+	defer client.Close()
+
+	var response livenessResponse
+	if err := client.Call(getLivenessFn, &response); err != nil {
+		return nil, fmt.Errorf("failed to get validators liveness: %w", err)
+	}
+
+	result := &LivenessResult{
+		Validators: make([]*ValidatorLiveness, len(response.Validators)),
+	}
+
+	for _, field := range []struct {
+		value  *string
+		target *uint64
+	}{
+		{&response.FromBlock, &result.FromBlock},
+		{&response.ToBlock, &result.ToBlock},
+		{&response.Threshold, &result.Threshold},
+	} {
+		if *field.target, err = common.ParseUint64orHex(field.value); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, v := range response.Validators {
+		liveness := &ValidatorLiveness{Address: v.Address.String()}
+
+		if liveness.SignedBlocks, err = common.ParseUint64orHex(&v.SignedBlocks); err != nil {
+			return nil, err
+		}
+
+		if liveness.ExpectedBlocks, err = common.ParseUint64orHex(&v.ExpectedBlocks); err != nil {
+			return nil, err
+		}
+
+		result.Validators[i] = liveness
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package liveness
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type LivenessResult struct {
+	FromBlock  uint64               `json:"fromBlock"`
+	ToBlock    uint64               `json:"toBlock"`
+	Threshold  uint64               `json:"threshold"`
+	Validators []*ValidatorLiveness `json:"validators"`
+}
+
+type ValidatorLiveness struct {
+	Address        string `json:"address"`
+	SignedBlocks   uint64 `json:"signedBlocks"`
+	ExpectedBlocks uint64 `json:"expectedBlocks"`
+}
+
+func (r *LivenessResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	threshold := "disabled"
+	if r.Threshold > 0 {
+		threshold = fmt.Sprintf("%d%%", r.Threshold)
+	}
+
+	buffer.WriteString("\n[VALIDATORS LIVENESS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Blocks|%d - %d", r.FromBlock, r.ToBlock),
+		fmt.Sprintf("Threshold|%s", threshold),
+	}))
+	buffer.WriteString("\n\n")
+
+	rows := make([]string, 0, len(r.Validators)+1)
+	rows = append(rows, "Address|Signed Blocks|Expected Blocks|Uptime")
+
+	for _, v := range r.Validators {
+		uptime := "-"
+		if v.ExpectedBlocks > 0 {
+			uptime = fmt.Sprintf("%.2f%%", float64(v.SignedBlocks)*100/float64(v.ExpectedBlocks))
+		}
+
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%s", v.Address, v.SignedBlocks, v.ExpectedBlocks, uptime))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -78,6 +80,11 @@ type Params struct {
 	BlockTime      uint64
 
 	NumBlockConfirmations uint64
+
+	// StateStorage is the storage of the state trie, it's used by the syncer to serve and sync the state
+	StateStorage itrie.Storage
+	// StateSync is the configuration of the state sync, it's disabled if nil
+	StateSync *syncer.StateSyncConfig
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -90,4 +97,36 @@ type BridgeDataProvider interface {
 
 	// GetStateSyncProof retrieves the StateSync proof
 	GetStateSyncProof(stateSyncID uint64) (types.Proof, error)
+
+	// GetCheckpointedBlockNumber returns the number of the latest block checkpointed to the rootchain
+	GetCheckpointedBlockNumber() (uint64, error)
+}
+
+// LivenessDataProvider is an interface implemented by the consensus mechanisms
+// which track the liveness of the validators
+type LivenessDataProvider interface {
+	// GetValidatorsLiveness returns the liveness of the validators over the latest tracked blocks
+	GetValidatorsLiveness() (*ValidatorsLiveness, error)
+}
+
+// ValidatorsLiveness is the liveness of the validators over a window of blocks
+type ValidatorsLiveness struct {
+	// FromBlock is the first block of the window
+	FromBlock uint64
+	// ToBlock is the last block of the window
+	ToBlock uint64
+	// Threshold is the minimum percentage of the expected blocks a validator has to sign
+	// to stay in the validator set, zero if the validators are never excluded
+	Threshold uint64
+	// Validators is the liveness of each validator expected to sign the blocks of the window
+	Validators []*ValidatorLiveness
+}
+
+// ValidatorLiveness is the liveness of a single validator
+type ValidatorLiveness struct {
+	Address types.Address
+	// SignedBlocks is the number of the blocks signed by the validator
+	SignedBlocks uint64
+	// ExpectedBlocks is the number of the blocks the validator was expected to sign
+	ExpectedBlocks uint64
 }
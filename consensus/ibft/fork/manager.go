@@ -2,9 +2,12 @@ package fork
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -71,6 +74,9 @@ type ForkManager struct {
 	keyManagers     map[validators.ValidatorType]signer.KeyManager
 	validatorStores map[store.SourceType]ValidatorStore
 	hooksRegisters  map[IBFTType]HooksRegister
+
+	// protection is the slashing protection database of the validator key
+	protection *slashingprotection.DB
 }
 
 // NewForkManager is a constructor of ForkManager
@@ -118,6 +124,13 @@ func (m *ForkManager) Initialize() error {
 
 	m.initializeHooksRegisters()
 
+	protection, err := slashingprotection.Open(filepath.Join(m.filePath, slashingprotection.FileName))
+	if err != nil {
+		return fmt.Errorf("failed to open slashing protection database: %w", err)
+	}
+
+	m.protection = protection
+
 	return nil
 }
 
@@ -129,6 +142,12 @@ func (m *ForkManager) Close() error {
 		}
 	}
 
+	if m.protection != nil {
+		if err := m.protection.Close(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -147,10 +166,13 @@ func (m *ForkManager) GetSigner(height uint64) (signer.Signer, error) {
 		}
 	}
 
-	return signer.NewSigner(
+	ibftSigner := signer.NewSigner(
 		keyManager,
 		parentKeyManager,
-	), nil
+	)
+	ibftSigner.SetSlashingProtection(m.protection)
+
+	return ibftSigner, nil
 }
 
 // GetValidatorStore returns a proper validator set at specified height
@@ -274,12 +274,14 @@ func TestNewForkManager(t *testing.T) {
 			}
 		)
 
+		dirPath := createTestTempDirectory(t)
+
 		fm, err := NewForkManager(
 			logger,
 			nil,
 			nil,
 			secretManager,
-			"",
+			dirPath,
 			epochSize,
 			map[string]interface{}{
 				"type":           "PoS",
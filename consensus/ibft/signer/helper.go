@@ -99,6 +99,10 @@ func NewKeyManagerFromType(
 	secretManager secrets.SecretsManager,
 	validatorType validators.ValidatorType,
 ) (KeyManager, error) {
+	if remote, ok := secretManager.(secrets.Signer); ok {
+		return NewRemoteKeyManager(remote, validatorType)
+	}
+
 	switch validatorType {
 	case validators.ECDSAValidatorType:
 		return NewECDSAKeyManager(secretManager)
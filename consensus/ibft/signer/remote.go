@@ -0,0 +1,48 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+// RemoteECDSAKeyManager is a module that signs by the ECDSA key held by the remote signer,
+// so the key never lives on the node. The signatures are verified the same way as ECDSAKeyManager does
+type RemoteECDSAKeyManager struct {
+	*ECDSAKeyManager
+
+	remote secrets.Signer
+}
+
+// NewRemoteKeyManager initializes KeyManager of the given type which signs by the remote signer
+func NewRemoteKeyManager(remote secrets.Signer, validatorType validators.ValidatorType) (KeyManager, error) {
+	if validatorType != validators.ECDSAValidatorType {
+		return nil, fmt.Errorf("unsupported validator type by remote signer: %s", validatorType)
+	}
+
+	address, err := remote.Address()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteECDSAKeyManager{
+		ECDSAKeyManager: &ECDSAKeyManager{address: address},
+		remote:          remote,
+	}, nil
+}
+
+// SignProposerSeal signs the given message by the remote signer for ProposerSeal
+func (s *RemoteECDSAKeyManager) SignProposerSeal(message []byte) ([]byte, error) {
+	return s.remote.SignDigest(message)
+}
+
+// SignCommittedSeal signs the given message by the remote signer for committed seal
+func (s *RemoteECDSAKeyManager) SignCommittedSeal(message []byte) ([]byte, error) {
+	return s.remote.SignDigest(message)
+}
+
+// SignIBFTMessage signs the given message by the remote signer
+func (s *RemoteECDSAKeyManager) SignIBFTMessage(msg []byte) ([]byte, error) {
+	return s.remote.SignDigest(msg)
+}
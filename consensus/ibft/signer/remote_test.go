@@ -0,0 +1,65 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRemoteSigner is the remote signer holding the ECDSA key in the test process
+type testRemoteSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testRemoteSigner) Address() (types.Address, error) {
+	return crypto.PubKeyToAddress(&s.key.PublicKey), nil
+}
+
+func (s *testRemoteSigner) SignDigest(digest []byte) ([]byte, error) {
+	return crypto.Sign(s.key, digest)
+}
+
+func (s *testRemoteSigner) SignWithDomain(_, _ []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func TestNewRemoteKeyManager(t *testing.T) {
+	t.Parallel()
+
+	testKey, _ := newTestECDSAKey(t)
+	remote := &testRemoteSigner{key: testKey}
+
+	_, err := NewRemoteKeyManager(remote, validators.BLSValidatorType)
+	assert.Error(t, err)
+
+	keyManager, err := NewRemoteKeyManager(remote, validators.ECDSAValidatorType)
+	require.NoError(t, err)
+
+	address := crypto.PubKeyToAddress(&testKey.PublicKey)
+	assert.Equal(t, address, keyManager.Address())
+	assert.Equal(t, validators.ECDSAValidatorType, keyManager.Type())
+
+	// the signatures equal the ones of the local key
+	localKeyManager := NewECDSAKeyManagerFromKey(testKey)
+	msg := crypto.Keccak256([]byte("test"))
+
+	seal, err := keyManager.SignCommittedSeal(msg)
+	require.NoError(t, err)
+
+	localSeal, err := localKeyManager.SignCommittedSeal(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, localSeal, seal)
+
+	assert.NoError(t, keyManager.VerifyCommittedSeal(
+		validators.NewECDSAValidatorSet(validators.NewECDSAValidator(address)),
+		address,
+		seal,
+		msg,
+	))
+}
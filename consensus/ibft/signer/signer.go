@@ -2,10 +2,14 @@ package signer
 
 import (
 	"errors"
+	"fmt"
 
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -76,6 +80,9 @@ type Signer interface {
 type SignerImpl struct {
 	keyManager       KeyManager
 	parentKeyManager KeyManager
+
+	// protection is consulted before signing the IBFT messages, if set
+	protection *slashingprotection.DB
 }
 
 // NewSigner is a constructor of SignerImpl
@@ -89,6 +96,12 @@ func NewSigner(
 	}
 }
 
+// SetSlashingProtection sets the slashing protection database,
+// which is consulted before signing the IBFT messages
+func (s *SignerImpl) SetSlashingProtection(protection *slashingprotection.DB) {
+	s.protection = protection
+}
+
 // Type returns that validator type the signer expects
 func (s *SignerImpl) Type() validators.ValidatorType {
 	return s.keyManager.Type()
@@ -300,7 +313,26 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 
 // SignIBFTMessage signs arbitrary message
 func (s *SignerImpl) SignIBFTMessage(msg []byte) ([]byte, error) {
-	return s.keyManager.SignIBFTMessage(crypto.Keccak256(msg))
+	digest := crypto.Keccak256(msg)
+
+	// the message is recorded as signed before the signature is released
+	if s.protection != nil {
+		var message protoIBFT.Message
+		if err := proto.Unmarshal(msg, &message); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal IBFT message: %w", err)
+		}
+
+		record, err := slashingprotection.NewRecord(&message, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.protection.CheckAndRecord(s.keyManager.Address(), record); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.keyManager.SignIBFTMessage(digest)
 }
 
 // EcrecoverFromIBFTMessage recovers signer address from given signature and digest
@@ -4,13 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"path/filepath"
 	"testing"
 
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -1195,6 +1200,49 @@ func TestSignerSignIBFTMessage(t *testing.T) {
 	)
 }
 
+func TestSignerSignIBFTMessageWithSlashingProtection(t *testing.T) {
+	t.Parallel()
+
+	protection, err := slashingprotection.Open(filepath.Join(t.TempDir(), slashingprotection.FileName))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = protection.Close()
+	})
+
+	ecdsaKeyManager, _ := newTestECDSAKeyManager(t)
+
+	signer := NewSigner(ecdsaKeyManager, nil)
+	signer.SetSlashingProtection(protection)
+
+	newPrepare := func(proposalHash []byte) []byte {
+		raw, err := proto.Marshal(&protoIBFT.Message{
+			View: &protoIBFT.View{Height: 3, Round: 0},
+			From: ecdsaKeyManager.Address().Bytes(),
+			Type: protoIBFT.MessageType_PREPARE,
+			Payload: &protoIBFT.Message_PrepareData{
+				PrepareData: &protoIBFT.PrepareMessage{ProposalHash: proposalHash},
+			},
+		})
+		require.NoError(t, err)
+
+		return raw
+	}
+
+	_, err = signer.SignIBFTMessage(newPrepare([]byte{1}))
+	assert.NoError(t, err)
+
+	_, err = signer.SignIBFTMessage(newPrepare([]byte{1}))
+	assert.NoError(t, err)
+
+	_, err = signer.SignIBFTMessage(newPrepare([]byte{2}))
+	assert.ErrorIs(t, err, slashingprotection.ErrConflictingMessage)
+
+	// the raw message which is not an IBFT message is never signed
+	_, err = signer.SignIBFTMessage([]byte{0xff})
+	assert.Error(t, err)
+}
+
 func TestEcrecoverFromIBFTMessage(t *testing.T) {
 	t.Parallel()
 
@@ -20,6 +20,13 @@ func (g *gossipTransport) Multicast(msg *proto.Message) error {
 }
 
 func (i *backendIBFT) Multicast(msg *proto.Message) {
+	// the message is not built when the slashing protection refuses to sign it
+	if msg == nil {
+		i.logger.Debug("skipped gossip of the message which is not signed")
+
+		return
+	}
+
 	if err := i.transport.Multicast(msg); err != nil {
 		i.logger.Error("fail to gossip", "err", err)
 	}
@@ -35,7 +42,7 @@ func (i *backendIBFT) setupTransport() error {
 
 	// Subscribe to the newly created topic
 	if err := topic.Subscribe(
-		func(obj interface{}, _ peer.ID) {
+		func(obj interface{}, from peer.ID) {
 			if !i.isActiveValidator() {
 				return
 			}
@@ -47,6 +54,13 @@ func (i *backendIBFT) setupTransport() error {
 				return
 			}
 
+			if msg.GetView() == nil {
+				i.logger.Debug("validator message without view received", "peer", from)
+				i.network.PenalizePeer(from, network.PenaltyInvalidConsensusMessage, "consensus message without view")
+
+				return
+			}
+
 			i.consensus.AddMessage(msg)
 
 			i.logger.Debug(
@@ -0,0 +1,61 @@
+package ibft
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTransport struct {
+	messages []*proto.Message
+}
+
+func (m *mockTransport) Multicast(msg *proto.Message) error {
+	m.messages = append(m.messages, msg)
+
+	return nil
+}
+
+func TestIBFTBackend_Multicast_RefusedSignature(t *testing.T) {
+	t.Parallel()
+
+	protection, err := slashingprotection.Open(filepath.Join(t.TempDir(), slashingprotection.FileName))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = protection.Close()
+	})
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	currentSigner := signer.NewSigner(signer.NewECDSAKeyManagerFromKey(key), nil)
+	currentSigner.SetSlashingProtection(protection)
+
+	transport := &mockTransport{}
+	backend := &backendIBFT{
+		logger:        hclog.NewNullLogger(),
+		currentSigner: currentSigner,
+		transport:     transport,
+	}
+
+	view := &proto.View{Height: 3, Round: 0}
+
+	backend.Multicast(backend.BuildPrepareMessage([]byte{1}, view))
+
+	// the conflicting prepare message is refused by the slashing protection
+	refused := backend.BuildPrepareMessage([]byte{2}, view)
+	assert.Nil(t, refused)
+
+	backend.Multicast(refused)
+
+	require.Len(t, transport.messages, 1)
+	assert.Equal(t, []byte{1}, transport.messages[0].GetPrepareData().ProposalHash)
+}
@@ -52,6 +52,9 @@ type blockchainBackend interface {
 	// GetHeaderByHash returns a reference to block header for the given block hash
 	GetHeaderByHash(hash types.Hash) (*types.Header, bool)
 
+	// GetBlockByNumber returns a reference to block for the given block number.
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+
 	// GetSystemState creates a new instance of SystemState interface
 	GetSystemState(provider contract.Provider) SystemState
 
@@ -147,6 +150,11 @@ func (p *blockchainWrapper) GetHeaderByHash(hash types.Hash) (*types.Header, boo
 	return p.blockchain.GetHeaderByHash(hash)
 }
 
+// GetBlockByNumber is an implementation of blockchainBackend interface
+func (p *blockchainWrapper) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	return p.blockchain.GetBlockByNumber(number, full)
+}
+
 // NewBlockBuilder is an implementation of blockchainBackend interface
 func (p *blockchainWrapper) NewBlockBuilder(
 	parent *types.Header, coinbase types.Address,
@@ -2,6 +2,7 @@ package polybft
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
@@ -26,12 +27,16 @@ var (
 	currentCheckpointBlockNumMethod, _ = contractsapi.CheckpointManager.Abi.Methods["currentCheckpointBlockNumber"]
 	// frequency at which checkpoints are sent to the rootchain (in blocks count)
 	defaultCheckpointsOffset = uint64(900)
+
+	// errCheckpointsDisabled is returned when the checkpoints are queried while the bridge is disabled
+	errCheckpointsDisabled = errors.New("checkpoints are not submitted since the bridge is disabled")
 )
 
 type CheckpointManager interface {
 	PostBlock(req *PostBlockRequest) error
 	BuildEventRoot(epoch uint64) (types.Hash, error)
 	GenerateExitProof(exitID uint64) (types.Proof, error)
+	GetLatestCheckpointBlock() (uint64, error)
 }
 
 var _ CheckpointManager = (*dummyCheckpointManager)(nil)
@@ -45,6 +50,9 @@ func (d *dummyCheckpointManager) BuildEventRoot(epoch uint64) (types.Hash, error
 func (d *dummyCheckpointManager) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	return types.Proof{}, nil
 }
+func (d *dummyCheckpointManager) GetLatestCheckpointBlock() (uint64, error) {
+	return 0, errCheckpointsDisabled
+}
 
 var _ CheckpointManager = (*checkpointManager)(nil)
 
@@ -98,8 +106,8 @@ func newCheckpointManager(key ethgo.Key, checkpointOffset uint64,
 	}
 }
 
-// getLatestCheckpointBlock queries CheckpointManager smart contract and retrieves latest checkpoint block number
-func (c *checkpointManager) getLatestCheckpointBlock() (uint64, error) {
+// GetLatestCheckpointBlock queries CheckpointManager smart contract and retrieves latest checkpoint block number
+func (c *checkpointManager) GetLatestCheckpointBlock() (uint64, error) {
 	checkpointBlockNumMethodEncoded, err := currentCheckpointBlockNumMethod.Encode([]interface{}{})
 	if err != nil {
 		return 0, fmt.Errorf("failed to encode currentCheckpointId function parameters: %w", err)
@@ -124,7 +132,7 @@ func (c *checkpointManager) getLatestCheckpointBlock() (uint64, error) {
 
 // submitCheckpoint sends a transaction with checkpoint data to the rootchain
 func (c *checkpointManager) submitCheckpoint(latestHeader *types.Header, isEndOfEpoch bool) error {
-	lastCheckpointBlockNumber, err := c.getLatestCheckpointBlock()
+	lastCheckpointBlockNumber, err := c.GetLatestCheckpointBlock()
 	if err != nil {
 		return err
 	}
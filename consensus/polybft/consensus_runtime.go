@@ -9,6 +9,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
@@ -117,6 +118,12 @@ type consensusRuntime struct {
 	// manager for handling validator stake change and updating validator set
 	stakeManager StakeManager
 
+	// doubleSignTracker collects the evidences of the validators signing conflicting consensus messages
+	doubleSignTracker *doubleSignTracker
+
+	// livenessTracker records the signers of the blocks to calculate the liveness of the validators
+	livenessTracker *livenessTracker
+
 	// logger instance
 	logger hcf.Logger
 }
@@ -133,9 +140,18 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 		config:             config,
 		lastBuiltBlock:     config.blockchain.CurrentHeader(),
 		proposerCalculator: proposerCalculator,
+		doubleSignTracker:  newDoubleSignTracker(log.Named("double_sign_tracker")),
 		logger:             log.Named("consensus_runtime"),
 	}
 
+	runtime.livenessTracker = newLivenessTracker(
+		log.Named("liveness_tracker"),
+		config.State,
+		config.blockchain,
+		config.polybftBackend,
+		config.PolyBFTConfig.GetLivenessWindow(),
+	)
+
 	if err := runtime.initStateSyncManager(log); err != nil {
 		return nil, err
 	}
@@ -154,6 +170,8 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 		return nil, fmt.Errorf("consensus runtime creation - restart epoch failed: %w", err)
 	}
 
+	runtime.doubleSignTracker.SetView(runtime.lastBuiltBlock.Number+1, runtime.epoch.Validators)
+
 	return runtime, nil
 }
 
@@ -232,7 +250,13 @@ func (c *consensusRuntime) initStakeManager(logger hcf.Logger) error {
 		contracts.ValidatorSetContract,
 		c.config.PolyBFTConfig.Bridge.CustomSupernetManagerAddr,
 		c.config.blockchain,
+		c.config.polybftBackend,
 		int(c.config.PolyBFTConfig.MaxValidatorSetSize),
+		&LivenessConfig{
+			Window:    c.config.PolyBFTConfig.GetLivenessWindow(),
+			Threshold: c.config.PolyBFTConfig.GetLivenessThreshold(),
+		},
+		c.config.PolyBFTConfig.GetDoubleSignJailPeriod(),
 	)
 
 	return nil
@@ -307,11 +331,21 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 		c.logger.Error("Could not update proposer calculator", "err", err)
 	}
 
+	// record the signers of the parent block, before the validator set is updated by the stake manager
+	if err := c.livenessTracker.PostBlock(postBlock); err != nil {
+		c.logger.Error("failed to post block in liveness tracker", "err", err)
+	}
+
 	// handle transfer events that happened in block
 	if err := c.stakeManager.PostBlock(postBlock); err != nil {
 		c.logger.Error("failed to post block in stake manager", "err", err)
 	}
 
+	// handle double sign evidences submitted in block
+	if err := c.doubleSignTracker.PostBlock(postBlock); err != nil {
+		c.logger.Error("failed to post block in double sign tracker", "err", err)
+	}
+
 	if isEndOfEpoch {
 		if epoch, err = c.restartEpoch(fullBlock.Block.Header); err != nil {
 			c.logger.Error("failed to restart epoch after block inserted", "error", err)
@@ -323,6 +357,7 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 	// finally update runtime state (lastBuiltBlock, epoch, proposerSnapshot)
 	c.epoch = epoch
 	c.lastBuiltBlock = fullBlock.Block.Header
+	c.doubleSignTracker.SetView(fullBlock.Block.Number()+1, epoch.Validators)
 }
 
 // FSM creates a new instance of fsm
@@ -377,6 +412,8 @@ func (c *consensusRuntime) FSM() error {
 		logger:            c.logger.Named("fsm"),
 	}
 
+	ff.doubleSignEvidences = c.doubleSignTracker.Evidences(epoch.Validators)
+
 	if isEndOfSprint {
 		commitment, err := c.stateSyncManager.Commitment()
 		if err != nil {
@@ -594,6 +631,28 @@ func (c *consensusRuntime) GenerateExitProof(exitID uint64) (types.Proof, error)
 	return c.checkpointManager.GenerateExitProof(exitID)
 }
 
+// GetCheckpointedBlockNumber returns the number of the latest block checkpointed to the rootchain,
+// and is a bridge endpoint store function
+func (c *consensusRuntime) GetCheckpointedBlockNumber() (uint64, error) {
+	return c.checkpointManager.GetLatestCheckpointBlock()
+}
+
+// GetValidatorsLiveness returns the liveness of the validators over the window ending at the last built block
+func (c *consensusRuntime) GetValidatorsLiveness() (*consensus.ValidatorsLiveness, error) {
+	c.lock.RLock()
+	lastBuiltBlock := c.lastBuiltBlock
+	c.lock.RUnlock()
+
+	liveness, err := getValidatorsLiveness(c.state, lastBuiltBlock.Number, c.config.PolyBFTConfig.GetLivenessWindow())
+	if err != nil {
+		return nil, err
+	}
+
+	liveness.Threshold = c.config.PolyBFTConfig.GetLivenessThreshold()
+
+	return liveness, nil
+}
+
 // GetStateSyncProof returns the proof for the state sync
 func (c *consensusRuntime) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return c.stateSyncManager.GetStateSyncProof(stateSyncID)
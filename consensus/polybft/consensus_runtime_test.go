@@ -202,7 +202,7 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headerMap.getHeader)
 
 	polybftBackendMock := new(polybftBackendMock)
-	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(validatorSet).Times(3)
+	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(validatorSet).Times(4)
 
 	txPool := new(txPoolMock)
 	txPool.On("ResetWithHeaders", mock.Anything).Once()
@@ -218,6 +218,8 @@ func TestConsensusRuntime_OnBlockInserted_EndOfEpoch(t *testing.T) {
 		State:          newTestState(t),
 	}
 	runtime := &consensusRuntime{
+		doubleSignTracker:  newDoubleSignTracker(hclog.NewNullLogger()),
+		livenessTracker:    newLivenessTracker(hclog.NewNullLogger(), config.State, blockchainMock, polybftBackendMock, 1),
 		proposerCalculator: NewProposerCalculatorFromSnapshot(snapshot, config, hclog.NewNullLogger()),
 		logger:             hclog.NewNullLogger(),
 		state:              config.State,
@@ -272,7 +274,8 @@ func TestConsensusRuntime_OnBlockInserted_MiddleOfEpoch(t *testing.T) {
 	}
 
 	runtime := &consensusRuntime{
-		lastBuiltBlock: header,
+		doubleSignTracker: newDoubleSignTracker(hclog.NewNullLogger()),
+		lastBuiltBlock:    header,
 		config: &runtimeConfig{
 			PolyBFTConfig: &PolyBFTConfig{EpochSize: epochSize},
 			blockchain:    blockchainMock,
@@ -342,6 +345,7 @@ func TestConsensusRuntime_FSM_NotEndOfEpoch_NotEndOfSprint(t *testing.T) {
 		blockchain: blockchainMock,
 	}
 	runtime := &consensusRuntime{
+		doubleSignTracker:  newDoubleSignTracker(hclog.NewNullLogger()),
 		proposerCalculator: NewProposerCalculatorFromSnapshot(snapshot, config, hclog.NewNullLogger()),
 		logger:             hclog.NewNullLogger(),
 		config:             config,
@@ -415,6 +419,7 @@ func TestConsensusRuntime_FSM_EndOfEpoch_BuildCommitEpoch(t *testing.T) {
 
 	snapshot := NewProposerSnapshot(1, nil)
 	runtime := &consensusRuntime{
+		doubleSignTracker:  newDoubleSignTracker(hclog.NewNullLogger()),
 		proposerCalculator: NewProposerCalculatorFromSnapshot(snapshot, config, hclog.NewNullLogger()),
 		logger:             hclog.NewNullLogger(),
 		state:              state,
@@ -980,6 +985,15 @@ func TestConsensusRuntime_BuildPrepareMessage(t *testing.T) {
 	assert.Equal(t, signedMsg, runtime.BuildPrepareMessage(proposalHash, view))
 }
 
+func TestConsensusRuntime_GetCheckpointedBlockNumber_BridgeDisabled(t *testing.T) {
+	t.Parallel()
+
+	runtime := &consensusRuntime{checkpointManager: &dummyCheckpointManager{}}
+
+	_, err := runtime.GetCheckpointedBlockNumber()
+	assert.ErrorIs(t, err, errCheckpointsDisabled)
+}
+
 func createTestBlocks(t *testing.T, numberOfBlocks, defaultEpochSize uint64,
 	validatorSet validator.AccountSet) (*types.Header, *testHeadersMap) {
 	t.Helper()
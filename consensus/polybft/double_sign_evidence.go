@@ -0,0 +1,235 @@
+package polybft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/abi"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// defaultDoubleSignJailPeriod is the default number of the blocks the validators reported
+// for double signing are excluded from the validator set for
+const defaultDoubleSignJailPeriod = uint64(1000)
+
+// submitDoubleSignEvidenceABIMethod is the method invoked by the state transaction which submits
+// the double sign evidences. Each evidence consists of two conflicting signed consensus messages
+var submitDoubleSignEvidenceABIMethod = abi.MustNewMethod(
+	"function submitDoubleSignEvidence(tuple(bytes first, bytes second)[] evidences)")
+
+var (
+	errEvidenceMessageTypeNotSupported = errors.New("double sign evidence is supported for prepare and commit messages")
+	errEvidenceMessagesNotConflicting  = errors.New("double sign evidence messages are not conflicting")
+	errEvidenceEmpty                   = errors.New("double sign evidence transaction has no evidences")
+)
+
+var _ contractsapi.StateTransactionInput = &SubmitDoubleSignEvidenceFn{}
+
+// DoubleSignEvidence is a proof of a validator signing two different consensus messages
+// of the same type at the same height and round
+type DoubleSignEvidence struct {
+	// First is the first signed message, encoded in protobuf
+	First []byte `abi:"first"`
+	// Second is the second signed message, encoded in protobuf
+	Second []byte `abi:"second"`
+}
+
+// newDoubleSignEvidence creates the evidence of two conflicting signed consensus messages
+func newDoubleSignEvidence(first, second *proto.Message) (*DoubleSignEvidence, error) {
+	firstRaw, err := protobuf.Marshal(first)
+	if err != nil {
+		return nil, err
+	}
+
+	secondRaw, err := protobuf.Marshal(second)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoubleSignEvidence{First: firstRaw, Second: secondRaw}, nil
+}
+
+// Verify checks both messages are signed by the same validator, have the same type, height and round,
+// while their content differs. It returns the address of the offending validator
+func (e *DoubleSignEvidence) Verify() (types.Address, error) {
+	first, firstDigest, err := decodeSignedMessage(e.First)
+	if err != nil {
+		return types.ZeroAddress, fmt.Errorf("invalid first message of double sign evidence: %w", err)
+	}
+
+	second, secondDigest, err := decodeSignedMessage(e.Second)
+	if err != nil {
+		return types.ZeroAddress, fmt.Errorf("invalid second message of double sign evidence: %w", err)
+	}
+
+	if first.Type != proto.MessageType_PREPARE && first.Type != proto.MessageType_COMMIT {
+		return types.ZeroAddress, errEvidenceMessageTypeNotSupported
+	}
+
+	if first.Type != second.Type ||
+		first.View.Height != second.View.Height ||
+		first.View.Round != second.View.Round ||
+		!bytes.Equal(first.From, second.From) ||
+		bytes.Equal(firstDigest, secondDigest) {
+		return types.ZeroAddress, errEvidenceMessagesNotConflicting
+	}
+
+	return types.BytesToAddress(first.From), nil
+}
+
+// decodeSignedMessage decodes the consensus message, verifies its signature
+// and returns it along with the digest of its content
+func decodeSignedMessage(raw []byte) (*proto.Message, []byte, error) {
+	msg := &proto.Message{}
+	if err := protobuf.Unmarshal(raw, msg); err != nil {
+		return nil, nil, err
+	}
+
+	digest, err := verifySignedMessage(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return msg, digest, nil
+}
+
+// verifySignedMessage verifies the consensus message is signed by its sender
+// and returns the digest of its content
+func verifySignedMessage(msg *proto.Message) ([]byte, error) {
+	if msg.View == nil {
+		return nil, errors.New("message view not found")
+	}
+
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := wallet.RecoverAddressFromSignature(msg.Signature, msgNoSig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(msg.From, signer.Bytes()) {
+		return nil, fmt.Errorf("signer address %s doesn't match From field", signer)
+	}
+
+	return crypto.Keccak256(msgNoSig), nil
+}
+
+// SubmitDoubleSignEvidenceFn is the input of the state transaction which submits the double sign evidences.
+// The offending validators are jailed at the end of the epoch, which excludes them from the validator set
+// until the block carrying the evidences leaves the jail period
+type SubmitDoubleSignEvidenceFn struct {
+	Evidences []*DoubleSignEvidence `abi:"evidences"`
+}
+
+// Sig returns the signature of the submit double sign evidence method
+func (s *SubmitDoubleSignEvidenceFn) Sig() []byte {
+	return submitDoubleSignEvidenceABIMethod.ID()
+}
+
+// EncodeAbi contains logic for encoding arbitrary data into ABI format
+func (s *SubmitDoubleSignEvidenceFn) EncodeAbi() ([]byte, error) {
+	return submitDoubleSignEvidenceABIMethod.Encode(s)
+}
+
+// DecodeAbi contains logic for decoding given ABI data
+func (s *SubmitDoubleSignEvidenceFn) DecodeAbi(txData []byte) error {
+	if len(txData) < abiMethodIDLength || !bytes.Equal(txData[:abiMethodIDLength], s.Sig()) {
+		return fmt.Errorf("invalid double sign evidence data, len = %d", len(txData))
+	}
+
+	decoded, err := abi.Decode(submitDoubleSignEvidenceABIMethod.Inputs, txData[abiMethodIDLength:])
+	if err != nil {
+		return err
+	}
+
+	decodedMap, ok := decoded.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid double sign evidence data")
+	}
+
+	evidences, ok := decodedMap["evidences"].([]map[string]interface{})
+	if !ok {
+		return errors.New("invalid double sign evidence data. Could not find evidences part")
+	}
+
+	s.Evidences = make([]*DoubleSignEvidence, len(evidences))
+
+	for i, evidence := range evidences {
+		first, ok := evidence["first"].([]byte)
+		if !ok {
+			return errors.New("invalid double sign evidence data. Could not find first message")
+		}
+
+		second, ok := evidence["second"].([]byte)
+		if !ok {
+			return errors.New("invalid double sign evidence data. Could not find second message")
+		}
+
+		s.Evidences[i] = &DoubleSignEvidence{First: first, Second: second}
+	}
+
+	return nil
+}
+
+// Offenders verifies the evidences and returns the addresses of the offending validators,
+// each of them has to be a member of the given validator set and can be reported only once
+func (s *SubmitDoubleSignEvidenceFn) Offenders(validators validator.AccountSet) ([]types.Address, error) {
+	if len(s.Evidences) == 0 {
+		return nil, errEvidenceEmpty
+	}
+
+	offenders := make([]types.Address, 0, len(s.Evidences))
+	reported := make(map[types.Address]struct{}, len(s.Evidences))
+
+	for _, evidence := range s.Evidences {
+		offender, err := evidence.Verify()
+		if err != nil {
+			return nil, err
+		}
+
+		if !validators.ContainsAddress(offender) {
+			return nil, fmt.Errorf("double sign evidence offender %s is not a validator", offender)
+		}
+
+		if _, exists := reported[offender]; exists {
+			return nil, fmt.Errorf("double sign evidence offender %s is reported more than once", offender)
+		}
+
+		reported[offender] = struct{}{}
+		offenders = append(offenders, offender)
+	}
+
+	return offenders, nil
+}
+
+// getDoubleSignEvidenceTx returns the double sign evidences submitted by the state transaction of the block,
+// nil if the block doesn't contain one
+func getDoubleSignEvidenceTx(txs []*types.Transaction) (*SubmitDoubleSignEvidenceFn, error) {
+	var submitEvidenceFn SubmitDoubleSignEvidenceFn
+
+	for _, tx := range txs {
+		if tx.Type != types.StateTx ||
+			len(tx.Input) < abiMethodIDLength ||
+			!bytes.Equal(tx.Input[:abiMethodIDLength], submitEvidenceFn.Sig()) {
+			continue
+		}
+
+		if err := submitEvidenceFn.DecodeAbi(tx.Input); err != nil {
+			return nil, err
+		}
+
+		return &submitEvidenceFn, nil
+	}
+
+	return nil, nil
+}
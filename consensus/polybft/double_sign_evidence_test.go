@@ -0,0 +1,185 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSignedMessage creates the consensus message of the given type signed by the key
+func newTestSignedMessage(t *testing.T, key *wallet.Key, msgType proto.MessageType,
+	height, round uint64, proposalHash []byte) *proto.Message {
+	t.Helper()
+
+	msg := &proto.Message{
+		View: &proto.View{Height: height, Round: round},
+		From: key.Address().Bytes(),
+		Type: msgType,
+	}
+
+	switch msgType {
+	case proto.MessageType_PREPARE:
+		msg.Payload = &proto.Message_PrepareData{PrepareData: &proto.PrepareMessage{ProposalHash: proposalHash}}
+	case proto.MessageType_COMMIT:
+		msg.Payload = &proto.Message_CommitData{CommitData: &proto.CommitMessage{ProposalHash: proposalHash}}
+	}
+
+	signed, err := key.SignIBFTMessage(msg)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func TestDoubleSignEvidence_Verify(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	keyA, keyB := validators.GetValidator("A").Key(), validators.GetValidator("B").Key()
+
+	cases := []struct {
+		name   string
+		first  *proto.Message
+		second *proto.Message
+		err    error
+	}{
+		{
+			name:   "conflicting prepares",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}),
+			second: newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{2}),
+		},
+		{
+			name:   "conflicting commits",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5, 0, []byte{1}),
+			second: newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5, 0, []byte{2}),
+		},
+		{
+			name:   "same message",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}),
+			second: newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}),
+			err:    errEvidenceMessagesNotConflicting,
+		},
+		{
+			name:   "different rounds",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}),
+			second: newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 2, []byte{2}),
+			err:    errEvidenceMessagesNotConflicting,
+		},
+		{
+			name:   "different types",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}),
+			second: newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5, 1, []byte{2}),
+			err:    errEvidenceMessagesNotConflicting,
+		},
+		{
+			name:   "different signers",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}),
+			second: newTestSignedMessage(t, keyB, proto.MessageType_PREPARE, 5, 1, []byte{2}),
+			err:    errEvidenceMessagesNotConflicting,
+		},
+		{
+			name:   "round change",
+			first:  newTestSignedMessage(t, keyA, proto.MessageType_ROUND_CHANGE, 5, 1, nil),
+			second: newTestSignedMessage(t, keyA, proto.MessageType_ROUND_CHANGE, 5, 1, nil),
+			err:    errEvidenceMessageTypeNotSupported,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			evidence, err := newDoubleSignEvidence(c.first, c.second)
+			require.NoError(t, err)
+
+			offender, err := evidence.Verify()
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, types.BytesToAddress(c.first.From), offender)
+		})
+	}
+
+	t.Run("forged signature", func(t *testing.T) {
+		t.Parallel()
+
+		forged := newTestSignedMessage(t, keyB, proto.MessageType_PREPARE, 5, 1, []byte{2})
+		forged.From = keyA.Address().Bytes()
+
+		evidence, err := newDoubleSignEvidence(
+			newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 1, []byte{1}), forged)
+		require.NoError(t, err)
+
+		_, err = evidence.Verify()
+		require.Error(t, err)
+	})
+}
+
+func TestSubmitDoubleSignEvidenceFn(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"})
+	keyA, keyB := validators.GetValidator("A").Key(), validators.GetValidator("B").Key()
+
+	newEvidence := func(key *wallet.Key) *DoubleSignEvidence {
+		evidence, err := newDoubleSignEvidence(
+			newTestSignedMessage(t, key, proto.MessageType_COMMIT, 3, 0, []byte{1}),
+			newTestSignedMessage(t, key, proto.MessageType_COMMIT, 3, 0, []byte{2}))
+		require.NoError(t, err)
+
+		return evidence
+	}
+
+	submitEvidenceFn := &SubmitDoubleSignEvidenceFn{
+		Evidences: []*DoubleSignEvidence{newEvidence(keyA), newEvidence(keyB)},
+	}
+
+	input, err := submitEvidenceFn.EncodeAbi()
+	require.NoError(t, err)
+
+	decoded, err := decodeStateTransaction(input)
+	require.NoError(t, err)
+	require.Equal(t, submitEvidenceFn, decoded)
+
+	offenders, err := submitEvidenceFn.Offenders(validators.GetPublicIdentities())
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{types.Address(keyA.Address()), types.Address(keyB.Address())}, offenders)
+
+	// the offender has to be a validator
+	_, err = submitEvidenceFn.Offenders(validators.GetPublicIdentities("B", "C"))
+	require.ErrorContains(t, err, "is not a validator")
+
+	// the offender can be reported only once
+	_, err = (&SubmitDoubleSignEvidenceFn{
+		Evidences: []*DoubleSignEvidence{newEvidence(keyA), newEvidence(keyA)},
+	}).Offenders(validators.GetPublicIdentities())
+	require.ErrorContains(t, err, "more than once")
+
+	_, err = (&SubmitDoubleSignEvidenceFn{}).Offenders(validators.GetPublicIdentities())
+	require.ErrorIs(t, err, errEvidenceEmpty)
+
+	// the evidences are found in the block transactions
+	txs := []*types.Transaction{
+		{Type: types.LegacyTx, Input: input},
+		createStateTransactionWithData(contracts.DoubleSignEvidenceAddr, input),
+	}
+
+	fromBlock, err := getDoubleSignEvidenceTx(txs)
+	require.NoError(t, err)
+	require.Equal(t, submitEvidenceFn, fromBlock)
+
+	fromBlock, err = getDoubleSignEvidenceTx(txs[:1])
+	require.NoError(t, err)
+	require.Nil(t, fromBlock)
+}
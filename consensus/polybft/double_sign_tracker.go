@@ -0,0 +1,271 @@
+package polybft
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	hcf "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// doubleSignTrackedHeights is the number of the latest heights whose consensus messages are tracked
+	doubleSignTrackedHeights = 10
+
+	// doubleSignFutureHeights is the number of the heights above the current one whose consensus messages are tracked
+	doubleSignFutureHeights = 2
+
+	// doubleSignTrackedRounds is the maximum number of the rounds tracked per validator and height
+	doubleSignTrackedRounds = 10
+)
+
+// trackedMessageKey identifies the message a validator is allowed to sign only once
+type trackedMessageKey struct {
+	height  uint64
+	round   uint64
+	msgType proto.MessageType
+	from    types.Address
+}
+
+// trackedSenderKey identifies the messages a validator signed at the given height
+type trackedSenderKey struct {
+	height uint64
+	from   types.Address
+}
+
+// trackedMessage is the message seen on the consensus topic along with the digest of its content
+type trackedMessage struct {
+	msg      *proto.Message
+	digest   []byte
+	verified bool
+}
+
+// doubleSignTracker collects the consensus messages seen on the consensus topic,
+// and builds the evidences of the validators signing conflicting messages
+type doubleSignTracker struct {
+	lock   sync.Mutex
+	logger hcf.Logger
+
+	// messages are the tracked messages of the latest heights
+	messages map[trackedMessageKey]*trackedMessage
+
+	// rounds are the rounds of the tracked messages of each validator per height
+	rounds map[trackedSenderKey]map[uint64]struct{}
+
+	// evidences are the evidences which are not submitted yet, one per offending validator
+	evidences map[types.Address]*DoubleSignEvidence
+
+	// reported are the offending validators whose evidences are already submitted,
+	// along with the number of the block the evidences are submitted in
+	reported map[types.Address]uint64
+
+	// lowestHeight is the lowest height of the tracked messages
+	lowestHeight uint64
+
+	// height is the height the consensus is currently run for
+	height uint64
+
+	// validators are the validators of the current height, only their messages are tracked
+	validators validator.AccountSet
+}
+
+// newDoubleSignTracker creates a new instance of double sign tracker
+func newDoubleSignTracker(logger hcf.Logger) *doubleSignTracker {
+	return &doubleSignTracker{
+		logger:    logger,
+		messages:  make(map[trackedMessageKey]*trackedMessage),
+		rounds:    make(map[trackedSenderKey]map[uint64]struct{}),
+		evidences: make(map[types.Address]*DoubleSignEvidence),
+		reported:  make(map[types.Address]uint64),
+	}
+}
+
+// SetView sets the height the consensus is currently run for along with its validators
+func (t *doubleSignTracker) SetView(height uint64, validators validator.AccountSet) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.height = height
+	t.validators = validators
+}
+
+// AddMessage tracks the consensus message, and builds the evidence if it conflicts with the message
+// of the same type, signed by the same validator at the same height and round.
+// Only the messages of the current validators, at the heights around the current one are tracked,
+// and at most doubleSignTrackedRounds rounds per validator and height, so the memory usage is bounded
+func (t *doubleSignTracker) AddMessage(msg *proto.Message) {
+	if msg.View == nil || (msg.Type != proto.MessageType_PREPARE && msg.Type != proto.MessageType_COMMIT) {
+		return
+	}
+
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return
+	}
+
+	key := trackedMessageKey{
+		height:  msg.View.Height,
+		round:   msg.View.Round,
+		msgType: msg.Type,
+		from:    types.BytesToAddress(msg.From),
+	}
+	tracked := &trackedMessage{msg: msg, digest: crypto.Keccak256(msgNoSig)}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if key.height < t.lowestHeight || key.height > t.height+doubleSignFutureHeights ||
+		!t.validators.ContainsAddress(key.from) {
+		return
+	}
+
+	existing, exists := t.messages[key]
+	if !exists {
+		senderKey := trackedSenderKey{height: key.height, from: key.from}
+
+		rounds, ok := t.rounds[senderKey]
+		if !ok {
+			rounds = make(map[uint64]struct{})
+			t.rounds[senderKey] = rounds
+		}
+
+		if _, ok := rounds[key.round]; !ok {
+			if len(rounds) >= doubleSignTrackedRounds {
+				return
+			}
+
+			rounds[key.round] = struct{}{}
+		}
+
+		// the signature is verified lazily, only once the conflicting message is received
+		t.messages[key] = tracked
+
+		return
+	}
+
+	if bytes.Equal(existing.digest, tracked.digest) {
+		return
+	}
+
+	if !tracked.verify() {
+		return
+	}
+
+	if !existing.verify() {
+		// the existing message is forged, so it is replaced with the valid one
+		t.messages[key] = tracked
+
+		return
+	}
+
+	if _, reported := t.reported[key.from]; reported {
+		return
+	}
+
+	if _, exists := t.evidences[key.from]; exists {
+		return
+	}
+
+	evidence, err := newDoubleSignEvidence(existing.msg, tracked.msg)
+	if err != nil {
+		t.logger.Error("failed to create double sign evidence", "validator", key.from, "error", err)
+
+		return
+	}
+
+	t.evidences[key.from] = evidence
+
+	t.logger.Warn("validator signed conflicting consensus messages",
+		"validator", key.from, "type", key.msgType, "height", key.height, "round", key.round)
+}
+
+// Evidences returns the evidences of the given validators which are not submitted yet,
+// sorted by the offending validators
+func (t *doubleSignTracker) Evidences(validators validator.AccountSet) []*DoubleSignEvidence {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	offenders := make([]types.Address, 0, len(t.evidences))
+
+	for offender := range t.evidences {
+		// the validators which already left the validator set can't be jailed
+		if validators.ContainsAddress(offender) {
+			offenders = append(offenders, offender)
+		}
+	}
+
+	sort.Slice(offenders, func(i, j int) bool {
+		return bytes.Compare(offenders[i].Bytes(), offenders[j].Bytes()) < 0
+	})
+
+	evidences := make([]*DoubleSignEvidence, len(offenders))
+	for i, offender := range offenders {
+		evidences[i] = t.evidences[offender]
+	}
+
+	return evidences
+}
+
+// PostBlock removes the evidences submitted in the block, and stops tracking the messages of old heights
+func (t *doubleSignTracker) PostBlock(req *PostBlockRequest) error {
+	submitEvidenceFn, err := getDoubleSignEvidenceTx(req.FullBlock.Block.Transactions)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if submitEvidenceFn != nil {
+		for _, evidence := range submitEvidenceFn.Evidences {
+			// the evidences are already verified on the block validation
+			offender, err := evidence.Verify()
+			if err != nil {
+				return err
+			}
+
+			t.reported[offender] = req.FullBlock.Block.Number()
+			delete(t.evidences, offender)
+		}
+	}
+
+	if blockNumber := req.FullBlock.Block.Number(); blockNumber > doubleSignTrackedHeights {
+		t.lowestHeight = blockNumber - doubleSignTrackedHeights
+	}
+
+	for key := range t.messages {
+		if key.height < t.lowestHeight {
+			delete(t.messages, key)
+		}
+	}
+
+	for key := range t.rounds {
+		if key.height < t.lowestHeight {
+			delete(t.rounds, key)
+		}
+	}
+
+	// the messages of the reported offence are not tracked anymore,
+	// so the validator is reported again if it signs conflicting messages once released from jail
+	for offender, reportedAt := range t.reported {
+		if reportedAt < t.lowestHeight {
+			delete(t.reported, offender)
+		}
+	}
+
+	return nil
+}
+
+// verify checks the message is signed by its sender, the result is cached
+func (m *trackedMessage) verify() bool {
+	if !m.verified {
+		_, err := verifySignedMessage(m.msg)
+		m.verified = err == nil
+	}
+
+	return m.verified
+}
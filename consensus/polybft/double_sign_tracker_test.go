@@ -0,0 +1,161 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleSignTracker_AddMessage(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	keyA, keyB := validators.GetValidator("A").Key(), validators.GetValidator("B").Key()
+
+	tracker := newDoubleSignTracker(hclog.NewNullLogger())
+	tracker.SetView(5, validators.GetPublicIdentities())
+
+	// the same message received twice is not an evidence
+	prepare := newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 0, []byte{1})
+	tracker.AddMessage(prepare)
+	tracker.AddMessage(prepare)
+	require.Empty(t, tracker.Evidences(validators.GetPublicIdentities()))
+
+	// the forged message doesn't replace the valid one, and is not an evidence
+	forged := newTestSignedMessage(t, keyB, proto.MessageType_PREPARE, 5, 0, []byte{2})
+	forged.From = keyA.Address().Bytes()
+	tracker.AddMessage(forged)
+	require.Empty(t, tracker.Evidences(validators.GetPublicIdentities()))
+
+	// the round change messages are not tracked
+	tracker.AddMessage(newTestSignedMessage(t, keyB, proto.MessageType_ROUND_CHANGE, 5, 0, nil))
+	require.Empty(t, tracker.messages[trackedMessageKey{
+		height: 5, msgType: proto.MessageType_ROUND_CHANGE, from: types.Address(keyB.Address()),
+	}])
+
+	// the conflicting prepare
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 0, []byte{3}))
+
+	evidences := tracker.Evidences(validators.GetPublicIdentities())
+	require.Len(t, evidences, 1)
+
+	offender, err := evidences[0].Verify()
+	require.NoError(t, err)
+	require.Equal(t, types.Address(keyA.Address()), offender)
+
+	// the evidence is returned only for the validators of the given set
+	require.Empty(t, tracker.Evidences(validators.GetPublicIdentities("B")))
+}
+
+func TestDoubleSignTracker_ForgedFirstMessage(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	keyA, keyB := validators.GetValidator("A").Key(), validators.GetValidator("B").Key()
+
+	tracker := newDoubleSignTracker(hclog.NewNullLogger())
+	tracker.SetView(5, validators.GetPublicIdentities())
+
+	forged := newTestSignedMessage(t, keyB, proto.MessageType_COMMIT, 5, 0, []byte{1})
+	forged.From = keyA.Address().Bytes()
+
+	tracker.AddMessage(forged)
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5, 0, []byte{2}))
+	require.Empty(t, tracker.Evidences(validators.GetPublicIdentities()))
+
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5, 0, []byte{3}))
+	require.Len(t, tracker.Evidences(validators.GetPublicIdentities()), 1)
+}
+
+func TestDoubleSignTracker_PostBlock(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B"})
+	keyA := validators.GetValidator("A").Key()
+
+	tracker := newDoubleSignTracker(hclog.NewNullLogger())
+	tracker.SetView(20, validators.GetPublicIdentities())
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 0, []byte{1}))
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5, 0, []byte{2}))
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 20, 0, []byte{1}))
+
+	evidences := tracker.Evidences(validators.GetPublicIdentities())
+	require.Len(t, evidences, 1)
+
+	input, err := (&SubmitDoubleSignEvidenceFn{Evidences: evidences}).EncodeAbi()
+	require.NoError(t, err)
+
+	require.NoError(t, tracker.PostBlock(&PostBlockRequest{
+		FullBlock: &types.FullBlock{Block: &types.Block{
+			Header:       &types.Header{Number: 20},
+			Transactions: []*types.Transaction{createStateTransactionWithData(contracts.DoubleSignEvidenceAddr, input)},
+		}},
+	}))
+
+	// the submitted evidence is removed and the messages of old heights are not tracked anymore
+	require.Empty(t, tracker.Evidences(validators.GetPublicIdentities()))
+	require.Len(t, tracker.messages, 1)
+
+	tracker.SetView(21, validators.GetPublicIdentities())
+
+	// the reported validator is not reported again
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 20, 0, []byte{2}))
+	require.Empty(t, tracker.Evidences(validators.GetPublicIdentities()))
+
+	// the messages of old heights are ignored
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5, 0, []byte{2}))
+	require.Len(t, tracker.messages, 1)
+
+	// once the reported offence leaves the tracked heights, the released validator is reported again
+	require.NoError(t, tracker.PostBlock(&PostBlockRequest{
+		FullBlock: &types.FullBlock{Block: &types.Block{Header: &types.Header{Number: 20 + doubleSignTrackedHeights + 1}}},
+	}))
+	require.Empty(t, tracker.reported)
+
+	tracker.SetView(40, validators.GetPublicIdentities())
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 40, 0, []byte{1}))
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 40, 0, []byte{2}))
+	require.Len(t, tracker.Evidences(validators.GetPublicIdentities()), 1)
+}
+
+func TestDoubleSignTracker_BoundedMessages(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C"})
+	keyA, keyC := validators.GetValidator("A").Key(), validators.GetValidator("C").Key()
+
+	tracker := newDoubleSignTracker(hclog.NewNullLogger())
+	tracker.SetView(5, validators.GetPublicIdentities("A", "B"))
+
+	// the messages of the heights too far ahead are ignored
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5+doubleSignFutureHeights+1, 0, nil))
+	require.Empty(t, tracker.messages)
+
+	// the messages of the validators out of the validator set are ignored
+	tracker.AddMessage(newTestSignedMessage(t, keyC, proto.MessageType_PREPARE, 5, 0, nil))
+	require.Empty(t, tracker.messages)
+
+	// the number of the tracked rounds per validator and height is capped
+	for round := uint64(0); round <= doubleSignTrackedRounds; round++ {
+		tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5+doubleSignFutureHeights, round, nil))
+		tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 5+doubleSignFutureHeights, round, nil))
+	}
+
+	require.Len(t, tracker.messages, 2*doubleSignTrackedRounds)
+	require.Len(t, tracker.rounds, 1)
+
+	// the messages of the tracked rounds are still checked for the conflicts
+	tracker.AddMessage(newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 5+doubleSignFutureHeights, 0, []byte{1}))
+	require.Len(t, tracker.Evidences(validators.GetPublicIdentities()), 1)
+
+	require.NoError(t, tracker.PostBlock(&PostBlockRequest{
+		FullBlock: &types.FullBlock{Block: &types.Block{Header: &types.Header{Number: 20}}},
+	}))
+	require.Empty(t, tracker.messages)
+	require.Empty(t, tracker.rounds)
+}
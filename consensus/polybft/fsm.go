@@ -41,6 +41,8 @@ var (
 		"in a non epoch ending block")
 	errDistributeRewardsTxSingleExpected = errors.New("only one distribute rewards transaction is " +
 		"allowed in an epoch ending block")
+	errDoubleSignEvidenceTxSingleExpected = errors.New("only one double sign evidence transaction is " +
+		"allowed in a block")
 	errProposalDontMatch = errors.New("failed to insert proposal, because the validated proposal " +
 		"is either nil or it does not match the received one")
 	errValidatorSetDeltaMismatch           = errors.New("validator set delta mismatch")
@@ -91,6 +93,10 @@ type fsm struct {
 	// proposerCommitmentToRegister is a commitment that is registered via state transaction by proposer
 	proposerCommitmentToRegister *CommitmentMessageSigned
 
+	// doubleSignEvidences are the evidences of the validators signing conflicting consensus messages,
+	// which are submitted via state transaction by proposer
+	doubleSignEvidences []*DoubleSignEvidence
+
 	// logger instance
 	logger hcf.Logger
 
@@ -151,6 +157,17 @@ func (f *fsm) BuildProposal(currentRound uint64) ([]byte, error) {
 		}
 	}
 
+	if len(f.doubleSignEvidences) > 0 {
+		tx, err := f.createDoubleSignEvidenceTx()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := f.blockBuilder.WriteTx(tx); err != nil {
+			return nil, fmt.Errorf("failed to apply double sign evidence transaction: %w", err)
+		}
+	}
+
 	// fill the block with transactions
 	f.blockBuilder.Fill()
 
@@ -269,6 +286,17 @@ func (f *fsm) createDistributeRewardsTx() (*types.Transaction, error) {
 	return createStateTransactionWithData(contracts.RewardPoolContract, input), nil
 }
 
+// createDoubleSignEvidenceTx create a StateTransaction, which submits the double sign evidences
+// collected by the proposer. The offending validators are jailed at the end of the epoch.
+func (f *fsm) createDoubleSignEvidenceTx() (*types.Transaction, error) {
+	input, err := (&SubmitDoubleSignEvidenceFn{Evidences: f.doubleSignEvidences}).EncodeAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return createStateTransactionWithData(contracts.DoubleSignEvidenceAddr, input), nil
+}
+
 // ValidateCommit is used to validate that a given commit is valid
 func (f *fsm) ValidateCommit(signer []byte, seal []byte, proposalHash []byte) error {
 	from := types.BytesToAddress(signer)
@@ -416,9 +444,10 @@ func (f *fsm) ValidateSender(msg *proto.Message) error {
 
 func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 	var (
-		commitmentTxExists        bool
-		commitEpochTxExists       bool
-		distributeRewardsTxExists bool
+		commitmentTxExists         bool
+		commitEpochTxExists        bool
+		distributeRewardsTxExists  bool
+		doubleSignEvidenceTxExists bool
 	)
 
 	for _, tx := range transactions {
@@ -472,6 +501,16 @@ func (f *fsm) VerifyStateTransactions(transactions []*types.Transaction) error {
 			if err := f.verifyDistributeRewardsTx(tx); err != nil {
 				return fmt.Errorf("error while verifying distribute rewards transaction. error: %w", err)
 			}
+		case *SubmitDoubleSignEvidenceFn:
+			if doubleSignEvidenceTxExists {
+				return errDoubleSignEvidenceTxSingleExpected
+			}
+
+			doubleSignEvidenceTxExists = true
+
+			if _, err := stateTxData.Offenders(f.validators.Accounts()); err != nil {
+				return fmt.Errorf("error while verifying double sign evidence transaction. error: %w", err)
+			}
 		default:
 			return fmt.Errorf("invalid state transaction data type: %v", stateTxData)
 		}
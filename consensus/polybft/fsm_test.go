@@ -1463,6 +1463,34 @@ func TestFSM_VerifyStateTransaction_TwoCommitmentMessages(t *testing.T) {
 	require.ErrorContains(t, err, "only one commitment tx is allowed per block")
 }
 
+func TestFSM_VerifyStateTransactions_DoubleSignEvidence(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	keyA := validators.GetValidator("A").Key()
+
+	evidence, err := newDoubleSignEvidence(
+		newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 2, 0, []byte{1}),
+		newTestSignedMessage(t, keyA, proto.MessageType_PREPARE, 2, 0, []byte{2}))
+	require.NoError(t, err)
+
+	f := &fsm{
+		validators:          validator.NewValidatorSet(validators.GetPublicIdentities(), hclog.NewNullLogger()),
+		doubleSignEvidences: []*DoubleSignEvidence{evidence},
+	}
+
+	tx, err := f.createDoubleSignEvidenceTx()
+	require.NoError(t, err)
+	require.Equal(t, contracts.DoubleSignEvidenceAddr, *tx.To)
+
+	require.NoError(t, f.VerifyStateTransactions([]*types.Transaction{tx}))
+	require.ErrorIs(t, f.VerifyStateTransactions([]*types.Transaction{tx, tx}), errDoubleSignEvidenceTxSingleExpected)
+
+	// the offender is not in the validator set
+	f.validators = validator.NewValidatorSet(validators.GetPublicIdentities("B", "C", "D"), hclog.NewNullLogger())
+	require.ErrorContains(t, f.VerifyStateTransactions([]*types.Transaction{tx}), "is not a validator")
+}
+
 func TestFSM_Validate_FailToVerifySignatures(t *testing.T) {
 	t.Parallel()
 
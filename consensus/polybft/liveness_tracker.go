@@ -0,0 +1,236 @@
+package polybft
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	hcf "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultLivenessWindow is the default number of the latest blocks the liveness of the validators is tracked over
+	defaultLivenessWindow = uint64(1000)
+
+	// livenessFirstBlock is the first block carrying the signatures of its parent block,
+	// since the genesis block is not signed
+	livenessFirstBlock = uint64(2)
+)
+
+// livenessTracker records the validators which signed the parent block of each inserted block,
+// so the liveness of the validators can be calculated over the latest blocks.
+// The signatures are taken from the Parent field of the block extra, which is a part of the block hash,
+// hence all the nodes calculate the same liveness
+type livenessTracker struct {
+	state          *State
+	blockchain     blockchainBackend
+	polybftBackend polybftBackend
+	window         uint64
+	logger         hcf.Logger
+}
+
+// newLivenessTracker creates a new instance of liveness tracker
+func newLivenessTracker(
+	logger hcf.Logger,
+	state *State,
+	blockchain blockchainBackend,
+	polybftBackend polybftBackend,
+	window uint64) *livenessTracker {
+	return &livenessTracker{
+		state:          state,
+		blockchain:     blockchain,
+		polybftBackend: polybftBackend,
+		window:         window,
+		logger:         logger,
+	}
+}
+
+// PostBlock records the signers of the parent block of the inserted block, along with the ones
+// of the blocks in the window which are not recorded yet, and removes the records out of the window
+func (l *livenessTracker) PostBlock(req *PostBlockRequest) error {
+	blockNumber := req.FullBlock.Block.Number()
+	if blockNumber < livenessFirstBlock {
+		return nil
+	}
+
+	fromBlock := livenessWindowStart(blockNumber, l.window)
+
+	lastBlock, err := l.state.LivenessStore.getLastLivenessBlock()
+	if err != nil {
+		return err
+	}
+
+	if lastBlock >= fromBlock {
+		fromBlock = lastBlock + 1
+	}
+
+	for number := fromBlock; number <= blockNumber; number++ {
+		header := req.FullBlock.Block.Header
+
+		if number != blockNumber {
+			blockHeader, found := l.blockchain.GetHeaderByNumber(number)
+			if !found {
+				return fmt.Errorf("cannot get header of block %d", number)
+			}
+
+			header = blockHeader
+		}
+
+		record, err := newLivenessRecord(l.polybftBackend, header)
+		if err != nil {
+			return fmt.Errorf("cannot create liveness record of block %d: %w", number, err)
+		}
+
+		if err := l.state.LivenessStore.insertLivenessRecord(number, record); err != nil {
+			return err
+		}
+	}
+
+	return l.state.LivenessStore.removeLivenessRecords(livenessWindowStart(blockNumber, l.window))
+}
+
+// newLivenessRecord creates the liveness record of the block from the signatures of its parent block
+func newLivenessRecord(polybftBackend polybftBackend, header *types.Header) (*livenessRecord, error) {
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+
+	// the parent block is signed by the validators of the block before it
+	validators, err := polybftBackend.GetValidators(header.Number-2, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var signers validator.AccountSet
+
+	if extra.Parent != nil {
+		if signers, err = validators.GetFilteredValidators(extra.Parent.Bitmap); err != nil {
+			return nil, err
+		}
+	}
+
+	record := &livenessRecord{
+		Signed: make([]types.Address, 0, len(signers)),
+		Missed: make([]types.Address, 0, len(validators)-len(signers)),
+	}
+
+	for _, v := range validators {
+		if signers.ContainsAddress(v.Address) {
+			record.Signed = append(record.Signed, v.Address)
+		} else {
+			record.Missed = append(record.Missed, v.Address)
+		}
+	}
+
+	return record, nil
+}
+
+// getValidatorsLiveness returns the liveness of the validators over the window ending at the given block,
+// as recorded by the liveness tracker
+func getValidatorsLiveness(state *State, toBlock, window uint64) (*consensus.ValidatorsLiveness, error) {
+	fromBlock := livenessWindowStart(toBlock, window)
+
+	validators, err := state.LivenessStore.getValidatorsLiveness(fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consensus.ValidatorsLiveness{
+		FromBlock:  fromBlock,
+		ToBlock:    toBlock,
+		Validators: validators,
+	}, nil
+}
+
+// calculateValidatorsLiveness calculates the liveness of the validators over the window ending at the given block
+// from the headers of the blocks in the window. Unlike the recorded liveness, it doesn't depend on the blocks
+// the node processed, so all the nodes calculate the same liveness
+func calculateValidatorsLiveness(
+	blockchain blockchainBackend,
+	polybftBackend polybftBackend,
+	toBlock, window uint64) ([]*consensus.ValidatorLiveness, error) {
+	counter := livenessCounter{}
+
+	for number := livenessWindowStart(toBlock, window); number <= toBlock; number++ {
+		header, found := blockchain.GetHeaderByNumber(number)
+		if !found {
+			return nil, fmt.Errorf("cannot get header of block %d", number)
+		}
+
+		record, err := newLivenessRecord(polybftBackend, header)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create liveness record of block %d: %w", number, err)
+		}
+
+		counter.add(record)
+	}
+
+	return counter.sorted(), nil
+}
+
+// getAbsentValidators returns the validators which signed less than the threshold percentage
+// of the blocks they were expected to sign
+func getAbsentValidators(liveness []*consensus.ValidatorLiveness, threshold uint64) []types.Address {
+	var absentees []types.Address
+
+	for _, v := range liveness {
+		if v.ExpectedBlocks > 0 && v.SignedBlocks*100 < threshold*v.ExpectedBlocks {
+			absentees = append(absentees, v.Address)
+		}
+	}
+
+	return absentees
+}
+
+// livenessCounter counts the signed and the expected blocks of each validator
+type livenessCounter map[types.Address]*consensus.ValidatorLiveness
+
+// add counts the blocks of the liveness record
+func (c livenessCounter) add(record *livenessRecord) {
+	for _, address := range record.Signed {
+		liveness := c.get(address)
+		liveness.SignedBlocks++
+		liveness.ExpectedBlocks++
+	}
+
+	for _, address := range record.Missed {
+		c.get(address).ExpectedBlocks++
+	}
+}
+
+func (c livenessCounter) get(address types.Address) *consensus.ValidatorLiveness {
+	liveness, exists := c[address]
+	if !exists {
+		liveness = &consensus.ValidatorLiveness{Address: address}
+		c[address] = liveness
+	}
+
+	return liveness
+}
+
+// sorted returns the liveness of the validators sorted by the validator addresses
+func (c livenessCounter) sorted() []*consensus.ValidatorLiveness {
+	result := make([]*consensus.ValidatorLiveness, 0, len(c))
+	for _, liveness := range c {
+		result = append(result, liveness)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Address.Bytes(), result[j].Address.Bytes()) < 0
+	})
+
+	return result
+}
+
+// livenessWindowStart returns the first block of the window ending at the given block
+func livenessWindowStart(toBlock, window uint64) uint64 {
+	if toBlock < livenessFirstBlock+window {
+		return livenessFirstBlock
+	}
+
+	return toBlock - window + 1
+}
@@ -0,0 +1,127 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/bitmap"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestLivenessHeader creates the header whose parent block is signed by the given validators
+func createTestLivenessHeader(number uint64, validators validator.AccountSet, signers ...types.Address) *types.Header {
+	parentBitmap := bitmap.Bitmap{}
+	for _, signer := range signers {
+		parentBitmap.Set(uint64(validators.Index(signer)))
+	}
+
+	extra := &Extra{
+		Parent:     &Signature{Bitmap: parentBitmap},
+		Committed:  &Signature{},
+		Checkpoint: &CheckpointData{},
+	}
+
+	return &types.Header{
+		Number:    number,
+		ExtraData: extra.MarshalRLPTo(nil),
+	}
+}
+
+func TestLivenessTracker_PostBlock(t *testing.T) {
+	t.Parallel()
+
+	const window = 3
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	accounts := validators.GetPublicIdentities()
+	addrA := validators.GetValidator("A").Address()
+	addrB := validators.GetValidator("B").Address()
+	addrC := validators.GetValidator("C").Address()
+	addrD := validators.GetValidator("D").Address()
+
+	// D signs only the parent of the block 4
+	headers := map[uint64]*types.Header{}
+	for number := uint64(1); number <= 7; number++ {
+		signers := []types.Address{addrA, addrB, addrC}
+		if number == 4 {
+			signers = append(signers, addrD)
+		}
+
+		headers[number] = createTestLivenessHeader(number, accounts, signers...)
+	}
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(func(number uint64) *types.Header {
+		return headers[number]
+	})
+
+	polybftBackendMock := new(polybftBackendMock)
+	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(accounts)
+
+	state := newTestState(t)
+	tracker := newLivenessTracker(hclog.NewNullLogger(), state, blockchainMock, polybftBackendMock, window)
+
+	postBlock := func(number uint64) {
+		require.NoError(t, tracker.PostBlock(&PostBlockRequest{
+			FullBlock: &types.FullBlock{Block: &types.Block{Header: headers[number]}},
+		}))
+	}
+
+	// the first block doesn't carry the signatures of the genesis block
+	postBlock(1)
+
+	liveness, err := getValidatorsLiveness(state, 1, window)
+	require.NoError(t, err)
+	require.Empty(t, liveness.Validators)
+
+	// the blocks missing in the window are recorded from the blockchain
+	postBlock(6)
+
+	liveness, err = getValidatorsLiveness(state, 6, window)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), liveness.FromBlock)
+	require.Equal(t, uint64(6), liveness.ToBlock)
+	require.Len(t, liveness.Validators, 4)
+
+	for _, v := range liveness.Validators {
+		require.Equal(t, uint64(window), v.ExpectedBlocks)
+
+		if v.Address == addrD {
+			require.Equal(t, uint64(1), v.SignedBlocks)
+		} else {
+			require.Equal(t, uint64(window), v.SignedBlocks)
+		}
+	}
+
+	// the liveness calculated from the headers matches the recorded one
+	calculated, err := calculateValidatorsLiveness(blockchainMock, polybftBackendMock, 6, window)
+	require.NoError(t, err)
+	require.Equal(t, liveness.Validators, calculated)
+	require.Equal(t, []types.Address{addrD}, getAbsentValidators(calculated, 50))
+
+	// the block 4 leaves the window
+	postBlock(7)
+
+	lastBlock, err := state.LivenessStore.getLastLivenessBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), lastBlock)
+
+	livenessRecords, err := state.LivenessStore.getValidatorsLiveness(0, 7)
+	require.NoError(t, err)
+
+	for _, v := range livenessRecords {
+		require.Equal(t, uint64(window), v.ExpectedBlocks)
+	}
+
+	calculated, err = calculateValidatorsLiveness(blockchainMock, polybftBackendMock, 7, window)
+	require.NoError(t, err)
+	require.Equal(t, []types.Address{addrD}, getAbsentValidators(calculated, 100))
+	require.Empty(t, getAbsentValidators(calculated, 0))
+
+	// the liveness can't be calculated if a header of the window is missing
+	_, err = calculateValidatorsLiveness(blockchainMock, polybftBackendMock, 8, window)
+	require.Error(t, err)
+}
@@ -101,6 +101,20 @@ func (m *blockchainMock) GetHeaderByHash(hash types.Hash) (*types.Header, bool)
 	panic("Unsupported mock for GetHeaderByHash") //nolint:gocritic
 }
 
+func (m *blockchainMock) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	args := m.Called(number, full)
+
+	if getBlockCallback, ok := args.Get(0).(func(number uint64) *types.Block); ok {
+		block := getBlockCallback(number)
+
+		return block, block != nil
+	}
+
+	block, ok := args.Get(0).(*types.Block)
+
+	return block, ok
+}
+
 func (m *blockchainMock) GetSystemState(provider contract.Provider) SystemState {
 	args := m.Called(provider)
 
@@ -16,6 +16,7 @@ import (
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
@@ -97,6 +98,9 @@ type Polybft struct {
 	// dataDir is the data directory to store the info
 	dataDir string
 
+	// protection is the slashing protection database of the validator key
+	protection *slashingprotection.DB
+
 	// reference to the syncer
 	syncer syncer.Syncer
 
@@ -404,20 +408,21 @@ func ForkManagerFactory(forks *chain.Forks) error {
 func (p *Polybft) Initialize() error {
 	p.logger.Info("initializing polybft...")
 
-	// read account
-	account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
+	// read account and set key
+	key, err := wallet.NewKeyFromSecret(p.config.SecretsManager)
 	if err != nil {
 		return fmt.Errorf("failed to read account data. Error: %w", err)
 	}
 
-	// set key
-	p.key = wallet.NewKey(account)
+	p.key = key
 
 	// create and set syncer
 	p.syncer = syncer.NewSyncer(
 		p.config.Logger.Named("syncer"),
 		p.config.Network,
 		p.config.Blockchain,
+		p.config.StateStorage,
+		p.config.StateSync,
 		time.Duration(p.config.BlockTime)*3*time.Second,
 	)
 
@@ -442,6 +447,15 @@ func (p *Polybft) Initialize() error {
 		return fmt.Errorf("failed to create data directory. Error: %w", err)
 	}
 
+	// the slashing protection database is kept in the consensus directory, the same as for IBFT
+	protection, err := slashingprotection.Open(filepath.Join(p.config.Config.Path, slashingprotection.FileName))
+	if err != nil {
+		return fmt.Errorf("failed to open slashing protection database. Error: %w", err)
+	}
+
+	p.protection = protection
+	p.key.SetSlashingProtection(protection)
+
 	stt, err := newState(filepath.Join(p.dataDir, stateFileName), p.logger, p.closeCh)
 	if err != nil {
 		return fmt.Errorf("failed to create state instance. Error: %w", err)
@@ -657,6 +671,12 @@ func (p *Polybft) Close() error {
 	close(p.closeCh)
 	p.runtime.close()
 
+	if p.protection != nil {
+		if err := p.protection.Close(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -717,14 +737,17 @@ func (p *Polybft) GetBlockCreator(h *types.Header) (types.Address, error) {
 
 // PreCommitState a hook to be called before finalizing state transition on inserting block
 func (p *Polybft) PreCommitState(block *types.Block, _ *state.Transition) error {
-	commitmentTxExists := false
+	var (
+		commitmentTxExists         bool
+		doubleSignEvidenceTxExists bool
+	)
 
 	validators, err := p.GetValidators(block.Number()-1, nil)
 	if err != nil {
 		return err
 	}
 
-	// validate commitment state transactions
+	// validate commitment and double sign evidence state transactions
 	for _, tx := range block.Transactions {
 		if tx.Type != types.StateTx {
 			continue
@@ -735,7 +758,8 @@ func (p *Polybft) PreCommitState(block *types.Block, _ *state.Transition) error
 			return fmt.Errorf("unknown state transaction: tx=%v, error: %w", tx.Hash, err)
 		}
 
-		if signedCommitment, ok := decodedStateTx.(*CommitmentMessageSigned); ok {
+		switch stateTxData := decodedStateTx.(type) {
+		case *CommitmentMessageSigned:
 			if commitmentTxExists {
 				return fmt.Errorf("only one commitment state tx is allowed per block: %v", tx.Hash)
 			}
@@ -744,10 +768,20 @@ func (p *Polybft) PreCommitState(block *types.Block, _ *state.Transition) error
 
 			if err := verifyBridgeCommitmentTx(
 				tx.Hash,
-				signedCommitment,
+				stateTxData,
 				validator.NewValidatorSet(validators, p.logger)); err != nil {
 				return err
 			}
+		case *SubmitDoubleSignEvidenceFn:
+			if doubleSignEvidenceTxExists {
+				return fmt.Errorf("only one double sign evidence state tx is allowed per block: %v", tx.Hash)
+			}
+
+			doubleSignEvidenceTxExists = true
+
+			if _, err := stateTxData.Offenders(validators); err != nil {
+				return fmt.Errorf("invalid double sign evidence state tx %v: %w", tx.Hash, err)
+			}
 		}
 	}
 
@@ -760,6 +794,15 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// GetValidatorsLiveness returns the liveness of the validators over the latest tracked blocks
+func (p *Polybft) GetValidatorsLiveness() (*consensus.ValidatorsLiveness, error) {
+	if p.runtime == nil {
+		return nil, errors.New("consensus runtime is not started")
+	}
+
+	return p.runtime.GetValidatorsLiveness()
+}
+
 // GetBridgeProvider is an implementation of Consensus interface
 // Filters extra data to not contain Committed field
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
@@ -54,6 +54,13 @@ type PolyBFTConfig struct {
 
 	// BlockTimeDrift defines the time slot in which a new block can be created
 	BlockTimeDrift uint64 `json:"blockTimeDrift"`
+
+	// Liveness defines the tracking of the validators liveness
+	Liveness *LivenessConfig `json:"liveness,omitempty"`
+
+	// DoubleSignJailPeriod is the number of the blocks the validators reported for double signing
+	// are excluded from the validator set for, counted from the block the evidence is submitted in
+	DoubleSignJailPeriod uint64 `json:"doubleSignJailPeriod,omitempty"`
 }
 
 // LoadPolyBFTConfig loads chain config from provided path and unmarshals PolyBFTConfig
@@ -112,6 +119,46 @@ type BridgeConfig struct {
 	EventTrackerStartBlocks map[types.Address]uint64 `json:"eventTrackerStartBlocks"`
 }
 
+// LivenessConfig defines the window of blocks the liveness of the validators is tracked over,
+// and the threshold below which the validators are excluded from the next validator set
+type LivenessConfig struct {
+	// Window is the number of the latest blocks the signatures of the validators are counted in
+	Window uint64 `json:"window"`
+
+	// Threshold is the minimum percentage of the blocks in the window a validator has to sign
+	// to stay in the validator set, zero disables the exclusion
+	Threshold uint64 `json:"threshold"`
+}
+
+// GetLivenessWindow returns the number of the latest blocks the liveness of the validators is tracked over
+func (p *PolyBFTConfig) GetLivenessWindow() uint64 {
+	if p.Liveness == nil || p.Liveness.Window == 0 {
+		return defaultLivenessWindow
+	}
+
+	return p.Liveness.Window
+}
+
+// GetLivenessThreshold returns the minimum percentage of the tracked blocks a validator has to sign,
+// zero if the validators are never excluded for missing the blocks
+func (p *PolyBFTConfig) GetLivenessThreshold() uint64 {
+	if p.Liveness == nil {
+		return 0
+	}
+
+	return p.Liveness.Threshold
+}
+
+// GetDoubleSignJailPeriod returns the number of the blocks the validators reported for double signing
+// are excluded from the validator set for
+func (p *PolyBFTConfig) GetDoubleSignJailPeriod() uint64 {
+	if p.DoubleSignJailPeriod == 0 {
+		return defaultDoubleSignJailPeriod
+	}
+
+	return p.DoubleSignJailPeriod
+}
+
 func (p *PolyBFTConfig) IsBridgeEnabled() bool {
 	return p.Bridge != nil
 }
@@ -2,15 +2,19 @@ package polybft
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
+	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -345,3 +349,39 @@ func Test_GenesisPostHookFactory(t *testing.T) {
 		})
 	}
 }
+
+func TestPolybft_Multicast_RefusedSignature(t *testing.T) {
+	t.Parallel()
+
+	protection, err := slashingprotection.Open(filepath.Join(t.TempDir(), slashingprotection.FileName))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = protection.Close()
+	})
+
+	key := createTestKey(t)
+	key.SetSlashingProtection(protection)
+
+	runtime := &consensusRuntime{
+		logger: hclog.NewNullLogger(),
+		config: &runtimeConfig{Key: key},
+	}
+
+	view := &ibftProto.View{Height: 3, Round: 0}
+	require.NotNil(t, runtime.BuildPrepareMessage([]byte{1}, view))
+
+	// the conflicting prepare message is refused by the slashing protection
+	refused := runtime.BuildPrepareMessage([]byte{2}, view)
+	require.Nil(t, refused)
+
+	// the topic is not joined, so publishing any message to it would panic
+	polybft := &Polybft{
+		logger:         hclog.NewNullLogger(),
+		consensusTopic: &network.Topic{},
+	}
+
+	require.NotPanics(t, func() {
+		polybft.Multicast(refused)
+	})
+}
@@ -56,6 +56,15 @@ type stakeManager struct {
 	supernetManagerContract types.Address
 	maxValidatorSetSize     int
 	eventsGetter            *eventsGetter[*contractsapi.TransferEvent]
+
+	// liveness defines the exclusion of the validators missing the blocks, nil disables it
+	liveness *LivenessConfig
+	// jailPeriod is the number of the blocks the validators reported for double signing
+	// are excluded from the validator set for, zero disables it
+	jailPeriod uint64
+	// blockchain and polybftBackend provide the headers and the validators the liveness is calculated from
+	blockchain     blockchainBackend
+	polybftBackend polybftBackend
 }
 
 // newStakeManager returns a new instance of stake manager
@@ -66,7 +75,10 @@ func newStakeManager(
 	key ethgo.Key,
 	validatorSetAddr, supernetManagerAddr types.Address,
 	blockchain blockchainBackend,
+	polybftBackend polybftBackend,
 	maxValidatorSetSize int,
+	liveness *LivenessConfig,
+	jailPeriod uint64,
 ) *stakeManager {
 	eventsGetter := &eventsGetter[*contractsapi.TransferEvent]{
 		blockchain: blockchain,
@@ -89,6 +101,10 @@ func newStakeManager(
 		supernetManagerContract: supernetManagerAddr,
 		maxValidatorSetSize:     maxValidatorSetSize,
 		eventsGetter:            eventsGetter,
+		liveness:                liveness,
+		jailPeriod:              jailPeriod,
+		blockchain:              blockchain,
+		polybftBackend:          polybftBackend,
 	}
 }
 
@@ -196,6 +212,28 @@ func (s *stakeManager) UpdateValidatorSet(
 	// stake map that holds stakes for all validators
 	stakeMap := fullValidatorSet.Validators
 
+	// the validators reported for double signing are excluded from the validator set,
+	// until their evidences leave the jail period, while their stake stays locked
+	jailed, err := s.getJailedValidators(fullValidatorSet.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jailed validators. Epoch: %d. Error: %w", epoch, err)
+	}
+
+	for _, address := range jailed {
+		delete(stakeMap, address)
+	}
+
+	// the validators missing too many blocks are excluded from the validator set,
+	// until their missed blocks leave the liveness window
+	absentees, err := s.getAbsentValidators(fullValidatorSet.BlockNumber, stakeMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absent validators. Epoch: %d. Error: %w", epoch, err)
+	}
+
+	for _, address := range absentees {
+		delete(stakeMap, address)
+	}
+
 	// slice of all validator set
 	newValidatorSet := stakeMap.getSorted(s.maxValidatorSetSize)
 	// set of all addresses that will be in next validator set
@@ -257,6 +295,102 @@ func (s *stakeManager) UpdateValidatorSet(
 	return delta, nil
 }
 
+// getAbsentValidators returns the validators of the stake map which signed less than the liveness threshold
+// of the blocks in the window ending at the given block. The liveness is calculated from the headers
+// of the window, so all the nodes agree on the absent validators. The validators are not excluded
+// if none of the active ones would be left
+func (s *stakeManager) getAbsentValidators(toBlock uint64, stakeMap validatorStakeMap) ([]types.Address, error) {
+	if s.liveness == nil || s.liveness.Threshold == 0 {
+		return nil, nil
+	}
+
+	liveness, err := calculateValidatorsLiveness(s.blockchain, s.polybftBackend, toBlock, s.liveness.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	absentees := getAbsentValidators(liveness, s.liveness.Threshold)
+
+	absent := make(map[types.Address]struct{}, len(absentees))
+	for _, address := range absentees {
+		absent[address] = struct{}{}
+	}
+
+	activeLeft := false
+
+	for address, v := range stakeMap {
+		if _, isAbsent := absent[address]; !isAbsent && v.VotingPower.Cmp(bigZero) > 0 {
+			activeLeft = true
+
+			break
+		}
+	}
+
+	if !activeLeft {
+		s.logger.Warn("All the validators missed too many blocks, none of them is excluded", "block", toBlock)
+
+		return nil, nil
+	}
+
+	for _, address := range absentees {
+		s.logger.Info("Validator excluded for missing blocks", "validator", address, "block", toBlock)
+	}
+
+	return absentees, nil
+}
+
+// getJailedValidators returns the validators reported by the double sign evidences submitted
+// in the blocks of the jail period ending at the given block. The evidences are read from the blocks,
+// so all the nodes agree on the jailed validators, even the ones which never processed the evidences
+func (s *stakeManager) getJailedValidators(toBlock uint64) ([]types.Address, error) {
+	if s.jailPeriod == 0 {
+		return nil, nil
+	}
+
+	fromBlock := uint64(1)
+	if toBlock >= s.jailPeriod {
+		fromBlock = toBlock - s.jailPeriod + 1
+	}
+
+	jailed := []types.Address{}
+	reported := map[types.Address]struct{}{}
+
+	for number := fromBlock; number <= toBlock; number++ {
+		block, found := s.blockchain.GetBlockByNumber(number, true)
+		if !found {
+			return nil, fmt.Errorf("cannot get block %d", number)
+		}
+
+		submitEvidenceFn, err := getDoubleSignEvidenceTx(block.Transactions)
+		if err != nil {
+			return nil, fmt.Errorf("could not get double sign evidences from block %d. Error: %w", number, err)
+		}
+
+		if submitEvidenceFn == nil {
+			continue
+		}
+
+		for _, evidence := range submitEvidenceFn.Evidences {
+			// the evidences are already verified on the block validation
+			offender, err := evidence.Verify()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, exists := reported[offender]; exists {
+				continue
+			}
+
+			s.logger.Info("Validator jailed for double signing", "validator", offender, "block", number)
+
+			reported[offender] = struct{}{}
+			jailed = append(jailed, offender)
+		}
+	}
+
+	return jailed, nil
+}
+
 // getBlsKey returns bls key for validator from the supernet contract
 func (s *stakeManager) getBlsKey(address types.Address) (*bls.PublicKey, error) {
 	getValidatorFn := &contractsapi.GetValidatorCustomSupernetManagerFn{
@@ -157,7 +157,10 @@ func FuzzTestStakeManagerPostBlock(f *testing.F) {
 			validatorSetAddr,
 			types.StringToAddress("0x0002"),
 			nil,
+			nil,
 			5,
+			nil,
+			0,
 		)
 
 		// insert initial full validator set
@@ -204,7 +207,10 @@ func FuzzTestStakeManagerUpdateValidatorSet(f *testing.F) {
 		wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
 		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 		nil,
+		nil,
 		10,
+		nil,
+		0,
 	)
 
 	seeds := []updateValidatorSetF{
@@ -4,9 +4,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/go-ibft/messages/proto"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/txrelayer"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -78,7 +80,10 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
 			validatorSetAddr, types.StringToAddress("0x0002"),
 			nil,
+			nil,
 			5,
+			nil,
+			0,
 		)
 
 		// insert initial full validator set
@@ -134,7 +139,10 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
 			types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 			nil,
+			nil,
 			5,
+			nil,
+			0,
 		)
 
 		// insert initial full validator set
@@ -200,7 +208,10 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
 			types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 			nil,
+			nil,
 			5,
+			nil,
+			0,
 		)
 
 		// insert initial full validator set
@@ -261,7 +272,10 @@ func TestStakeManager_PostBlock(t *testing.T) {
 			wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
 			types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 			bcMock,
+			nil,
 			5,
+			nil,
+			0,
 		)
 
 		// insert initial full validator set
@@ -324,7 +338,10 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 		wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
 		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
 		nil,
+		nil,
 		10,
+		nil,
+		0,
 	)
 
 	t.Run("UpdateValidatorSet - only update", func(t *testing.T) {
@@ -444,6 +461,163 @@ func TestStakeManager_UpdateValidatorSet(t *testing.T) {
 	})
 }
 
+func TestStakeManager_DoubleSignEvidence(t *testing.T) {
+	t.Parallel()
+
+	const (
+		evidenceBlock = uint64(5)
+		jailPeriod    = uint64(10)
+	)
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	keyA := validators.GetValidator("A").Key()
+	offenderIndex := uint64(validators.GetPublicIdentities().Index(types.Address(keyA.Address())))
+
+	evidence, err := newDoubleSignEvidence(
+		newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 3, 0, []byte{1}),
+		newTestSignedMessage(t, keyA, proto.MessageType_COMMIT, 3, 0, []byte{2}))
+	require.NoError(t, err)
+
+	input, err := (&SubmitDoubleSignEvidenceFn{Evidences: []*DoubleSignEvidence{evidence}}).EncodeAbi()
+	require.NoError(t, err)
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetBlockByNumber", mock.Anything, true).Return(func(number uint64) *types.Block {
+		block := &types.Block{Header: &types.Header{Number: number}}
+
+		if number == evidenceBlock {
+			block.Transactions = []*types.Transaction{
+				createStateTransactionWithData(contracts.DoubleSignEvidenceAddr, input),
+			}
+		}
+
+		return block
+	})
+
+	// the jailing is derived from the blocks, so a fresh node which never processed
+	// the block carrying the evidence agrees on the jailed validators
+	updateValidatorSet := func(blockNumber uint64) *validator.ValidatorSetDelta {
+		state := newTestState(t)
+
+		stakeManager := newStakeManager(
+			hclog.NewNullLogger(),
+			state,
+			nil,
+			wallet.NewEcdsaSigner(keyA),
+			types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
+			blockchainMock,
+			nil,
+			10,
+			nil,
+			jailPeriod,
+		)
+
+		require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{
+			Validators:  newValidatorStakeMap(validators.GetPublicIdentities()),
+			BlockNumber: blockNumber,
+		}))
+
+		delta, err := stakeManager.UpdateValidatorSet(1, validators.GetPublicIdentities())
+		require.NoError(t, err)
+		require.Len(t, delta.Added, 0)
+		require.Len(t, delta.Updated, 0)
+
+		return delta
+	}
+
+	// the validator is not jailed before the evidence is submitted
+	require.False(t, updateValidatorSet(evidenceBlock-1).Removed.IsSet(offenderIndex))
+
+	// the jailed validator is removed from the validator set while the evidence is in the jail period
+	require.True(t, updateValidatorSet(evidenceBlock).Removed.IsSet(offenderIndex))
+	require.True(t, updateValidatorSet(evidenceBlock+jailPeriod-1).Removed.IsSet(offenderIndex))
+
+	// the validator is released once the evidence leaves the jail period
+	require.False(t, updateValidatorSet(evidenceBlock+jailPeriod).Removed.IsSet(offenderIndex))
+}
+
+func TestStakeManager_UpdateValidatorSet_LivenessThreshold(t *testing.T) {
+	t.Parallel()
+
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	accounts := validators.GetPublicIdentities()
+	state := newTestState(t)
+
+	// the last validator signs a single block, while the third one signs half of the blocks in the window
+	headers := map[uint64]*types.Header{}
+
+	for number := uint64(1); number <= 5; number++ {
+		signers := []types.Address{accounts[0].Address, accounts[1].Address}
+
+		if number%2 == 0 {
+			signers = append(signers, accounts[2].Address)
+		}
+
+		if number == 2 {
+			signers = append(signers, accounts[3].Address)
+		}
+
+		headers[number] = createTestLivenessHeader(number, accounts, signers...)
+	}
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(func(number uint64) *types.Header {
+		return headers[number]
+	})
+
+	polybftBackendMock := new(polybftBackendMock)
+	polybftBackendMock.On("GetValidators", mock.Anything, mock.Anything).Return(accounts)
+
+	stakeManager := newStakeManager(
+		hclog.NewNullLogger(),
+		state,
+		nil,
+		wallet.NewEcdsaSigner(validators.GetValidator("A").Key()),
+		types.StringToAddress("0x0001"), types.StringToAddress("0x0002"),
+		blockchainMock,
+		polybftBackendMock,
+		10,
+		&LivenessConfig{Window: 4, Threshold: 50},
+		0,
+	)
+
+	require.NoError(t, state.StakeStore.insertFullValidatorSet(validatorSetState{
+		Validators:  newValidatorStakeMap(accounts),
+		BlockNumber: 5,
+	}))
+
+	// the liveness records of the node don't affect the validator set
+	require.NoError(t, state.LivenessStore.insertLivenessRecord(5, &livenessRecord{
+		Signed: []types.Address{},
+		Missed: []types.Address{accounts[0].Address, accounts[1].Address},
+	}))
+
+	delta, err := stakeManager.UpdateValidatorSet(1, accounts.Copy())
+	require.NoError(t, err)
+	require.Len(t, delta.Added, 0)
+	require.Len(t, delta.Updated, 0)
+
+	for i := range accounts {
+		// only the last validator is removed, since the third one signed exactly the threshold of the blocks
+		require.Equal(t, i == 3, delta.Removed.IsSet(uint64(i)))
+	}
+
+	// the validators are not excluded if none of them would be left
+	for number := uint64(1); number <= 5; number++ {
+		headers[number] = createTestLivenessHeader(number, accounts)
+	}
+
+	delta, err = stakeManager.UpdateValidatorSet(1, accounts.Copy())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), delta.Removed.Len())
+
+	// the validator set can't be updated if a header of the window is missing
+	delete(headers, 3)
+
+	_, err = stakeManager.UpdateValidatorSet(1, accounts.Copy())
+	require.Error(t, err)
+}
+
 func TestStakeCounter_ShouldBeDeterministic(t *testing.T) {
 	t.Parallel()
 
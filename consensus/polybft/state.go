@@ -37,6 +37,7 @@ type State struct {
 	EpochStore            *EpochStore
 	ProposerSnapshotStore *ProposerSnapshotStore
 	StakeStore            *StakeStore
+	LivenessStore         *LivenessStore
 }
 
 // newState creates new instance of State
@@ -54,6 +55,7 @@ func newState(path string, logger hclog.Logger, closeCh chan struct{}) (*State,
 		EpochStore:            &EpochStore{db: db},
 		ProposerSnapshotStore: &ProposerSnapshotStore{db: db},
 		StakeStore:            &StakeStore{db: db},
+		LivenessStore:         &LivenessStore{db: db},
 	}
 
 	if err = s.initStorages(); err != nil {
@@ -80,7 +82,11 @@ func (s *State) initStorages() error {
 			return err
 		}
 
-		return s.StakeStore.initialize(tx)
+		if err := s.StakeStore.initialize(tx); err != nil {
+			return err
+		}
+
+		return s.LivenessStore.initialize(tx)
 	})
 }
 
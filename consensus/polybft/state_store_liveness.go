@@ -0,0 +1,117 @@
+package polybft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// bucket to store the signers of the parent block of each tracked block
+	livenessBucket = []byte("liveness")
+)
+
+/*
+Bolt DB schema:
+
+liveness/
+|--> blockNumber -> *livenessRecord (json marshalled)
+*/
+
+// livenessRecord holds the validators which signed the parent block of the given block,
+// and the ones which were expected to sign it but didn't
+type livenessRecord struct {
+	Signed []types.Address `json:"signed"`
+	Missed []types.Address `json:"missed"`
+}
+
+type LivenessStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *LivenessStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(livenessBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(livenessBucket), err)
+	}
+
+	return nil
+}
+
+// insertLivenessRecord inserts the liveness record of the given block (or updates it if exists)
+func (s *LivenessStore) insertLivenessRecord(blockNumber uint64, record *livenessRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		raw, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(livenessBucket).Put(common.EncodeUint64ToBytes(blockNumber), raw)
+	})
+}
+
+// getLastLivenessBlock returns the number of the last block whose liveness record is stored,
+// zero if there are no records
+func (s *LivenessStore) getLastLivenessBlock() (uint64, error) {
+	var blockNumber uint64
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(livenessBucket).Cursor().Last()
+		if k != nil {
+			blockNumber = common.EncodeBytesToUint64(k)
+		}
+
+		return nil
+	})
+
+	return blockNumber, err
+}
+
+// getValidatorsLiveness returns the number of the signed and the expected blocks of each validator
+// in the given range of blocks (inclusive), sorted by the validator addresses
+func (s *LivenessStore) getValidatorsLiveness(fromBlock, toBlock uint64) ([]*consensus.ValidatorLiveness, error) {
+	counter := livenessCounter{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(livenessBucket).Cursor()
+		from, to := common.EncodeUint64ToBytes(fromBlock), common.EncodeUint64ToBytes(toBlock)
+
+		for k, v := c.Seek(from); k != nil && bytes.Compare(k, to) <= 0; k, v = c.Next() {
+			var record livenessRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return err
+			}
+
+			counter.add(&record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counter.sorted(), nil
+}
+
+// removeLivenessRecords removes the liveness records of the blocks lower than the given one
+func (s *LivenessStore) removeLivenessRecords(beforeBlock uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(livenessBucket)
+		c := bucket.Cursor()
+		before := common.EncodeUint64ToBytes(beforeBlock)
+
+		for k, _ := c.First(); k != nil && bytes.Compare(k, before) < 0; k, _ = c.First() {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_LivenessRecords(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addrA = types.StringToAddress("0xA")
+		addrB = types.StringToAddress("0xB")
+		addrC = types.StringToAddress("0xC")
+	)
+
+	state := newTestState(t)
+
+	lastBlock, err := state.LivenessStore.getLastLivenessBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), lastBlock)
+
+	for blockNumber := uint64(2); blockNumber <= 5; blockNumber++ {
+		record := &livenessRecord{Signed: []types.Address{addrB, addrA}, Missed: []types.Address{}}
+		if blockNumber%2 == 0 {
+			record.Signed = append(record.Signed, addrC)
+		} else {
+			record.Missed = append(record.Missed, addrC)
+		}
+
+		require.NoError(t, state.LivenessStore.insertLivenessRecord(blockNumber, record))
+	}
+
+	lastBlock, err = state.LivenessStore.getLastLivenessBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), lastBlock)
+
+	liveness, err := state.LivenessStore.getValidatorsLiveness(3, 5)
+	require.NoError(t, err)
+	require.Equal(t, []*consensus.ValidatorLiveness{
+		{Address: addrA, SignedBlocks: 3, ExpectedBlocks: 3},
+		{Address: addrB, SignedBlocks: 3, ExpectedBlocks: 3},
+		{Address: addrC, SignedBlocks: 1, ExpectedBlocks: 3},
+	}, liveness)
+
+	require.NoError(t, state.LivenessStore.removeLivenessRecords(5))
+
+	liveness, err = state.LivenessStore.getValidatorsLiveness(0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*consensus.ValidatorLiveness{
+		{Address: addrA, SignedBlocks: 1, ExpectedBlocks: 1},
+		{Address: addrB, SignedBlocks: 1, ExpectedBlocks: 1},
+		{Address: addrC, SignedBlocks: 0, ExpectedBlocks: 1},
+	}, liveness)
+}
@@ -20,6 +20,7 @@ func decodeStateTransaction(txData []byte) (contractsapi.StateTransactionInput,
 		commitFn            contractsapi.CommitStateReceiverFn
 		commitEpochFn       contractsapi.CommitEpochValidatorSetFn
 		distributeRewardsFn contractsapi.DistributeRewardForRewardPoolFn
+		submitEvidenceFn    SubmitDoubleSignEvidenceFn
 		obj                 contractsapi.StateTransactionInput
 	)
 
@@ -32,6 +33,9 @@ func decodeStateTransaction(txData []byte) (contractsapi.StateTransactionInput,
 	} else if bytes.Equal(sig, distributeRewardsFn.Sig()) {
 		// distribute rewards
 		obj = &contractsapi.DistributeRewardForRewardPoolFn{}
+	} else if bytes.Equal(sig, submitEvidenceFn.Sig()) {
+		// double sign evidence
+		obj = &SubmitDoubleSignEvidenceFn{}
 	} else {
 		return nil, fmt.Errorf("unknown state transaction")
 	}
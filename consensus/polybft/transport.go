@@ -5,6 +5,7 @@ import (
 
 	ibftProto "github.com/0xPolygon/go-ibft/messages/proto"
 	polybftProto "github.com/0xPolygon/polygon-edge/consensus/polybft/proto"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -16,7 +17,7 @@ type BridgeTransport interface {
 
 // subscribeToIbftTopic subscribes to ibft topic
 func (p *Polybft) subscribeToIbftTopic() error {
-	return p.consensusTopic.Subscribe(func(obj interface{}, _ peer.ID) {
+	return p.consensusTopic.Subscribe(func(obj interface{}, from peer.ID) {
 		if !p.runtime.IsActiveValidator() {
 			return
 		}
@@ -28,6 +29,14 @@ func (p *Polybft) subscribeToIbftTopic() error {
 			return
 		}
 
+		if msg.GetView() == nil {
+			p.logger.Debug("consensus engine: validator message without view received", "peer", from)
+			p.config.Network.PenalizePeer(from, network.PenaltyInvalidConsensusMessage, "consensus message without view")
+
+			return
+		}
+
+		p.runtime.doubleSignTracker.AddMessage(msg)
 		p.ibft.AddMessage(msg)
 
 		p.logger.Debug(
@@ -59,6 +68,13 @@ func (p *Polybft) createTopics() (err error) {
 
 // Multicast is implementation of core.Transport interface
 func (p *Polybft) Multicast(msg *ibftProto.Message) {
+	// the message is not built when the slashing protection refuses to sign it
+	if msg == nil {
+		p.logger.Debug("skipped multicast of the consensus message which is not signed")
+
+		return
+	}
+
 	if err := p.consensusTopic.Publish(msg); err != nil {
 		p.logger.Warn("failed to multicast consensus message", "error", err)
 	}
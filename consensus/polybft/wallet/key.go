@@ -5,30 +5,74 @@ import (
 
 	"github.com/0xPolygon/go-ibft/messages/proto"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/ethgo"
 	protobuf "google.golang.org/protobuf/proto"
 )
 
 type Key struct {
-	raw *Account
+	raw     *Account
+	address ethgo.Address
+
+	// remote signs instead of the raw account, if the keys are held by the remote signer
+	remote secrets.Signer
+
+	// protection is consulted before signing the IBFT messages, if set
+	protection *slashingprotection.DB
 }
 
 func NewKey(raw *Account) *Key {
 	return &Key{
-		raw: raw,
+		raw:     raw,
+		address: raw.Ecdsa.Address(),
 	}
 }
 
+// NewRemoteKey creates the key which signs by the keys held by the remote signer
+func NewRemoteKey(remote secrets.Signer) (*Key, error) {
+	address, err := remote.Address()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{
+		address: ethgo.Address(address),
+		remote:  remote,
+	}, nil
+}
+
+// NewKeyFromSecret creates the key by using provided secretsManager,
+// the key signs by the remote signer if the secretsManager is the one
+func NewKeyFromSecret(secretsManager secrets.SecretsManager) (*Key, error) {
+	if remote, ok := secretsManager.(secrets.Signer); ok {
+		return NewRemoteKey(remote)
+	}
+
+	account, err := NewAccountFromSecret(secretsManager)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(account), nil
+}
+
+// SetSlashingProtection sets the slashing protection database, which is consulted
+// before signing the IBFT messages, so the key never signs the conflicting ones
+func (k *Key) SetSlashingProtection(protection *slashingprotection.DB) {
+	k.protection = protection
+}
+
 // String returns hex encoded ECDSA address
 func (k *Key) String() string {
-	return k.raw.Ecdsa.Address().String()
+	return k.address.String()
 }
 
 // Address returns ECDSA address
 func (k *Key) Address() ethgo.Address {
-	return k.raw.Ecdsa.Address()
+	return k.address
 }
 
 // Sign signs the provided digest with BLS key
@@ -39,6 +83,10 @@ func (k *Key) Sign(digest []byte) ([]byte, error) {
 
 // SignWithDomain signs the provided digest with BLS key and provided domain
 func (k *Key) SignWithDomain(digest, domain []byte) ([]byte, error) {
+	if k.remote != nil {
+		return k.remote.SignWithDomain(digest, domain)
+	}
+
 	signature, err := k.raw.Bls.Sign(digest, domain)
 	if err != nil {
 		return nil, err
@@ -54,13 +102,36 @@ func (k *Key) SignIBFTMessage(msg *proto.Message) (*proto.Message, error) {
 		return nil, fmt.Errorf("cannot marshal message: %w", err)
 	}
 
-	if msg.Signature, err = k.raw.Ecdsa.Sign(crypto.Keccak256(msgRaw)); err != nil {
+	digest := crypto.Keccak256(msgRaw)
+
+	// the message is recorded as signed before the signature is released
+	if k.protection != nil {
+		record, err := slashingprotection.NewRecord(msg, digest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create message signature: %w", err)
+		}
+
+		if err := k.protection.CheckAndRecord(types.Address(k.address), record); err != nil {
+			return nil, fmt.Errorf("cannot create message signature: %w", err)
+		}
+	}
+
+	if msg.Signature, err = k.signDigest(digest); err != nil {
 		return nil, fmt.Errorf("cannot create message signature: %w", err)
 	}
 
 	return msg, nil
 }
 
+// signDigest signs the provided digest with ECDSA key
+func (k *Key) signDigest(digest []byte) ([]byte, error) {
+	if k.remote != nil {
+		return k.remote.SignDigest(digest)
+	}
+
+	return k.raw.Ecdsa.Sign(digest)
+}
+
 // RecoverAddressFromSignature calculates keccak256 hash of provided rawContent
 // and recovers signer address from given signature and hash
 func RecoverAddressFromSignature(sig, rawContent []byte) (types.Address, error) {
@@ -82,5 +153,5 @@ func NewEcdsaSigner(ecdsaKey *Key) *ECDSASigner {
 }
 
 func (k *ECDSASigner) Sign(b []byte) ([]byte, error) {
-	return k.raw.Ecdsa.Sign(b)
+	return k.signDigest(b)
 }
@@ -1,10 +1,13 @@
 package wallet
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/0xPolygon/go-ibft/messages/proto"
 	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/slashingprotection"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,3 +61,105 @@ func Test_String(t *testing.T) {
 		assert.Equal(t, key.Address().String(), key.String())
 	}
 }
+
+// testRemoteSigner is the remote signer holding the account in the test process
+type testRemoteSigner struct {
+	account *Account
+}
+
+func (s *testRemoteSigner) Address() (types.Address, error) {
+	return s.account.Address(), nil
+}
+
+func (s *testRemoteSigner) SignDigest(digest []byte) ([]byte, error) {
+	return s.account.Ecdsa.Sign(digest)
+}
+
+func (s *testRemoteSigner) SignWithDomain(digest, domain []byte) ([]byte, error) {
+	return NewKey(s.account).SignWithDomain(digest, domain)
+}
+
+func Test_RemoteKey(t *testing.T) {
+	t.Parallel()
+
+	account := generateTestAccount(t)
+
+	key, err := NewRemoteKey(&testRemoteSigner{account: account})
+	require.NoError(t, err)
+	assert.Equal(t, account.Ecdsa.Address(), key.Address())
+
+	msgNoSig := &proto.Message{
+		From:    key.Address().Bytes(),
+		Type:    proto.MessageType_COMMIT,
+		Payload: &proto.Message_CommitData{},
+	}
+
+	msg, err := key.SignIBFTMessage(msgNoSig)
+	require.NoError(t, err)
+
+	payload, err := msgNoSig.PayloadNoSig()
+	require.NoError(t, err)
+
+	address, err := RecoverAddressFromSignature(msg.Signature, payload)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address(), address)
+
+	digest := []byte("some message")
+
+	ser, err := key.SignWithDomain(digest, bls.DomainCheckpointManager)
+	require.NoError(t, err)
+
+	sig, err := bls.UnmarshalSignature(ser)
+	require.NoError(t, err)
+	assert.True(t, sig.Verify(account.Bls.PublicKey(), digest, bls.DomainCheckpointManager))
+}
+
+func Test_SlashingProtection(t *testing.T) {
+	t.Parallel()
+
+	protection, err := slashingprotection.Open(filepath.Join(t.TempDir(), slashingprotection.FileName))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = protection.Close()
+	})
+
+	key := NewKey(generateTestAccount(t))
+	key.SetSlashingProtection(protection)
+
+	newCommit := func(proposalHash []byte) *proto.Message {
+		return &proto.Message{
+			View: &proto.View{Height: 10, Round: 1},
+			From: key.Address().Bytes(),
+			Type: proto.MessageType_COMMIT,
+			Payload: &proto.Message_CommitData{
+				CommitData: &proto.CommitMessage{ProposalHash: proposalHash},
+			},
+		}
+	}
+
+	_, err = key.SignIBFTMessage(newCommit([]byte{1}))
+	require.NoError(t, err)
+
+	// the same message is signed again
+	_, err = key.SignIBFTMessage(newCommit([]byte{1}))
+	require.NoError(t, err)
+
+	// the conflicting commit at the same height and round
+	_, err = key.SignIBFTMessage(newCommit([]byte{2}))
+	assert.ErrorIs(t, err, slashingprotection.ErrConflictingMessage)
+
+	// the prepare of the same round is older than the commit
+	_, err = key.SignIBFTMessage(&proto.Message{
+		View:    &proto.View{Height: 10, Round: 1},
+		From:    key.Address().Bytes(),
+		Type:    proto.MessageType_PREPARE,
+		Payload: &proto.Message_PrepareData{PrepareData: &proto.PrepareMessage{}},
+	})
+	assert.ErrorIs(t, err, slashingprotection.ErrOlderMessage)
+
+	last, err := protection.LastSigned(types.Address(key.Address()))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), last.Height)
+	assert.Equal(t, slashingprotection.StepCommit, last.Step)
+}
@@ -0,0 +1,189 @@
+package slashingprotection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// InterchangeFormatVersion is the version of the interchange format
+const InterchangeFormatVersion = "1"
+
+// Interchange is the interchange format of the slashing protection data, used to migrate
+// the keys between the nodes without losing the protection. It is a JSON document:
+//
+//	{
+//	  "metadata": {
+//	    "interchange_format_version": "1"
+//	  },
+//	  "data": [
+//	    {
+//	      "address": "0x<20 bytes ECDSA address of the key>",
+//	      "last_signed": {
+//	        "height": "<decimal block height>",
+//	        "round": "<decimal round>",
+//	        "step": "round-change" | "preprepare" | "prepare" | "commit",
+//	        "digest": "0x<keccak256 of the signed message>, empty if unknown"
+//	      }
+//	    }
+//	  ]
+//	}
+//
+// The key signs only the messages after the last signed one, ordered by height, round and step.
+// The message at the same height, round and step is signed again only if it has the same digest.
+// On import, the newer of the imported and the stored messages is kept for each key,
+// and if they are at the same step with different digests, signing at that step is forbidden
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData  `json:"data"`
+}
+
+// InterchangeMetadata is the metadata of the interchange format
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+}
+
+// InterchangeData is the slashing protection data of a single key
+type InterchangeData struct {
+	Address    types.Address      `json:"address"`
+	LastSigned *InterchangeRecord `json:"last_signed"`
+}
+
+// InterchangeRecord is the last signed message of a key in the interchange format
+type InterchangeRecord struct {
+	Height string `json:"height"`
+	Round  string `json:"round"`
+	Step   string `json:"step"`
+	Digest string `json:"digest"`
+}
+
+// toRecord converts the interchange record to the record of the database
+func (r *InterchangeRecord) toRecord() (*Record, error) {
+	height, err := strconv.ParseUint(r.Height, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid height: %w", err)
+	}
+
+	round, err := strconv.ParseUint(r.Round, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid round: %w", err)
+	}
+
+	step, err := parseStep(r.Step)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &Record{
+		Height: height,
+		Round:  round,
+		Step:   step,
+	}
+
+	if r.Digest != "" {
+		if record.Digest, err = hex.DecodeHex(r.Digest); err != nil {
+			return nil, fmt.Errorf("invalid digest: %w", err)
+		}
+	}
+
+	return record, nil
+}
+
+// Export writes the slashing protection data of all the keys in the interchange format
+func (d *DB) Export(w io.Writer) error {
+	records, err := d.records()
+	if err != nil {
+		return err
+	}
+
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion},
+		Data:     make([]*InterchangeData, 0, len(records)),
+	}
+
+	for address, record := range records {
+		lastSigned := &InterchangeRecord{
+			Height: strconv.FormatUint(record.Height, 10),
+			Round:  strconv.FormatUint(record.Round, 10),
+			Step:   record.Step.String(),
+		}
+
+		if len(record.Digest) > 0 {
+			lastSigned.Digest = hex.EncodeToHex(record.Digest)
+		}
+
+		interchange.Data = append(interchange.Data, &InterchangeData{
+			Address:    address,
+			LastSigned: lastSigned,
+		})
+	}
+
+	sort.Slice(interchange.Data, func(i, j int) bool {
+		return interchange.Data[i].Address.String() < interchange.Data[j].Address.String()
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(interchange)
+}
+
+// Import merges the slashing protection data in the interchange format into the database,
+// and returns the number of the imported keys
+func (d *DB) Import(r io.Reader) (int, error) {
+	var interchange Interchange
+	if err := json.NewDecoder(r).Decode(&interchange); err != nil {
+		return 0, fmt.Errorf("unable to decode interchange data: %w", err)
+	}
+
+	if version := interchange.Metadata.InterchangeFormatVersion; version != InterchangeFormatVersion {
+		return 0, fmt.Errorf("unsupported interchange format version: %s", version)
+	}
+
+	// all the records are validated before the merge, so the data is imported completely or not at all
+	records := make(map[types.Address]*Record, len(interchange.Data))
+
+	for _, data := range interchange.Data {
+		if data.LastSigned == nil {
+			continue
+		}
+
+		record, err := data.LastSigned.toRecord()
+		if err != nil {
+			return 0, fmt.Errorf("invalid data of %s: %w", data.Address, err)
+		}
+
+		if existing, ok := records[data.Address]; ok {
+			mergeRecords(existing, record)
+
+			continue
+		}
+
+		records[data.Address] = record
+	}
+
+	if err := d.merge(records); err != nil {
+		return 0, err
+	}
+
+	return len(records), nil
+}
+
+// mergeRecords merges the record into the existing one of the same key, the newer one is kept,
+// and the digest is dropped if the records are at the same step with different digests
+func mergeRecords(existing, record *Record) {
+	switch record.compare(existing) {
+	case 1:
+		*existing = *record
+	case 0:
+		if !bytes.Equal(existing.Digest, record.Digest) {
+			existing.Digest = nil
+		}
+	}
+}
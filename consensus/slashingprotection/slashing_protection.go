@@ -0,0 +1,298 @@
+package slashingprotection
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	bolt "go.etcd.io/bbolt"
+)
+
+// FileName is the name of the slashing protection database in the consensus directory
+const FileName = "slashing-protection.db"
+
+// openTimeout is the timeout of acquiring the lock of the database,
+// which is held by the running node
+const openTimeout = time.Second
+
+var (
+	// signedBucket is the bucket of the last signed messages, keyed by the signer address
+	signedBucket = []byte("signed")
+
+	ErrDatabaseLocked      = errors.New("slashing protection database is used by another process")
+	ErrOlderMessage        = errors.New("the message is older than the last signed message")
+	ErrConflictingMessage  = errors.New("the message conflicts with the last signed message")
+	ErrUnknownMessageType  = errors.New("unknown message type")
+	ErrMessageViewNotFound = errors.New("message view not found")
+)
+
+// Step is the step of the consensus round a message is signed at,
+// the steps are ordered the way a validator goes through a round
+type Step uint8
+
+const (
+	// StepRoundChange is the step of the round change message, sent when entering the round
+	StepRoundChange Step = iota
+	// StepPrePrepare is the step of the proposal
+	StepPrePrepare
+	// StepPrepare is the step of the prepare message
+	StepPrepare
+	// StepCommit is the step of the commit message
+	StepCommit
+)
+
+// stepNames are the names of the steps in the interchange format
+var stepNames = map[Step]string{
+	StepRoundChange: "round-change",
+	StepPrePrepare:  "preprepare",
+	StepPrepare:     "prepare",
+	StepCommit:      "commit",
+}
+
+// String returns the name of the step
+func (s Step) String() string {
+	if name, ok := stepNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(s))
+}
+
+// parseStep parses the name of the step
+func parseStep(name string) (Step, error) {
+	for step, stepName := range stepNames {
+		if stepName == name {
+			return step, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown step: %s", name)
+}
+
+// Record is the last message signed by a key
+type Record struct {
+	Height uint64 `json:"height"`
+	Round  uint64 `json:"round"`
+	Step   Step   `json:"step"`
+
+	// Digest is the digest of the signed message, the message is signed again
+	// only if it has the same digest. Empty digest forbids signing at the same step
+	Digest []byte `json:"digest"`
+}
+
+// NewRecord creates the record of the IBFT message and its digest
+func NewRecord(msg *proto.Message, digest []byte) (*Record, error) {
+	if msg.View == nil {
+		return nil, ErrMessageViewNotFound
+	}
+
+	var step Step
+
+	switch msg.Type {
+	case proto.MessageType_ROUND_CHANGE:
+		step = StepRoundChange
+	case proto.MessageType_PREPREPARE:
+		step = StepPrePrepare
+	case proto.MessageType_PREPARE:
+		step = StepPrepare
+	case proto.MessageType_COMMIT:
+		step = StepCommit
+	default:
+		return nil, ErrUnknownMessageType
+	}
+
+	return &Record{
+		Height: msg.View.Height,
+		Round:  msg.View.Round,
+		Step:   step,
+		Digest: digest,
+	}, nil
+}
+
+// compare compares the height, round and step of the records
+func (r *Record) compare(other *Record) int {
+	switch {
+	case r.Height != other.Height:
+		return compareUint64(r.Height, other.Height)
+	case r.Round != other.Round:
+		return compareUint64(r.Round, other.Round)
+	default:
+		return compareUint64(uint64(r.Step), uint64(other.Step))
+	}
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DB is the slashing protection database. It keeps the last message signed by each key,
+// so a validator never signs conflicting messages, even if it was restored from a backup
+// or started twice
+type DB struct {
+	db   *bolt.DB
+	lock sync.Mutex
+}
+
+// Open opens the slashing protection database at the given path,
+// the database can be opened by a single process only
+func Open(path string) (*DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, ErrDatabaseLocked
+		}
+
+		return nil, err
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(signedBucket)
+
+		return err
+	}); err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return &DB{db: db}, nil
+}
+
+// Close closes the database
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// CheckAndRecord checks the message can be signed by the key without conflicting with the messages
+// signed before, and persists it as the last signed message, so it is done before the signature is released
+func (d *DB) CheckAndRecord(address types.Address, record *Record) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(signedBucket)
+
+		last, err := getRecord(bucket, address)
+		if err != nil {
+			return err
+		}
+
+		if last != nil {
+			switch record.compare(last) {
+			case -1:
+				return fmt.Errorf("%w: height %d, round %d, step %s",
+					ErrOlderMessage, last.Height, last.Round, last.Step)
+			case 0:
+				if len(last.Digest) == 0 || !bytes.Equal(last.Digest, record.Digest) {
+					return fmt.Errorf("%w: height %d, round %d, step %s",
+						ErrConflictingMessage, last.Height, last.Round, last.Step)
+				}
+
+				// the same message is signed again
+				return nil
+			}
+		}
+
+		return putRecord(bucket, address, record)
+	})
+}
+
+// LastSigned returns the last message signed by the key, nil if the key hasn't signed any
+func (d *DB) LastSigned(address types.Address) (*Record, error) {
+	var record *Record
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		var err error
+
+		record, err = getRecord(tx.Bucket(signedBucket), address)
+
+		return err
+	})
+
+	return record, err
+}
+
+// records returns the last signed messages of all the keys
+func (d *DB) records() (map[types.Address]*Record, error) {
+	records := map[types.Address]*Record{}
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(signedBucket).ForEach(func(k, v []byte) error {
+			var record Record
+			if err := json.Unmarshal(v, &record); err != nil {
+				return err
+			}
+
+			records[types.BytesToAddress(k)] = &record
+
+			return nil
+		})
+	})
+
+	return records, err
+}
+
+// merge merges the last signed messages of the keys in a single transaction, for each key
+// the newer message is kept, and signing at the same step is forbidden if the messages differ
+func (d *DB) merge(records map[types.Address]*Record) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(signedBucket)
+
+		for address, record := range records {
+			last, err := getRecord(bucket, address)
+			if err != nil {
+				return err
+			}
+
+			if last == nil {
+				last = record
+			} else {
+				mergeRecords(last, record)
+			}
+
+			if err := putRecord(bucket, address, last); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func getRecord(bucket *bolt.Bucket, address types.Address) (*Record, error) {
+	raw := bucket.Get(address.Bytes())
+	if raw == nil {
+		return nil, nil
+	}
+
+	var record Record
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func putRecord(bucket *bolt.Bucket, address types.Address, record *Record) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(address.Bytes(), raw)
+}
@@ -0,0 +1,210 @@
+package slashingprotection
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), FileName))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
+
+func TestNewRecord(t *testing.T) {
+	t.Parallel()
+
+	record, err := NewRecord(&proto.Message{
+		View: &proto.View{Height: 10, Round: 2},
+		Type: proto.MessageType_PREPARE,
+	}, []byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, &Record{Height: 10, Round: 2, Step: StepPrepare, Digest: []byte{1}}, record)
+
+	_, err = NewRecord(&proto.Message{Type: proto.MessageType_COMMIT}, nil)
+	assert.ErrorIs(t, err, ErrMessageViewNotFound)
+
+	_, err = NewRecord(&proto.Message{View: &proto.View{}, Type: proto.MessageType(100)}, nil)
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+}
+
+func TestDB_CheckAndRecord(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	address := types.StringToAddress("1")
+
+	last, err := db.LastSigned(address)
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	prepare := &Record{Height: 5, Round: 1, Step: StepPrepare, Digest: []byte{1}}
+	require.NoError(t, db.CheckAndRecord(address, prepare))
+
+	// the same message is signed again
+	require.NoError(t, db.CheckAndRecord(address, prepare))
+
+	// the conflicting message at the same step
+	assert.ErrorIs(t,
+		db.CheckAndRecord(address, &Record{Height: 5, Round: 1, Step: StepPrepare, Digest: []byte{2}}),
+		ErrConflictingMessage)
+
+	// the older messages
+	assert.ErrorIs(t,
+		db.CheckAndRecord(address, &Record{Height: 5, Round: 1, Step: StepPrePrepare, Digest: []byte{1}}),
+		ErrOlderMessage)
+	assert.ErrorIs(t,
+		db.CheckAndRecord(address, &Record{Height: 5, Round: 0, Step: StepCommit, Digest: []byte{1}}),
+		ErrOlderMessage)
+	assert.ErrorIs(t,
+		db.CheckAndRecord(address, &Record{Height: 4, Round: 3, Step: StepCommit, Digest: []byte{1}}),
+		ErrOlderMessage)
+
+	// the newer messages
+	commit := &Record{Height: 5, Round: 1, Step: StepCommit, Digest: []byte{1}}
+	require.NoError(t, db.CheckAndRecord(address, commit))
+
+	roundChange := &Record{Height: 5, Round: 2, Step: StepRoundChange, Digest: []byte{3}}
+	require.NoError(t, db.CheckAndRecord(address, roundChange))
+
+	last, err = db.LastSigned(address)
+	require.NoError(t, err)
+	assert.Equal(t, roundChange, last)
+
+	// the keys are protected separately
+	require.NoError(t, db.CheckAndRecord(types.StringToAddress("2"), prepare))
+}
+
+func TestDB_Locked(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName)
+
+	db, err := Open(path)
+	require.NoError(t, err)
+
+	_, err = Open(path)
+	assert.ErrorIs(t, err, ErrDatabaseLocked)
+
+	require.NoError(t, db.Close())
+
+	db, err = Open(path)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
+
+func TestDB_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	addressA, addressB := types.StringToAddress("1"), types.StringToAddress("2")
+
+	source := newTestDB(t)
+	require.NoError(t, source.CheckAndRecord(addressA, &Record{Height: 7, Round: 0, Step: StepCommit, Digest: []byte{1}}))
+	require.NoError(t, source.CheckAndRecord(addressB, &Record{Height: 3, Round: 1, Step: StepPrepare, Digest: []byte{2}}))
+
+	var buffer bytes.Buffer
+	require.NoError(t, source.Export(&buffer))
+
+	target := newTestDB(t)
+	// the stored record of A is older, and the one of B is newer than the imported ones
+	require.NoError(t, target.CheckAndRecord(addressA, &Record{Height: 2, Round: 0, Step: StepCommit}))
+	require.NoError(t, target.CheckAndRecord(addressB, &Record{Height: 4, Round: 0, Step: StepPrepare}))
+
+	imported, err := target.Import(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported)
+
+	last, err := target.LastSigned(addressA)
+	require.NoError(t, err)
+	assert.Equal(t, &Record{Height: 7, Round: 0, Step: StepCommit, Digest: []byte{1}}, last)
+
+	last, err = target.LastSigned(addressB)
+	require.NoError(t, err)
+	assert.Equal(t, &Record{Height: 4, Round: 0, Step: StepPrepare}, last)
+
+	assert.ErrorIs(t,
+		target.CheckAndRecord(addressA, &Record{Height: 7, Round: 0, Step: StepPrepare}),
+		ErrOlderMessage)
+}
+
+func TestDB_ImportConflicting(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	address := types.StringToAddress("1")
+
+	require.NoError(t, db.CheckAndRecord(address, &Record{Height: 7, Round: 0, Step: StepCommit, Digest: []byte{1}}))
+
+	imported, err := db.Import(bytes.NewBufferString(`{
+		"metadata": {"interchange_format_version": "1"},
+		"data": [{
+			"address": "` + address.String() + `",
+			"last_signed": {"height": "7", "round": "0", "step": "commit", "digest": "0x02"}
+		}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+
+	// the digest is dropped, so neither of the messages is signed again
+	assert.ErrorIs(t,
+		db.CheckAndRecord(address, &Record{Height: 7, Round: 0, Step: StepCommit, Digest: []byte{1}}),
+		ErrConflictingMessage)
+}
+
+func TestDB_ImportInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "invalid version",
+			data: `{"metadata": {"interchange_format_version": "2"}, "data": []}`,
+		},
+		{
+			name: "invalid step",
+			data: `{"metadata": {"interchange_format_version": "1"}, "data": [{
+				"address": "0x0000000000000000000000000000000000000001",
+				"last_signed": {"height": "1", "round": "0", "step": "vote"}
+			}]}`,
+		},
+		{
+			name: "invalid height",
+			data: `{"metadata": {"interchange_format_version": "1"}, "data": [{
+				"address": "0x0000000000000000000000000000000000000001",
+				"last_signed": {"height": "0x1", "round": "0", "step": "commit"}
+			}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDB(t)
+
+			_, err := db.Import(bytes.NewBufferString(c.data))
+			assert.Error(t, err)
+
+			records, err := db.records()
+			require.NoError(t, err)
+			assert.Empty(t, records)
+		})
+	}
+}
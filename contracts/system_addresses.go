@@ -13,6 +13,9 @@ var (
 	RewardTokenContract = types.StringToAddress("0x104")
 	// RewardPoolContract is an address of RewardPoolContract contract on the child chain
 	RewardPoolContract = types.StringToAddress("0x105")
+	// DoubleSignEvidenceAddr is an address the double sign evidences are submitted to by the state transactions,
+	// there is no contract deployed to it, since the consensus reads the evidences from the blocks
+	DoubleSignEvidenceAddr = types.StringToAddress("0x106")
 	// StateReceiverContract is an address of bridge contract on the child chain
 	StateReceiverContract = types.StringToAddress("0x1001")
 	// NativeERC20TokenContract is an address of bridge contract (used for transferring ERC20 native tokens on child chain)
@@ -38,10 +38,14 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 		signer = NewFrontierSigner(forks.Homestead)
 	}
 
-	// London signer requires a fallback signer that is defined above.
-	// This is the reason why the london signer check is separated.
+	// Berlin and London signers require a fallback signer that is defined above.
+	// This is the reason why the berlin and london signer checks are separated.
+	if forks.Berlin {
+		signer = NewBerlinSigner(chainID, forks.Homestead, signer)
+	}
+
 	if forks.London {
-		return NewLondonSigner(chainID, forks.Homestead, signer)
+		return NewLondonSigner(chainID, forks.Homestead, forks.Berlin, signer)
 	}
 
 	return signer
@@ -61,14 +65,16 @@ func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 	return sig, nil
 }
 
-// calcTxHash calculates the transaction hash (keccak256 hash of the RLP value)
-func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+// calcTxHash calculates the transaction hash (keccak256 hash of the RLP value).
+// The access list of the dynamic fee transaction is signed only since Berlin, it's signed as empty before
+func calcTxHash(tx *types.Transaction, chainID uint64, isBerlin bool) types.Hash {
 	a := signerPool.Get()
 	isDynamicFeeTx := tx.Type == types.DynamicFeeTx
+	isTypedTx := isDynamicFeeTx || tx.Type == types.AccessListTx
 
 	v := a.NewArray()
 
-	if isDynamicFeeTx {
+	if isTypedTx {
 		v.Set(a.NewUint(chainID))
 	}
 
@@ -93,8 +99,12 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 
 	v.Set(a.NewCopyBytes(tx.Input))
 
-	if isDynamicFeeTx {
-		v.Set(a.NewArray())
+	if isTypedTx {
+		if isBerlin || tx.Type == types.AccessListTx {
+			v.Set(tx.AccessList.MarshalRLPWith(a))
+		} else {
+			v.Set(a.NewArray())
+		}
 	} else {
 		// EIP155
 		if chainID != 0 {
@@ -105,7 +115,7 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	}
 
 	var hash []byte
-	if isDynamicFeeTx {
+	if isTypedTx {
 		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
 	} else {
 		hash = keccak.Keccak256Rlp(nil, v)
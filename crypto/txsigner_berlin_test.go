@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestBerlinSignerSender(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	chainID := uint64(100)
+
+	accessList := types.TxAccessList{
+		{
+			Address:     types.StringToAddress("2"),
+			StorageKeys: []types.Hash{types.StringToHash("3")},
+		},
+	}
+
+	testTable := []struct {
+		name   string
+		txType types.TxType
+	}{
+		{
+			name:   "access list tx",
+			txType: types.AccessListTx,
+		},
+		{
+			name:   "legacy tx falls back",
+			txType: types.LegacyTx,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := GenerateECDSAKey()
+			require.NoError(t, err)
+
+			txn := &types.Transaction{
+				Type:     tt.txType,
+				To:       &toAddress,
+				Value:    big.NewInt(1),
+				GasPrice: big.NewInt(10),
+				Gas:      21000,
+			}
+
+			if tt.txType == types.AccessListTx {
+				txn.AccessList = accessList
+			}
+
+			signer := NewBerlinSigner(chainID, true, NewEIP155Signer(chainID, true))
+
+			signedTx, err := signer.SignTx(txn, key)
+			require.NoError(t, err)
+
+			sender, err := signer.Sender(signedTx)
+			require.NoError(t, err)
+			require.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+
+			// the access list is a part of the signed payload
+			if tt.txType == types.AccessListTx {
+				signedTx.AccessList = nil
+
+				sender, err = signer.Sender(signedTx)
+				require.NoError(t, err)
+				require.NotEqual(t, PubKeyToAddress(&key.PublicKey), sender)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ func NewEIP155Signer(chainID uint64, isHomestead bool) *EIP155Signer {
 
 // Hash is a wrapper function that calls calcTxHash with the EIP155Signer's chainID
 func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
-	return calcTxHash(tx, e.chainID)
+	return calcTxHash(tx, e.chainID, false)
 }
 
 // Sender returns the transaction sender
@@ -25,7 +25,7 @@ func NewFrontierSigner(isHomestead bool) *FrontierSigner {
 
 // Hash is a wrapper function for the calcTxHash, with chainID 0
 func (f *FrontierSigner) Hash(tx *types.Transaction) types.Hash {
-	return calcTxHash(tx, 0)
+	return calcTxHash(tx, 0, false)
 }
 
 // Sender decodes the signature and returns the sender of the transaction
@@ -11,21 +11,24 @@ import (
 type LondonSigner struct {
 	chainID        uint64
 	isHomestead    bool
+	isBerlin       bool
 	fallbackSigner TxSigner
 }
 
-// NewLondonSigner returns a new LondonSigner object
-func NewLondonSigner(chainID uint64, isHomestead bool, fallbackSigner TxSigner) *LondonSigner {
+// NewLondonSigner returns a new LondonSigner object,
+// the access list of the dynamic fee transactions is signed only if Berlin is active
+func NewLondonSigner(chainID uint64, isHomestead, isBerlin bool, fallbackSigner TxSigner) *LondonSigner {
 	return &LondonSigner{
 		chainID:        chainID,
 		isHomestead:    isHomestead,
+		isBerlin:       isBerlin,
 		fallbackSigner: fallbackSigner,
 	}
 }
 
 // Hash is a wrapper function that calls calcTxHash with the LondonSigner's fields
 func (e *LondonSigner) Hash(tx *types.Transaction) types.Hash {
-	return calcTxHash(tx, e.chainID)
+	return calcTxHash(tx, e.chainID, e.isBerlin)
 }
 
 // Sender returns the transaction sender
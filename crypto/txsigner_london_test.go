@@ -79,7 +79,7 @@ func TestLondonSignerSender(t *testing.T) {
 			}
 
 			chainID := testCase.chainID.Uint64()
-			signer := NewLondonSigner(chainID, true, NewEIP155Signer(chainID, true))
+			signer := NewLondonSigner(chainID, true, true, NewEIP155Signer(chainID, true))
 
 			signedTx, signErr := signer.SignTx(txn, key)
 			if signErr != nil {
@@ -99,7 +99,7 @@ func TestLondonSignerSender(t *testing.T) {
 func Test_LondonSigner_Sender(t *testing.T) {
 	t.Parallel()
 
-	signer := NewLondonSigner(100, true, NewEIP155Signer(100, true))
+	signer := NewLondonSigner(100, true, true, NewEIP155Signer(100, true))
 	to := types.StringToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
 
 	r, ok := big.NewInt(0).SetString("102623819621514684481463796449525884981685455700611671612296611353030973716382", 10)
@@ -143,3 +143,40 @@ func Test_LondonSigner_Sender(t *testing.T) {
 		})
 	}
 }
+
+func Test_LondonSigner_AccessList(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	to := types.StringToAddress("1")
+	tx := &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		To:        &to,
+		Gas:       21000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Value:     big.NewInt(1),
+		AccessList: types.TxAccessList{
+			{Address: types.StringToAddress("2"), StorageKeys: []types.Hash{types.StringToHash("3")}},
+		},
+	}
+
+	noAccessListTx := tx.Copy()
+	noAccessListTx.AccessList = nil
+
+	for _, isBerlin := range []bool{false, true} {
+		signer := NewLondonSigner(100, true, isBerlin, NewEIP155Signer(100, true))
+
+		// the access list is signed only since Berlin
+		require.Equal(t, !isBerlin, signer.Hash(tx) == signer.Hash(noAccessListTx))
+
+		signedTx, err := signer.SignTx(tx, key)
+		require.NoError(t, err)
+
+		sender, err := signer.Sender(signedTx)
+		require.NoError(t, err)
+		require.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+	}
+}
@@ -0,0 +1,6 @@
+package ipc
+
+import "errors"
+
+// ErrPathTooLong is returned when the IPC path is too long to be bound to
+var ErrPathTooLong = errors.New("IPC path is too long")
@@ -4,6 +4,7 @@
 package ipc
 
 import (
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -12,6 +13,19 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/common"
 )
 
+// maxPathLength is the maximum length of the unix socket path, the socket address
+// holds 104 bytes on darwin and 108 bytes on linux, including the terminating zero byte
+const maxPathLength = 103
+
+// ValidatePath checks the unix socket can be bound to the IPC path
+func ValidatePath(path string) error {
+	if len(path) > maxPathLength {
+		return fmt.Errorf("%w, %d bytes exceed the limit of %d bytes", ErrPathTooLong, len(path), maxPathLength)
+	}
+
+	return nil
+}
+
 // Dial dials an IPC path
 func Dial(path string) (net.Conn, error) {
 	return net.Dial("unix", path)
@@ -28,7 +42,8 @@ func Listen(path string) (net.Listener, error) {
 		return nil, err
 	}
 
-	if removeErr := os.Remove(path); removeErr != nil {
+	// remove the socket left behind by the previous run
+	if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
 		return nil, removeErr
 	}
 
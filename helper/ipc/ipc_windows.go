@@ -4,12 +4,25 @@
 package ipc
 
 import (
+	"fmt"
 	"net"
 	"time"
 
 	"gopkg.in/natefinch/npipe.v2"
 )
 
+// maxPathLength is the maximum length of the named pipe path
+const maxPathLength = 256
+
+// ValidatePath checks the named pipe can be created on the IPC path
+func ValidatePath(path string) error {
+	if len(path) > maxPathLength {
+		return fmt.Errorf("%w, %d bytes exceed the limit of %d bytes", ErrPathTooLong, len(path), maxPathLength)
+	}
+
+	return nil
+}
+
 // Dial dials an IPC path
 func Dial(path string) (net.Conn, error) {
 	return npipe.Dial(path)
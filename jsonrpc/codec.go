@@ -115,15 +115,21 @@ func (e *ObjectError) MarshalJSON() ([]byte, error) {
 }
 
 const (
-	pending  = "pending"
-	latest   = "latest"
-	earliest = "earliest"
+	pending      = "pending"
+	latest       = "latest"
+	earliest     = "earliest"
+	finalized    = "finalized"
+	safe         = "safe"
+	checkpointed = "checkpointed"
 )
 
 const (
-	PendingBlockNumber  = BlockNumber(-3)
-	LatestBlockNumber   = BlockNumber(-2)
-	EarliestBlockNumber = BlockNumber(-1)
+	CheckpointedBlockNumber = BlockNumber(-6)
+	SafeBlockNumber         = BlockNumber(-5)
+	FinalizedBlockNumber    = BlockNumber(-4)
+	PendingBlockNumber      = BlockNumber(-3)
+	LatestBlockNumber       = BlockNumber(-2)
+	EarliestBlockNumber     = BlockNumber(-1)
 )
 
 type BlockNumber int64
@@ -136,7 +142,7 @@ type BlockNumberOrHash struct {
 // UnmarshalJSON will try to extract the filter's data.
 // Here are the possible input formats :
 //
-// 1 - "latest", "pending" or "earliest"	- self-explaining keywords
+// 1 - "latest", "pending", "earliest", "finalized", "safe" or "checkpointed"	- self-explaining keywords
 // 2 - "0x2"								- block number #2 (EIP-1898 backward compatible)
 // 3 - {blockNumber:	"0x2"}				- EIP-1898 compliant block number #2
 // 4 - {blockHash:		"0xe0e..."}			- EIP-1898 compliant block hash 0xe0e...
@@ -177,6 +183,12 @@ func stringToBlockNumber(str string) (BlockNumber, error) {
 	switch str {
 	case pending, latest:
 		return LatestBlockNumber, nil
+	case finalized:
+		return FinalizedBlockNumber, nil
+	case safe:
+		return SafeBlockNumber, nil
+	case checkpointed:
+		return CheckpointedBlockNumber, nil
 	case earliest:
 		return EarliestBlockNumber, nil
 	}
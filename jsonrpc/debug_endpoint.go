@@ -2,12 +2,15 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -21,6 +24,15 @@ var (
 	ErrTraceGenesisBlock = errors.New("genesis is not traceable")
 	// ErrNoConfig is an error returns when config is empty
 	ErrNoConfig = errors.New("missing config object")
+	// ErrUnknownTracer is an error returned when the requested tracer is not supported
+	ErrUnknownTracer = errors.New("unknown tracer")
+)
+
+const (
+	// callTracerName is the name of the tracer collecting the tree of the calls
+	callTracerName = "callTracer"
+	// prestateTracerName is the name of the tracer collecting the state of the touched accounts
+	prestateTracerName = "prestateTracer"
 )
 
 type debugBlockchainStore interface {
@@ -30,6 +42,9 @@ type debugBlockchainStore interface {
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
+	// GetHeaderByHash gets a header using the provided hash
+	GetHeaderByHash(types.Hash) (*types.Header, bool)
+
 	// ReadTxLookup returns a block hash in which a given txn was mined
 	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
 
@@ -45,8 +60,15 @@ type debugBlockchainStore interface {
 	// TraceTxn traces a transaction in the block, associated with the given hash
 	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
 
-	// TraceCall traces a single call at the point when the given header is mined
-	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	// TraceCall traces a single call at the point when the given header is mined,
+	// with the state and the block context overridden (if any)
+	TraceCall(
+		*types.Transaction,
+		*types.Header,
+		types.StateOverride,
+		*types.BlockOverride,
+		tracer.Tracer,
+	) (interface{}, error)
 }
 
 type debugTxPoolStore interface {
@@ -55,6 +77,9 @@ type debugTxPoolStore interface {
 
 type debugStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+
+	// IsStateAvailable checks if the node keeps the state of the block
+	IsStateAvailable(header *types.Header) bool
 }
 
 type debugStore interface {
@@ -74,6 +99,30 @@ type TraceConfig struct {
 	DisableStorage   bool    `json:"disableStorage"`
 	EnableReturnData bool    `json:"enableReturnData"`
 	Timeout          *string `json:"timeout"`
+
+	// Tracer is the name of the tracer to use instead of the default struct logger
+	Tracer *string `json:"tracer"`
+	// TracerConfig is the config specific to the selected tracer
+	TracerConfig json.RawMessage `json:"tracerConfig"`
+}
+
+// TraceCallConfig is the config of the call tracing, it extends the trace config with the overrides
+type TraceCallConfig struct {
+	TraceConfig
+
+	StateOverrides *stateOverride `json:"stateOverrides"`
+	BlockOverrides *blockOverride `json:"blockOverrides"`
+}
+
+// callTracerConfig is the config of the call tracer
+type callTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"`
+	WithLog     bool `json:"withLog"`
+}
+
+// prestateTracerConfig is the config of the prestate tracer
+type prestateTracerConfig struct {
+	DiffMode bool `json:"diffMode"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -135,6 +184,10 @@ func (d *Debug) TraceTransaction(
 		return nil, ErrTraceGenesisBlock
 	}
 
+	if err := d.checkParentStateAvailable(block); err != nil {
+		return nil, err
+	}
+
 	tracer, cancel, err := newTracer(config)
 	if err != nil {
 		return nil, err
@@ -148,13 +201,21 @@ func (d *Debug) TraceTransaction(
 func (d *Debug) TraceCall(
 	arg *txnArgs,
 	filter BlockNumberOrHash,
-	config *TraceConfig,
+	config *TraceCallConfig,
 ) (interface{}, error) {
+	if config == nil {
+		return nil, ErrNoConfig
+	}
+
 	header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
 	if err != nil {
 		return nil, ErrHeaderNotFound
 	}
 
+	if err := checkStateAvailable(header, d.store); err != nil {
+		return nil, err
+	}
+
 	tx, err := DecodeTxn(arg, d.store)
 	if err != nil {
 		return nil, err
@@ -165,14 +226,20 @@ func (d *Debug) TraceCall(
 		tx.Gas = header.GasLimit
 	}
 
-	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
+	tracer, cancel, err := newTracer(&config.TraceConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	return d.store.TraceCall(tx, header, tracer)
+	defer cancel()
+
+	return d.store.TraceCall(
+		tx,
+		header,
+		toStateOverride(config.StateOverrides),
+		config.BlockOverrides.ToType(),
+		tracer,
+	)
 }
 
 func (d *Debug) traceBlock(
@@ -183,16 +250,30 @@ func (d *Debug) traceBlock(
 		return nil, ErrTraceGenesisBlock
 	}
 
-	tracer, cancel, err := newTracer(config)
-	defer cancel()
+	if err := d.checkParentStateAvailable(block); err != nil {
+		return nil, err
+	}
 
+	tracer, cancel, err := newTracer(config)
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceBlock(block, tracer)
 }
 
+// checkParentStateAvailable returns an error if the state the block is executed on is not kept by the node
+func (d *Debug) checkParentStateAvailable(block *types.Block) error {
+	parent, ok := d.store.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return fmt.Errorf("parent of block %d not found", block.Number())
+	}
+
+	return checkStateAvailable(parent, d.store)
+}
+
 // newTracer creates new tracer by config
 func newTracer(config *TraceConfig) (
 	tracer.Tracer,
@@ -214,12 +295,10 @@ func newTracer(config *TraceConfig) (
 		}
 	}
 
-	tracer := structtracer.NewStructTracer(structtracer.Config{
-		EnableMemory:     config.EnableMemory,
-		EnableStack:      !config.DisableStack,
-		EnableStorage:    !config.DisableStorage,
-		EnableReturnData: config.EnableReturnData,
-	})
+	tracer, err := selectTracer(config)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
@@ -234,3 +313,54 @@ func newTracer(config *TraceConfig) (
 	// cancellation of context is done by caller
 	return tracer, cancel, nil
 }
+
+// selectTracer creates the tracer requested in the config, the struct tracer is used by default
+func selectTracer(config *TraceConfig) (tracer.Tracer, error) {
+	if config.Tracer == nil {
+		return structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     config.EnableMemory,
+			EnableStack:      !config.DisableStack,
+			EnableStorage:    !config.DisableStorage,
+			EnableReturnData: config.EnableReturnData,
+		}), nil
+	}
+
+	switch *config.Tracer {
+	case callTracerName:
+		tracerConfig := callTracerConfig{}
+		if err := decodeTracerConfig(config.TracerConfig, &tracerConfig); err != nil {
+			return nil, err
+		}
+
+		return calltracer.NewCallTracer(calltracer.Config{
+			OnlyTopCall: tracerConfig.OnlyTopCall,
+			WithLog:     tracerConfig.WithLog,
+		}), nil
+
+	case prestateTracerName:
+		tracerConfig := prestateTracerConfig{}
+		if err := decodeTracerConfig(config.TracerConfig, &tracerConfig); err != nil {
+			return nil, err
+		}
+
+		return prestatetracer.NewPrestateTracer(prestatetracer.Config{
+			DiffMode: tracerConfig.DiffMode,
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTracer, *config.Tracer)
+	}
+}
+
+// decodeTracerConfig decodes the tracer specific config, if it's given
+func decodeTracerConfig(raw json.RawMessage, config interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, config); err != nil {
+		return fmt.Errorf("invalid tracer config: %w", err)
+	}
+
+	return nil
+}
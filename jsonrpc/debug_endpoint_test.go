@@ -8,21 +8,33 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type debugEndpointMockStore struct {
 	headerFn            func() *types.Header
 	getHeaderByNumberFn func(uint64) (*types.Header, bool)
+	getHeaderByHashFn   func(types.Hash) (*types.Header, bool)
 	readTxLookupFn      func(types.Hash) (types.Hash, bool)
 	getBlockByHashFn    func(types.Hash, bool) (*types.Block, bool)
 	getBlockByNumberFn  func(uint64, bool) (*types.Block, bool)
 	traceBlockFn        func(*types.Block, tracer.Tracer) ([]interface{}, error)
 	traceTxnFn          func(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
-	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	isStateAvailableFn  func(*types.Header) bool
+
+	traceCallFn func(
+		*types.Transaction,
+		*types.Header,
+		types.StateOverride,
+		*types.BlockOverride,
+		tracer.Tracer,
+	) (interface{}, error)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
@@ -33,6 +45,10 @@ func (s *debugEndpointMockStore) GetHeaderByNumber(num uint64) (*types.Header, b
 	return s.getHeaderByNumberFn(num)
 }
 
+func (s *debugEndpointMockStore) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
+	return s.getHeaderByHashFn(hash)
+}
+
 func (s *debugEndpointMockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	return s.readTxLookupFn(txnHash)
 }
@@ -53,8 +69,14 @@ func (s *debugEndpointMockStore) TraceTxn(block *types.Block, targetTx types.Has
 	return s.traceTxnFn(block, targetTx, tracer)
 }
 
-func (s *debugEndpointMockStore) TraceCall(tx *types.Transaction, parent *types.Header, tracer tracer.Tracer) (interface{}, error) {
-	return s.traceCallFn(tx, parent, tracer)
+func (s *debugEndpointMockStore) TraceCall(
+	tx *types.Transaction,
+	parent *types.Header,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+	tracer tracer.Tracer,
+) (interface{}, error) {
+	return s.traceCallFn(tx, parent, override, blockOverride, tracer)
 }
 
 func (s *debugEndpointMockStore) GetNonce(acc types.Address) uint64 {
@@ -65,8 +87,22 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) IsStateAvailable(header *types.Header) bool {
+	if s.isStateAvailableFn == nil {
+		return true
+	}
+
+	return s.isStateAvailableFn(header)
+}
+
+// getTestParentHeader returns the parent header of the traced test block
+func getTestParentHeader(hash types.Hash) (*types.Header, bool) {
+	return &types.Header{Number: testHeader10.Number - 1, Hash: hash}, true
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
+	callTracer := "callTracer"
 
 	tests := []struct {
 		input    string
@@ -154,6 +190,16 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				Timeout:          &timeout15s,
 			},
 		},
+		{
+			input: `{
+				"tracer": "callTracer",
+				"tracerConfig": {"onlyTopCall": true}
+			}`,
+			expected: TraceConfig{
+				Tracer:       &callTracer,
+				TracerConfig: json.RawMessage(`{"onlyTopCall": true}`),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -193,6 +239,7 @@ func TestTraceBlockByNumber(t *testing.T) {
 			blockNumber: LatestBlockNumber,
 			config:      &TraceConfig{},
 			store: &debugEndpointMockStore{
+				getHeaderByHashFn: getTestParentHeader,
 				headerFn: func() *types.Header {
 					return testLatestHeader
 				},
@@ -216,6 +263,7 @@ func TestTraceBlockByNumber(t *testing.T) {
 			blockNumber: 10,
 			config:      &TraceConfig{},
 			store: &debugEndpointMockStore{
+				getHeaderByHashFn: getTestParentHeader,
 				getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
 					assert.Equal(t, testHeader10.Number, num)
 					assert.True(t, full)
@@ -300,6 +348,7 @@ func TestTraceBlockByHash(t *testing.T) {
 			blockHash: testHeader10.Hash,
 			config:    &TraceConfig{},
 			store: &debugEndpointMockStore{
+				getHeaderByHashFn: getTestParentHeader,
 				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
 					assert.Equal(t, testHeader10.Hash, hash)
 					assert.True(t, full)
@@ -372,6 +421,7 @@ func TestTraceBlock(t *testing.T) {
 			input:  blockHex,
 			config: &TraceConfig{},
 			store: &debugEndpointMockStore{
+				getHeaderByHashFn: getTestParentHeader,
 				traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
 					assert.Equal(t, testLatestBlock, block)
 
@@ -435,6 +485,7 @@ func TestTraceTransaction(t *testing.T) {
 			txHash: testTxHash1,
 			config: &TraceConfig{},
 			store: &debugEndpointMockStore{
+				getHeaderByHashFn: getTestParentHeader,
 				readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
 					assert.Equal(t, testTxHash1, hash)
 
@@ -575,6 +626,9 @@ func TestTraceCall(t *testing.T) {
 
 		blockNumber = BlockNumber(testBlock10.Number())
 
+		overrideBalance = argUint64(100)
+		overrideNumber  = argUint64(20)
+
 		txArg = &txnArgs{
 			From:      &from,
 			To:        &to,
@@ -606,7 +660,7 @@ func TestTraceCall(t *testing.T) {
 		name   string
 		arg    *txnArgs
 		filter BlockNumberOrHash
-		config *TraceConfig
+		config *TraceCallConfig
 		store  *debugEndpointMockStore
 		result interface{}
 		err    bool
@@ -617,16 +671,60 @@ func TestTraceCall(t *testing.T) {
 			filter: BlockNumberOrHash{
 				BlockNumber: &blockNumber,
 			},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
 					assert.Equal(t, testBlock10.Number(), num)
 
 					return testHeader10, true
 				},
-				traceCallFn: func(tx *types.Transaction, header *types.Header, tracer tracer.Tracer) (interface{}, error) {
+				traceCallFn: func(
+					tx *types.Transaction,
+					header *types.Header,
+					override types.StateOverride,
+					blockOverride *types.BlockOverride,
+					tracer tracer.Tracer,
+				) (interface{}, error) {
 					assert.Equal(t, decodedTx, tx)
 					assert.Equal(t, testHeader10, header)
+					assert.Nil(t, override)
+					assert.Nil(t, blockOverride)
+
+					return testTraceResult, nil
+				},
+			},
+			result: testTraceResult,
+			err:    false,
+		},
+		{
+			name: "should trace the given transaction with the overrides",
+			arg:  txArg,
+			filter: BlockNumberOrHash{
+				BlockNumber: &blockNumber,
+			},
+			config: &TraceCallConfig{
+				StateOverrides: &stateOverride{
+					from: overrideAccount{Balance: &overrideBalance},
+				},
+				BlockOverrides: &blockOverride{
+					Number: &overrideNumber,
+				},
+			},
+			store: &debugEndpointMockStore{
+				getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+					return testHeader10, true
+				},
+				traceCallFn: func(
+					tx *types.Transaction,
+					header *types.Header,
+					override types.StateOverride,
+					blockOverride *types.BlockOverride,
+					tracer tracer.Tracer,
+				) (interface{}, error) {
+					assert.Equal(t, types.StateOverride{
+						from: types.OverrideAccount{Balance: big.NewInt(int64(overrideBalance))},
+					}, override)
+					assert.Equal(t, &types.BlockOverride{Number: (*uint64)(&overrideNumber)}, blockOverride)
 
 					return testTraceResult, nil
 				},
@@ -634,13 +732,24 @@ func TestTraceCall(t *testing.T) {
 			result: testTraceResult,
 			err:    false,
 		},
+		{
+			name: "should return error if config is missing",
+			arg:  txArg,
+			filter: BlockNumberOrHash{
+				BlockNumber: &blockNumber,
+			},
+			config: nil,
+			store:  &debugEndpointMockStore{},
+			result: nil,
+			err:    true,
+		},
 		{
 			name: "should return error if block not found",
 			arg:  txArg,
 			filter: BlockNumberOrHash{
 				BlockHash: &testHeader10.Hash,
 			},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
 					assert.Equal(t, testHeader10.Hash, hash)
@@ -662,7 +771,7 @@ func TestTraceCall(t *testing.T) {
 				Nonce:    &nonce,
 			},
 			filter: BlockNumberOrHash{},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				headerFn: func() *types.Header {
 					return testLatestHeader
@@ -725,6 +834,60 @@ func Test_newTracer(t *testing.T) {
 		assert.ErrorIs(t, ErrNoConfig, err)
 	})
 
+	t.Run("should create tracer by name", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name     string
+			config   string
+			expected interface{}
+		}{
+			{name: callTracerName, config: `{"withLog": true}`, expected: &calltracer.CallTracer{}},
+			{name: prestateTracerName, config: `{"diffMode": true}`, expected: &prestatetracer.PrestateTracer{}},
+		}
+
+		for _, test := range tests {
+			name := test.name
+
+			tracer, cancel, err := newTracer(&TraceConfig{
+				Tracer:       &name,
+				TracerConfig: json.RawMessage(test.config),
+			})
+
+			require.NoError(t, err)
+			assert.IsType(t, test.expected, tracer)
+
+			cancel()
+		}
+	})
+
+	t.Run("should return error if tracer is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		name := "unknownTracer"
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: &name,
+		})
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.ErrorIs(t, err, ErrUnknownTracer)
+	})
+
+	t.Run("should return error if tracer config is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		name := callTracerName
+
+		_, _, err := newTracer(&TraceConfig{
+			Tracer:       &name,
+			TracerConfig: json.RawMessage(`{"withLog": 1}`),
+		})
+
+		assert.Error(t, err)
+	})
+
 	t.Run("GetResult should return errExecutionTimeout if timeout happens", func(t *testing.T) {
 		t.Parallel()
 
@@ -773,3 +936,57 @@ func Test_newTracer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestDebug_StateNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	blockWithTx := &types.Block{
+		Header: testBlock10.Header,
+		Transactions: []*types.Transaction{
+			testTx1,
+		},
+	}
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return testLatestHeader
+		},
+		getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+			return testHeader10, true
+		},
+		getHeaderByHashFn: getTestParentHeader,
+		readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+			return testBlock10.Hash(), true
+		},
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			return blockWithTx, true
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			return blockWithTx, true
+		},
+		isStateAvailableFn: func(header *types.Header) bool {
+			// only the state of the latest block is kept
+			return header.Number == testLatestHeader.Number
+		},
+	}
+
+	endpoint := &Debug{store}
+	config := &TraceConfig{}
+
+	_, err := endpoint.TraceBlockByNumber(BlockNumber(testBlock10.Number()), config)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = endpoint.TraceBlockByHash(testBlock10.Hash(), config)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = endpoint.TraceBlock(hex.EncodeToHex(blockWithTx.MarshalRLP()), config)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = endpoint.TraceTransaction(testTxHash1, config)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	blockNumber := BlockNumber(testBlock10.Number())
+
+	_, err = endpoint.TraceCall(&txnArgs{}, BlockNumberOrHash{BlockNumber: &blockNumber}, &TraceCallConfig{})
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+}
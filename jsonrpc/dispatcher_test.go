@@ -162,6 +162,34 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 			}`),
 			false,
 		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["newPendingTransactions"]
+			}`),
+			false,
+		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["newPendingTransactions", true]
+			}`),
+			false,
+		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["newPendingTransactions", "full"]
+			}`),
+			true,
+		},
+		{
+			[]byte(`{
+				"method": "eth_subscribe",
+				"params": ["syncing"]
+			}`),
+			false,
+		},
 	}
 	for _, c := range cases {
 		data, err := dispatcher.HandleWs(c.msg, mockConnection)
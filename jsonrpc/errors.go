@@ -10,6 +10,8 @@ import (
 
 var (
 	ErrStateNotFound = errors.New("given root and slot not found in storage")
+	// ErrStateNotAvailable is returned when the state of the requested block is not kept by the node
+	ErrStateNotAvailable = errors.New("state not available")
 )
 
 type Error interface {
@@ -8,9 +8,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -99,6 +101,120 @@ func TestEth_Block_GetBlockTransactionCountByNumber(t *testing.T) {
 	assert.Equal(t, "0xa", res)
 }
 
+func TestEth_Block_GetBlockTransactionCountByHash(t *testing.T) {
+	store := &mockBlockStore{}
+	block := newTestBlock(1, hash1)
+
+	for i := 0; i < 10; i++ {
+		block.Transactions = append(block.Transactions, []*types.Transaction{{Nonce: 0, From: addr0}}...)
+	}
+	store.add(block)
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetBlockTransactionCountByHash(hash1)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa", res)
+
+	res, err = eth.GetBlockTransactionCountByHash(hash2)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestEth_GetTransactionByBlockAndIndex(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{}
+	block := newTestBlock(1, hash1)
+	txn0 := newTestTransaction(uint64(0), addr0)
+	txn1 := newTestTransaction(uint64(1), addr1)
+	block.Transactions = []*types.Transaction{txn0, txn1}
+	store.add(block)
+
+	eth := newTestEthEndpoint(store)
+
+	t.Run("returns the transaction of the block with the given number", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetTransactionByBlockNumberAndIndex(BlockNumber(1), argUint64(1))
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		foundTxn := res.(*transaction)
+		assert.Equal(t, txn1.Hash, foundTxn.Hash)
+		assert.Equal(t, argUint64(1), *foundTxn.BlockNumber)
+		assert.Equal(t, hash1, *foundTxn.BlockHash)
+		assert.Equal(t, argUint64(1), *foundTxn.TxIndex)
+	})
+
+	t.Run("returns the transaction of the block with the given hash", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetTransactionByBlockHashAndIndex(hash1, argUint64(0))
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		foundTxn := res.(*transaction)
+		assert.Equal(t, txn0.Hash, foundTxn.Hash)
+		assert.Equal(t, argUint64(0), *foundTxn.TxIndex)
+	})
+
+	t.Run("returns nil if the index is out of range", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetTransactionByBlockNumberAndIndex(LatestBlockNumber, argUint64(2))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+
+		res, err = eth.GetTransactionByBlockHashAndIndex(hash1, argUint64(2))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns nil if the block is not found", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetTransactionByBlockNumberAndIndex(BlockNumber(2), argUint64(0))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+
+		res, err = eth.GetTransactionByBlockHashAndIndex(hash2, argUint64(0))
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestEth_Uncles(t *testing.T) {
+	store := &mockBlockStore{}
+	store.add(newTestBlock(1, hash1))
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetUncleCountByBlockNumber(BlockNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, argUintPtr(0), res)
+
+	res, err = eth.GetUncleCountByBlockNumber(BlockNumber(2))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleCountByBlockHash(hash1)
+	assert.NoError(t, err)
+	assert.Equal(t, argUintPtr(0), res)
+
+	res, err = eth.GetUncleCountByBlockHash(hash2)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleByBlockNumberAndIndex(BlockNumber(1), argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleByBlockHashAndIndex(hash1, argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
 func TestEth_GetTransactionByHash(t *testing.T) {
 	t.Parallel()
 
@@ -244,6 +360,54 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+	block := newTestBlock(1, hash4)
+	store.add(block)
+	txn0 := newTestTransaction(uint64(0), addr0)
+	txn1 := newTestTransaction(uint64(1), addr1)
+	block.Transactions = []*types.Transaction{txn0, txn1}
+	receipt1 := &types.Receipt{
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash1}},
+			{Topics: []types.Hash{hash2}},
+		},
+	}
+	receipt1.SetStatus(types.ReceiptSuccess)
+	receipt2 := &types.Receipt{
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash3}},
+		},
+	}
+	receipt2.SetStatus(types.ReceiptFailed)
+	store.receipts[hash4] = []*types.Receipt{receipt1, receipt2}
+
+	blockNumber := BlockNumber(1)
+
+	for _, filter := range []BlockNumberOrHash{{BlockNumber: &blockNumber}, {BlockHash: &hash4}} {
+		res, err := eth.GetBlockReceipts(filter)
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		receipts := res.([]*receipt)
+		require.Len(t, receipts, 2)
+
+		assert.Equal(t, txn0.Hash, receipts[0].TxHash)
+		assert.Equal(t, argUint64(types.ReceiptSuccess), receipts[0].Status)
+		assert.Len(t, receipts[0].Logs, 2)
+
+		assert.Equal(t, txn1.Hash, receipts[1].TxHash)
+		assert.Equal(t, argUint64(1), receipts[1].TxIndex)
+		assert.Equal(t, argUint64(types.ReceiptFailed), receipts[1].Status)
+		require.Len(t, receipts[1].Logs, 1)
+		assert.Equal(t, argUint64(2), receipts[1].Logs[0].LogIndex)
+		assert.Equal(t, hash4, receipts[1].Logs[0].BlockHash)
+	}
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -333,7 +497,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -357,7 +521,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -383,12 +547,221 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil)
+		res, err := eth.Call(contractCall, BlockNumberOrHash{}, nil, nil)
 		assert.Error(t, err)
 		assert.NotNil(t, res)
 		bres := res.([]byte) //nolint:forcetypeassert
 		assert.Equal(t, []byte(hex.EncodeToString(returnValue)), bres)
 	})
+
+	t.Run("passes the state and the block overrides to the execution", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			balance   = argUint64(100)
+			number    = argUint64(200)
+			timestamp = argUint64(300)
+			baseFee   = argBig(*big.NewInt(400))
+		)
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+		contractCall := &txnArgs{
+			From:     &addr0,
+			To:       &addr1,
+			Gas:      argUintPtr(100000),
+			GasPrice: argBytesPtr([]byte{0x64}),
+			Value:    argBytesPtr([]byte{0x64}),
+			Data:     nil,
+			Nonce:    argUintPtr(0),
+		}
+
+		res, err := eth.Call(
+			contractCall,
+			BlockNumberOrHash{},
+			&stateOverride{addr0: overrideAccount{Balance: &balance}},
+			&blockOverride{Number: &number, Time: &timestamp, Coinbase: &addr1, BaseFee: &baseFee},
+		)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, types.StateOverride{addr0: {Balance: big.NewInt(100)}}, store.stateOverride)
+		assert.Equal(t, &types.BlockOverride{
+			Number:    (*uint64)(&number),
+			Timestamp: (*uint64)(&timestamp),
+			Coinbase:  &addr1,
+			BaseFee:   big.NewInt(400),
+		}, store.blockOverride)
+	})
+}
+
+func TestEth_CallBundle(t *testing.T) {
+	t.Parallel()
+
+	newBundle := func() []*txnArgs {
+		return []*txnArgs{
+			{
+				From:     &addr0,
+				To:       &addr1,
+				Gas:      argUintPtr(100000),
+				GasPrice: argBytesPtr([]byte{0x64}),
+				Data:     nil,
+				Nonce:    argUintPtr(5),
+			},
+			{
+				From:     &addr0,
+				To:       &addr2,
+				GasPrice: argBytesPtr([]byte{0x64}),
+				Data:     nil,
+			},
+		}
+	}
+
+	t.Run("returns error if the bundle is empty", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallBundle(nil, BlockNumberOrHash{}, nil, nil)
+
+		assert.ErrorIs(t, err, ErrEmptyBundle)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns the results of the transactions applied one after another", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallBundle(newBundle(), BlockNumberOrHash{}, nil, nil)
+		require.NoError(t, err)
+
+		// the nonce of the sender follows the previous transaction of the bundle
+		require.Len(t, store.bundleTxns, 2)
+		assert.Equal(t, uint64(5), store.bundleTxns[0].Nonce)
+		assert.Equal(t, uint64(6), store.bundleTxns[1].Nonce)
+
+		// the gas limit of the block is used by default
+		assert.Equal(t, uint64(100000), store.bundleTxns[0].Gas)
+		assert.Equal(t, store.Header().GasLimit, store.bundleTxns[1].Gas)
+		assert.Empty(t, store.bundlePending)
+
+		bundleRes, ok := res.(*callBundleResult)
+		require.True(t, ok)
+
+		assert.Equal(t, argUint64(100), bundleRes.StateBlockNumber)
+		assert.Equal(t, argUint64(2*state.TxGas), bundleRes.TotalGasUsed)
+		require.Len(t, bundleRes.Results, 2)
+
+		for i, txRes := range bundleRes.Results {
+			assert.Equal(t, store.bundleTxns[i].Hash, txRes.TxHash)
+			assert.Equal(t, argUint64(types.ReceiptSuccess), txRes.Status)
+			assert.Empty(t, txRes.Error)
+			require.Len(t, txRes.Logs, 1)
+			assert.Equal(t, argUint64(i), txRes.Logs[0].TxIndex)
+			assert.Equal(t, argUint64(i), txRes.Logs[0].LogIndex)
+		}
+	})
+
+	t.Run("applies the bundle on top of the pending transactions", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.poolNonces = map[types.Address]uint64{addr0: 3}
+		store.pendingTxns = []*types.Transaction{
+			{From: addr0, Nonce: 2, GasPrice: big.NewInt(30)},
+			{From: addr0, Nonce: 1, GasPrice: big.NewInt(10)},
+			{From: addr1, Nonce: 7, GasPrice: big.NewInt(20)},
+		}
+		store.bundleSkipped = []*BundleSkippedTxn{
+			{Hash: hash2, Err: state.ErrNonceIncorrect},
+		}
+		eth := newTestEthEndpoint(store)
+
+		bundle := newBundle()
+		bundle[0].Nonce = nil
+
+		pending := PendingBlockNumber
+
+		res, err := eth.CallBundle(bundle, BlockNumberOrHash{BlockNumber: &pending}, nil, nil)
+		require.NoError(t, err)
+
+		// the pending transactions are ordered by the price and by the nonce, as in the block building
+		assert.Equal(t, []*types.Transaction{
+			{From: addr1, Nonce: 7, GasPrice: big.NewInt(20)},
+			{From: addr0, Nonce: 1, GasPrice: big.NewInt(10)},
+			{From: addr0, Nonce: 2, GasPrice: big.NewInt(30)},
+		}, store.bundlePending)
+
+		// the nonce of the sender follows the pending transactions
+		assert.Equal(t, uint64(3), store.bundleTxns[0].Nonce)
+		assert.Equal(t, uint64(4), store.bundleTxns[1].Nonce)
+
+		// the pending transactions which couldn't be applied are returned
+		bundleRes, ok := res.(*callBundleResult)
+		require.True(t, ok)
+
+		assert.Equal(t, []*bundleSkippedTxn{
+			{TxHash: hash2, Error: state.ErrNonceIncorrect.Error()},
+		}, bundleRes.SkippedPendingTxns)
+	})
+
+	t.Run("limits the number of the applied transactions", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+
+		for i := 0; i < callBundleTxsLimit; i++ {
+			store.pendingTxns = append(store.pendingTxns, &types.Transaction{
+				From:     addr0,
+				Nonce:    uint64(i),
+				GasPrice: big.NewInt(1),
+			})
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		pending := PendingBlockNumber
+
+		_, err := eth.CallBundle(newBundle(), BlockNumberOrHash{BlockNumber: &pending}, nil, nil)
+		require.NoError(t, err)
+
+		// the pending transactions fill the rest of the limit
+		assert.Len(t, store.bundlePending, callBundleTxsLimit-2)
+
+		bundle := make([]*txnArgs, callBundleTxsLimit+1)
+		for i := range bundle {
+			bundle[i] = newBundle()[0]
+		}
+
+		res, err := eth.CallBundle(bundle, BlockNumberOrHash{}, nil, nil)
+		assert.ErrorIs(t, err, ErrBundleTooLarge)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns the revert reason of the failed transaction", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.ethCallError = runtime.ErrExecutionReverted
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallBundle(newBundle(), BlockNumberOrHash{}, nil, nil)
+		require.NoError(t, err)
+
+		bundleRes, ok := res.(*callBundleResult)
+		require.True(t, ok)
+
+		assert.Equal(t, runtime.ErrExecutionReverted.Error(), bundleRes.Results[0].Error)
+	})
 }
 
 type testStore interface {
@@ -405,6 +778,12 @@ type mockBlockStore struct {
 	averageGasPrice int64
 	ethCallError    error
 	returnValue     []byte
+	stateOverride   types.StateOverride
+	blockOverride   *types.BlockOverride
+	poolNonces      map[types.Address]uint64
+	bundlePending   []*types.Transaction
+	bundleTxns      []*types.Transaction
+	bundleSkipped   []*BundleSkippedTxn
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -521,6 +900,15 @@ func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types
 	return nil, false
 }
 
+func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	b, ok := m.GetBlockByNumber(blockNumber, false)
+	if !ok {
+		return nil, false
+	}
+
+	return b.Header, true
+}
+
 func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Hash() == hash {
@@ -531,6 +919,10 @@ func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Bloc
 	return nil, false
 }
 
+func (m *mockBlockStore) IsStateAvailable(header *types.Header) bool {
+	return true
+}
+
 func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
@@ -557,6 +949,10 @@ func (m *mockBlockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bo
 	return nil, false
 }
 
+func (m *mockBlockStore) SubscribePendingTxs() (<-chan types.Hash, func()) {
+	return nil, func() {}
+}
+
 func (m *mockBlockStore) GetSyncProgression() *progress.Progression {
 	if m.isSyncing {
 		return &progress.Progression{
@@ -574,13 +970,71 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction, overrides types.StateOverride) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) ApplyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	overrides types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*runtime.ExecutionResult, error) {
+	m.stateOverride = overrides
+	m.blockOverride = blockOverride
+
 	return &runtime.ExecutionResult{
 		Err:         m.ethCallError,
 		ReturnValue: m.returnValue,
 	}, nil
 }
 
+func (m *mockBlockStore) GetNonce(addr types.Address) uint64 {
+	return m.poolNonces[addr]
+}
+
+func (m *mockBlockStore) GetTxs(inclQueued bool) (
+	map[types.Address][]*types.Transaction,
+	map[types.Address][]*types.Transaction,
+) {
+	pending := make(map[types.Address][]*types.Transaction)
+	for _, tx := range m.pendingTxns {
+		pending[tx.From] = append(pending[tx.From], tx)
+	}
+
+	return pending, nil
+}
+
+func (m *mockBlockStore) ApplyBundle(
+	header *types.Header,
+	pendingTxs []*types.Transaction,
+	txns []*types.Transaction,
+	overrides types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*BundleResult, error) {
+	m.bundlePending = pendingTxs
+	m.bundleTxns = txns
+
+	results := make([]*BundleTxnResult, len(txns))
+
+	for i, tx := range txns {
+		status := types.ReceiptSuccess
+
+		results[i] = &BundleTxnResult{
+			Result: &runtime.ExecutionResult{
+				ReturnValue: m.returnValue,
+				Err:         m.ethCallError,
+			},
+			Receipt: &types.Receipt{
+				TxHash:  tx.Hash,
+				GasUsed: state.TxGas,
+				Status:  &status,
+				Logs: []*types.Log{
+					{Address: *tx.To, Data: []byte{byte(i)}},
+				},
+			},
+		}
+	}
+
+	return &BundleResult{Txns: results, SkippedPendingTxns: m.bundleSkipped}, nil
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -26,6 +28,9 @@ type ethTxPoolStore interface {
 
 	// GetNonce returns the next nonce for this address
 	GetNonce(addr types.Address) uint64
+
+	// GetTxs gets tx pool transactions currently pending for inclusion and currently queued for validation
+	GetTxs(inclQueued bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction)
 }
 
 type Account struct {
@@ -33,11 +38,56 @@ type Account struct {
 	Nonce   uint64
 }
 
+// StorageProof is the Merkle proof of a storage slot (EIP-1186)
+type StorageProof struct {
+	Key   types.Hash
+	Value []byte
+	Proof [][]byte
+}
+
+// AccountProof is the Merkle proof of an account and a set of its storage slots (EIP-1186)
+type AccountProof struct {
+	Balance      *big.Int
+	Nonce        uint64
+	CodeHash     types.Hash
+	StorageHash  types.Hash
+	Proof        [][]byte
+	StorageProof []StorageProof
+}
+
+// callBundleTxsLimit is the maximum number of the transactions applied by a single simulation of the bundle,
+// including the pending transactions the bundle is applied on top of
+const callBundleTxsLimit = 1000
+
+// BundleTxnResult is the result of a transaction of the simulated bundle
+type BundleTxnResult struct {
+	Result  *runtime.ExecutionResult
+	Receipt *types.Receipt
+}
+
+// BundleSkippedTxn is the pending transaction which couldn't be applied before the bundle
+type BundleSkippedTxn struct {
+	Hash types.Hash
+	Err  error
+}
+
+// BundleResult is the result of the simulated bundle
+type BundleResult struct {
+	// Txns are the results of the transactions of the bundle
+	Txns []*BundleTxnResult
+	// SkippedPendingTxns are the pending transactions which couldn't be applied before the bundle
+	SkippedPendingTxns []*BundleSkippedTxn
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*AccountProof, error)
+
+	// IsStateAvailable checks if the node keeps the state of the block
+	IsStateAvailable(header *types.Header) bool
 }
 
 type ethBlockchainStore interface {
@@ -63,7 +113,21 @@ type ethBlockchainStore interface {
 	GetAvgGasPrice() *big.Int
 
 	// ApplyTxn applies a transaction object to the blockchain
-	ApplyTxn(header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error)
+	ApplyTxn(
+		header *types.Header,
+		txn *types.Transaction,
+		override types.StateOverride,
+		blockOverride *types.BlockOverride,
+	) (*runtime.ExecutionResult, error)
+
+	// ApplyBundle applies the pending transactions and then the bundle of transactions one after another
+	ApplyBundle(
+		header *types.Header,
+		pendingTxs []*types.Transaction,
+		txns []*types.Transaction,
+		override types.StateOverride,
+		blockOverride *types.BlockOverride,
+	) (*BundleResult, error)
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
@@ -106,12 +170,7 @@ func (e *Eth) ChainId() (interface{}, error) {
 func (e *Eth) Syncing() (interface{}, error) {
 	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
 		// Node is bulk syncing, return the status
-		return progression{
-			Type:          string(syncProgression.SyncType),
-			StartingBlock: argUint64(syncProgression.StartingBlock),
-			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
-			HighestBlock:  argUint64(syncProgression.HighestBlock),
-		}, nil
+		return toProgression(syncProgression), nil
 	}
 
 	// Node is not bulk syncing
@@ -183,6 +242,94 @@ func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{},
 	return *types.EncodeUint64(uint64(len(block.Transactions))), nil
 }
 
+// GetBlockTransactionCountByHash returns the number of transactions in the block with the given hash
+func (e *Eth) GetBlockTransactionCountByHash(hash types.Hash) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return *types.EncodeUint64(uint64(len(block.Transactions))), nil
+}
+
+// GetTransactionByBlockNumberAndIndex returns the transaction at the given index of the block with the given number
+func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argUint64) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return toBlockTransaction(block, uint64(index)), nil
+}
+
+// GetTransactionByBlockHashAndIndex returns the transaction at the given index of the block with the given hash
+func (e *Eth) GetTransactionByBlockHashAndIndex(hash types.Hash, index argUint64) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return toBlockTransaction(block, uint64(index)), nil
+}
+
+// toBlockTransaction returns the transaction at the given index of the block, or nil if there is none
+func toBlockTransaction(block *types.Block, index uint64) *transaction {
+	if index >= uint64(len(block.Transactions)) {
+		return nil
+	}
+
+	idx := int(index)
+
+	return toTransaction(
+		block.Transactions[idx],
+		argUintPtr(block.Number()),
+		argHashPtr(block.Hash()),
+		&idx,
+	)
+}
+
+// GetUncleCountByBlockNumber returns the number of uncles of the block with the given number,
+// which is always zero as the uncles don't exist in the supported consensus mechanisms
+func (e *Eth) GetUncleCountByBlockNumber(number BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := e.store.GetBlockByNumber(num, false); !ok {
+		return nil, nil
+	}
+
+	return argUintPtr(0), nil
+}
+
+// GetUncleCountByBlockHash returns the number of uncles of the block with the given hash,
+// which is always zero as the uncles don't exist in the supported consensus mechanisms
+func (e *Eth) GetUncleCountByBlockHash(hash types.Hash) (interface{}, error) {
+	if _, ok := e.store.GetBlockByHash(hash, false); !ok {
+		return nil, nil
+	}
+
+	return argUintPtr(0), nil
+}
+
+// GetUncleByBlockNumberAndIndex returns the uncle of the block with the given number,
+// which is always nil as the uncles don't exist in the supported consensus mechanisms
+func (e *Eth) GetUncleByBlockNumberAndIndex(_ BlockNumber, _ argUint64) (interface{}, error) {
+	return nil, nil
+}
+
+// GetUncleByBlockHashAndIndex returns the uncle of the block with the given hash,
+// which is always nil as the uncles don't exist in the supported consensus mechanisms
+func (e *Eth) GetUncleByBlockHashAndIndex(_ types.Hash, _ argUint64) (interface{}, error) {
+	return nil, nil
+}
+
 // BlockNumber returns current block number
 func (e *Eth) BlockNumber() (interface{}, error) {
 	h := e.store.Header()
@@ -338,50 +485,68 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		return nil, nil
 	}
 
-	txn := block.Transactions[txIndex]
-	raw := receipts[txIndex]
-
-	logs := make([]*Log, len(raw.Logs))
-	for i, elem := range raw.Logs {
-		logs[i] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(txIndex),
-			LogIndex:    argUint64(logIndex + i),
-			Removed:     false,
-		}
+	return toReceipt(receipts[txIndex], block.Transactions[txIndex], uint64(txIndex), block.Header, uint64(logIndex)), nil
+}
+
+// GetBlockReceipts returns the receipts of all the transactions of a block
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, nil
 	}
 
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(txIndex),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		// Receipts not written yet on the db
+		e.logger.Warn(
+			fmt.Sprintf("Receipts for block with hash [%s] not found", block.Hash().String()),
+		)
+
+		return nil, nil
+	}
+
+	res := make([]*receipt, len(receipts))
+	logIndex := 0
+
+	for i, raw := range receipts {
+		res[i] = toReceipt(raw, block.Transactions[i], uint64(i), block.Header, uint64(logIndex))
+		logIndex += len(raw.Logs)
 	}
 
 	return res, nil
 }
 
+// getStateHeader returns the header of the block referenced by the filter,
+// if the node keeps the state of the block
+func (e *Eth) getStateHeader(filter BlockNumberOrHash) (*types.Header, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStateAvailable(header, e.store); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
 // GetStorageAt returns the contract storage at the index position
 func (e *Eth) GetStorageAt(
 	address types.Address,
 	index types.Hash,
 	filter BlockNumberOrHash,
 ) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -446,9 +611,56 @@ func (o *overrideAccount) ToType() types.OverrideAccount {
 // StateOverride is the collection of overridden accounts.
 type stateOverride map[types.Address]overrideAccount
 
+// toStateOverride converts the API state override (if any) to the state override of the executor
+func toStateOverride(apiOverride *stateOverride) types.StateOverride {
+	if apiOverride == nil {
+		return nil
+	}
+
+	override := types.StateOverride{}
+	for addr, o := range *apiOverride {
+		override[addr] = o.ToType()
+	}
+
+	return override
+}
+
+// blockOverride is the set of the overridden fields of the block context
+type blockOverride struct {
+	Number   *argUint64     `json:"number"`
+	Time     *argUint64     `json:"time"`
+	Coinbase *types.Address `json:"coinbase"`
+	GasLimit *argUint64     `json:"gasLimit"`
+	BaseFee  *argBig        `json:"baseFee"`
+}
+
+func (o *blockOverride) ToType() *types.BlockOverride {
+	if o == nil {
+		return nil
+	}
+
+	res := &types.BlockOverride{
+		Number:    (*uint64)(o.Number),
+		Timestamp: (*uint64)(o.Time),
+		Coinbase:  o.Coinbase,
+		GasLimit:  (*uint64)(o.GasLimit),
+	}
+
+	if o.BaseFee != nil {
+		res.BaseFee = new(big.Int).Set((*big.Int)(o.BaseFee))
+	}
+
+	return res
+}
+
 // Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOverride) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+func (e *Eth) Call(
+	arg *txnArgs,
+	filter BlockNumberOrHash,
+	apiOverride *stateOverride,
+	apiBlockOverride *blockOverride,
+) (interface{}, error) {
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -462,16 +674,8 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		transaction.Gas = header.GasLimit
 	}
 
-	var override types.StateOverride
-	if apiOverride != nil {
-		override = types.StateOverride{}
-		for addr, o := range *apiOverride {
-			override[addr] = o.ToType()
-		}
-	}
-
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction, override)
+	result, err := e.store.ApplyTxn(header, transaction, toStateOverride(apiOverride), apiBlockOverride.ToType())
 	if err != nil {
 		return nil, err
 	}
@@ -488,8 +692,107 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 	return argBytesPtr(result.ReturnValue), nil
 }
 
+// CallBundle simulates the ordered bundle of transactions on top of the given block,
+// or on top of the pending transactions of the pool, without persisting anything
+func (e *Eth) CallBundle(
+	args []*txnArgs,
+	filter BlockNumberOrHash,
+	apiOverride *stateOverride,
+	apiBlockOverride *blockOverride,
+) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, ErrEmptyBundle
+	}
+
+	if len(args) > callBundleTxsLimit {
+		return nil, ErrBundleTooLarge
+	}
+
+	header, err := e.getStateHeader(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	isPending := filter.BlockNumber != nil && *filter.BlockNumber == PendingBlockNumber
+
+	var pendingTxs []*types.Transaction
+	if isPending {
+		pendingTxs = e.getPendingTxs(header.BaseFee, callBundleTxsLimit-len(args))
+	}
+
+	// the transactions of the same sender follow each other
+	nextNonces := make(map[types.Address]uint64)
+
+	for _, tx := range pendingTxs {
+		nextNonces[tx.From] = tx.Nonce + 1
+	}
+	txns := make([]*types.Transaction, len(args))
+
+	for idx, arg := range args {
+		if arg == nil {
+			return nil, fmt.Errorf("missing transaction %d of the bundle", idx)
+		}
+
+		if arg.From == nil {
+			arg.From = &types.ZeroAddress
+		}
+
+		if arg.Nonce == nil {
+			if nonce, ok := nextNonces[*arg.From]; ok {
+				arg.Nonce = argUintPtr(nonce)
+			} else if isPending {
+				arg.Nonce = argUintPtr(e.store.GetNonce(*arg.From))
+			}
+		}
+
+		tx, err := DecodeTxn(arg, e.store)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the transaction %d of the bundle: %w", idx, err)
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if tx.Gas == 0 {
+			tx.Gas = header.GasLimit
+		}
+
+		nextNonces[tx.From] = tx.Nonce + 1
+		txns[idx] = tx
+	}
+
+	result, err := e.store.ApplyBundle(
+		header,
+		pendingTxs,
+		txns,
+		toStateOverride(apiOverride),
+		apiBlockOverride.ToType(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, skipped := range result.SkippedPendingTxns {
+		e.logger.Debug("pending transaction skipped in the bundle simulation", "hash", skipped.Hash, "err", skipped.Err)
+	}
+
+	return toCallBundleResult(header, txns, result), nil
+}
+
+// getPendingTxs returns at most limit pending transactions of the pool,
+// ordered by the price and by the nonce of the sender as in the block building
+func (e *Eth) getPendingTxs(baseFee uint64, limit int) []*types.Transaction {
+	pending, _ := e.store.GetTxs(false)
+
+	for _, accountTxs := range pending {
+		sort.Slice(accountTxs, func(i, j int) bool {
+			return accountTxs[i].Nonce < accountTxs[j].Nonce
+		})
+	}
+
+	return txpool.OrderByPrice(pending, baseFee, limit)
+}
+
 // EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber, apiOverride *stateOverride) (interface{}, error) {
 	transaction, err := DecodeTxn(arg, e.store)
 	if err != nil {
 		return nil, err
@@ -506,7 +809,12 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
+	if err := checkStateAvailable(header, e.store); err != nil {
+		return nil, err
+	}
+
 	forksInTime := e.store.GetForksInTime(uint64(number))
+	override := toStateOverride(apiOverride)
 
 	var standardGas uint64
 	if transaction.IsContractCreation() && forksInTime.Homestead {
@@ -551,6 +859,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			accountBalance = acc.Balance
 		}
 
+		// The overridden balance is used for the estimation, if any
+		if o, ok := override[transaction.From]; ok && o.Balance != nil {
+			accountBalance = o.Balance
+		}
+
 		availableBalance = new(big.Int).Set(accountBalance)
 
 		if transaction.Value != nil {
@@ -606,7 +919,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		txn := transaction.Copy()
 		txn.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, txn, nil)
+		result, applyErr := e.store.ApplyTxn(header, txn, override, nil)
 
 		if applyErr != nil {
 			// Check the application error.
@@ -694,7 +1007,7 @@ func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
 
 // GetBalance returns the account's balance at the referenced block.
 func (e *Eth) GetBalance(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -735,6 +1048,18 @@ func (e *Eth) GetTransactionCount(address types.Address, filter BlockNumberOrHas
 		blockNumber = *filter.BlockNumber
 	}
 
+	if blockNumber != PendingBlockNumber {
+		if header == nil {
+			if header, err = GetBlockHeader(blockNumber, e.store); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := checkStateAvailable(header, e.store); err != nil {
+			return nil, err
+		}
+	}
+
 	nonce, err := GetNextNonce(address, blockNumber, e.store)
 	if err != nil {
 		if errors.Is(err, ErrStateNotFound) {
@@ -749,7 +1074,7 @@ func (e *Eth) GetTransactionCount(address types.Address, filter BlockNumberOrHas
 
 // GetCode returns account code at given block number
 func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
-	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	header, err := e.getStateHeader(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -768,6 +1093,26 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the Merkle proof of the account and of the given storage slots
+// against the state root of the block (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := e.getStateHeader(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -803,9 +1148,14 @@ func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
 	return argBigPtr(priorityFee), nil
 }
 
-func (e *Eth) FeeHistory(blockCount uint64, newestBlock uint64, rewardPercentiles []float64) (interface{}, error) {
+func (e *Eth) FeeHistory(blockCount uint64, newestBlock BlockNumber, rewardPercentiles []float64) (interface{}, error) {
+	newest, err := GetNumericBlockNumber(newestBlock, e.store)
+	if err != nil {
+		return nil, err
+	}
+
 	// Retrieve oldestBlock, baseFeePerGas, gasUsedRatio, and reward synchronously
-	history, err := e.store.FeeHistory(blockCount, newestBlock, rewardPercentiles)
+	history, err := e.store.FeeHistory(blockCount, newest, rewardPercentiles)
 	if err != nil {
 		return nil, err
 	}
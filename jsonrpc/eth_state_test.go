@@ -575,6 +575,44 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	t.Parallel()
+
+	store := getExampleStore()
+	store.account.storage[hash1] = hash2.Bytes()
+
+	eth := newTestEthEndpoint(store)
+	blockNumberLatest := LatestBlockNumber
+
+	t.Run("should return the proof of the account and its storage", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetProof(addr0, []types.Hash{hash1}, BlockNumberOrHash{BlockNumber: &blockNumberLatest})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProof)
+		assert.True(t, ok)
+
+		assert.Equal(t, addr0, proof.Address)
+		assert.Equal(t, argBig(*big.NewInt(100)), proof.Balance)
+		assert.Equal(t, types.EmptyCodeHash, proof.CodeHash)
+		assert.Equal(t, []argBytes{{0x1}, {0x2}}, proof.AccountProof)
+		assert.Len(t, proof.StorageProof, 1)
+		assert.Equal(t, hash1, proof.StorageProof[0].Key)
+		assert.Equal(t, argBig(*new(big.Int).SetBytes(hash2.Bytes())), proof.StorageProof[0].Value)
+		assert.Equal(t, []argBytes{{0x3}}, proof.StorageProof[0].Proof)
+	})
+
+	t.Run("should fail for unknown block", func(t *testing.T) {
+		t.Parallel()
+
+		blockNumber := BlockNumber(0x10)
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &blockNumber})
+		assert.Error(t, err)
+	})
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -611,6 +649,57 @@ func getExampleStore() *mockSpecialStore {
 // TestEth_EstimateGas_GasLimit tests eth_estimateGas, by using
 // the latest block gas limit for the upper bound, or the specified
 // gas limit in the transaction
+func TestEth_State_NotAvailable(t *testing.T) {
+	t.Parallel()
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{Balance: big.NewInt(100)},
+			storage: make(map[types.Hash][]byte),
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: types.EmptyRootHash,
+			},
+		},
+		statePruned: true,
+	}
+
+	eth := newTestEthEndpoint(store)
+	latest := LatestBlockNumber
+	pending := PendingBlockNumber
+	filter := BlockNumberOrHash{BlockNumber: &latest}
+
+	_, err := eth.GetBalance(addr0, filter)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = eth.GetCode(addr0, filter)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = eth.GetStorageAt(addr0, types.ZeroHash, filter)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = eth.GetProof(addr0, nil, filter)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = eth.GetTransactionCount(addr0, filter)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = eth.Call(&txnArgs{From: &addr0, To: &addr0, Nonce: argUintPtr(0)}, filter, nil, nil)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = eth.EstimateGas(&txnArgs{From: &addr0, To: &addr0, Nonce: argUintPtr(0)}, &latest, nil)
+	assert.ErrorIs(t, err, ErrStateNotAvailable)
+
+	// the pending nonce is read from the pool
+	nonce, err := eth.GetTransactionCount(addr0, BlockNumberOrHash{BlockNumber: &pending})
+	assert.NoError(t, err)
+	assert.Equal(t, argUintPtr(1), nonce)
+}
+
 func TestEth_EstimateGas_GasLimit(t *testing.T) {
 	t.Parallel()
 
@@ -680,7 +769,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -731,6 +820,7 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	estimate, estimateErr := ethEndpoint.EstimateGas(
 		constructMockTx(nil, nil),
 		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -757,6 +847,7 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	estimate, estimateErr := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
 	)
 
 	assert.Equal(t, 0, estimate)
@@ -765,12 +856,53 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+func TestEth_EstimateGas_StateOverride(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	// Account doesn't have any balance
+	store.account.account.Balance = big.NewInt(0)
+
+	// The transaction has a value > 0
+	mockTx := constructMockTx(nil, nil)
+	mockTx.Value = argBytesPtr([]byte{0x1})
+
+	// The balance is overridden to cover the value
+	balance := argUint64(100)
+	override := &stateOverride{
+		*mockTx.From: overrideAccount{Balance: &balance},
+	}
+
+	_, estimateErr := ethEndpoint.EstimateGas(
+		mockTx,
+		nil,
+		override,
+	)
+
+	assert.NoError(t, estimateErr)
+
+	// Make sure the override is used for the execution
+	assert.Equal(t, types.StateOverride{
+		*mockTx.From: {Balance: big.NewInt(100)},
+	}, store.stateOverride)
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
 
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+
+	// stateOverride is the state override of the last applied transaction
+	stateOverride types.StateOverride
+
+	// statePruned is set if the state of the block is pruned
+	statePruned bool
+}
+
+func (m *mockSpecialStore) IsStateAvailable(header *types.Header) bool {
+	return !m.statePruned
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -836,11 +968,43 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*AccountProof, error) {
+	if m.account.address != addr {
+		return nil, ErrStateNotFound
+	}
+
+	proof := &AccountProof{
+		Balance:      m.account.account.Balance,
+		Nonce:        m.account.account.Nonce,
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		Proof:        [][]byte{{0x1}, {0x2}},
+		StorageProof: make([]StorageProof, len(keys)),
+	}
+
+	for i, key := range keys {
+		proof.StorageProof[i] = StorageProof{
+			Key:   key,
+			Value: m.account.storage[key],
+			Proof: [][]byte{{0x3}},
+		}
+	}
+
+	return proof, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, overrides types.StateOverride) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(
+	header *types.Header,
+	txn *types.Transaction,
+	overrides types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*runtime.ExecutionResult, error) {
+	m.stateOverride = overrides
+
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
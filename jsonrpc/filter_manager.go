@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -33,6 +34,13 @@ var defaultTimeout = 1 * time.Minute
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
+
+	// maxStreamUpdates is the maximum number of the updates buffered for a subscription of the pending
+	// transactions or of the sync status, the subscription of a client which doesn't keep up is removed
+	maxStreamUpdates = 4096
+
+	// syncStatusInterval is the interval of checking the sync progression for the syncing subscriptions
+	syncStatusInterval = 1 * time.Second
 )
 
 // filter is an interface that BlockFilter and LogFilter implement
@@ -212,6 +220,120 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// streamFilter is a base of the filters of the updates not related to the new blocks.
+// The updates are buffered up to the limit, and the filter with web socket connection writes them
+// to the stream from its own goroutine, so a slow client doesn't hold up the other filters
+type streamFilter struct {
+	filterBase
+	sync.Mutex
+
+	updates []interface{}
+
+	// notifyCh signals the new updates to the web socket sender
+	notifyCh chan struct{}
+	// doneCh is closed once the filter is removed
+	doneCh chan struct{}
+}
+
+// newStreamFilter initializes streamFilter with unique ID
+func newStreamFilter(ws wsConn) streamFilter {
+	return streamFilter{
+		filterBase: newFilterBase(ws),
+		notifyCh:   make(chan struct{}, 1),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// getStreamFilter returns its own reference so that child struct can return base
+func (f *streamFilter) getStreamFilter() *streamFilter {
+	return f
+}
+
+// push appends the update and notifies the web socket sender.
+// It returns false if the buffer of the updates is full
+func (f *streamFilter) push(update interface{}) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	if len(f.updates) >= maxStreamUpdates {
+		return false
+	}
+
+	f.updates = append(f.updates, update)
+
+	select {
+	case f.notifyCh <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// takeUpdates returns all the buffered updates and clears the buffer
+func (f *streamFilter) takeUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	updates := f.updates
+	f.updates = nil
+
+	return updates
+}
+
+// getUpdates returns the buffered updates
+func (f *streamFilter) getUpdates() (interface{}, error) {
+	updates := f.takeUpdates()
+	if updates == nil {
+		updates = []interface{}{}
+	}
+
+	return updates, nil
+}
+
+// sendUpdates writes the buffered updates to web socket stream
+func (f *streamFilter) sendUpdates() error {
+	for _, update := range f.takeUpdates() {
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamingFilter is a filter based on streamFilter
+type streamingFilter interface {
+	filter
+	getStreamFilter() *streamFilter
+}
+
+// pendingTxFilter is a filter to store the transactions promoted in the pool
+type pendingTxFilter struct {
+	streamFilter
+
+	// fullTx indicates the updates are the full transactions instead of their hashes
+	fullTx bool
+}
+
+// syncingFilter is a filter to store the changes of the sync status
+type syncingFilter struct {
+	streamFilter
+
+	// status is the last sync status appended to the filter
+	status interface{}
+}
+
+// syncStatus is the sync status sent while the node is syncing, false is sent otherwise
+type syncStatus struct {
+	Syncing bool        `json:"syncing"`
+	Status  progression `json:"status"`
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -228,6 +350,16 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// SubscribePendingTxs subscribes for the hashes of the transactions promoted in the pool,
+	// the returned function cancels the subscription
+	SubscribePendingTxs() (<-chan types.Hash, func())
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
@@ -246,6 +378,10 @@ type FilterManager struct {
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
+	// pendingTxsOnce and syncStatusOnce start the feeds of the stream filters on their first subscription
+	pendingTxsOnce sync.Once
+	syncStatusOnce sync.Once
+
 	updateCh chan struct{}
 	closeCh  chan struct{}
 }
@@ -359,6 +495,205 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter, the updates are the hashes of the transactions
+// promoted in the pool, or the full transactions if fullTx is set
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	f.pendingTxsOnce.Do(func() {
+		hashes, cancel := f.store.SubscribePendingTxs()
+
+		go f.runPendingTxs(hashes, cancel)
+	})
+
+	return f.addStreamFilter(&pendingTxFilter{
+		streamFilter: newStreamFilter(ws),
+		fullTx:       fullTx,
+	})
+}
+
+// NewSyncingFilter adds new SyncingFilter, the updates are the changes of the sync status
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	f.syncStatusOnce.Do(func() {
+		go f.runSyncStatus()
+	})
+
+	return f.addStreamFilter(&syncingFilter{
+		streamFilter: newStreamFilter(ws),
+		status:       false,
+	})
+}
+
+// addStreamFilter adds the stream filter and starts its web socket sender
+func (f *FilterManager) addStreamFilter(filter streamingFilter) string {
+	base := filter.getStreamFilter()
+
+	if base.hasWSConn() {
+		base.ws.SetFilterID(base.id)
+
+		go f.runStreamSender(base)
+	}
+
+	return f.addFilter(filter)
+}
+
+// runStreamSender writes the updates of the stream filter to its web socket stream
+// until the filter is removed
+func (f *FilterManager) runStreamSender(filter *streamFilter) {
+	for {
+		select {
+		case <-filter.notifyCh:
+			if err := filter.sendUpdates(); err != nil {
+				if isWsConnClosed(err) {
+					f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", filter.id))
+					f.Uninstall(filter.id)
+
+					return
+				}
+
+				f.logger.Error(fmt.Sprintf("Unable to process flush, %v", err))
+			}
+
+		case <-filter.doneCh:
+			return
+
+		case <-f.closeCh:
+			return
+		}
+	}
+}
+
+// runPendingTxs appends the transactions promoted in the pool to the pending transaction filters
+func (f *FilterManager) runPendingTxs(hashes <-chan types.Hash, cancel func()) {
+	defer cancel()
+
+	for {
+		select {
+		case hash, ok := <-hashes:
+			if !ok {
+				return
+			}
+
+			f.processPendingTx(hash)
+
+		case <-f.closeCh:
+			return
+		}
+	}
+}
+
+// processPendingTx makes each pending transaction filter append the new pending transaction
+func (f *FilterManager) processPendingTx(hash types.Hash) {
+	var (
+		tx         *transaction
+		txFetched  bool
+		overflowed []string
+	)
+
+	f.RLock()
+
+	for id, filter := range f.filters {
+		pendingFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		var update interface{} = hash
+
+		if pendingFilter.fullTx {
+			if !txFetched {
+				if poolTx, ok := f.store.GetPendingTx(hash); ok {
+					tx = toPendingTransaction(poolTx)
+				}
+
+				txFetched = true
+			}
+
+			if tx == nil {
+				// the transaction is not in the pool anymore
+				continue
+			}
+
+			update = tx
+		}
+
+		if !pendingFilter.push(update) {
+			overflowed = append(overflowed, id)
+		}
+	}
+
+	f.RUnlock()
+
+	f.removeOverflowedFilters(overflowed)
+}
+
+// runSyncStatus checks the sync progression periodically
+// and makes each syncing filter append the changed sync status
+func (f *FilterManager) runSyncStatus() {
+	ticker := time.NewTicker(syncStatusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.processSyncStatus(f.getSyncStatus())
+
+		case <-f.closeCh:
+			return
+		}
+	}
+}
+
+// getSyncStatus returns the current sync status
+func (f *FilterManager) getSyncStatus() interface{} {
+	syncProgression := f.store.GetSyncProgression()
+	if syncProgression == nil {
+		return false
+	}
+
+	return syncStatus{
+		Syncing: true,
+		Status:  toProgression(syncProgression),
+	}
+}
+
+// processSyncStatus makes each syncing filter append the sync status if it has changed
+func (f *FilterManager) processSyncStatus(status interface{}) {
+	var overflowed []string
+
+	f.RLock()
+
+	for id, filter := range f.filters {
+		syncFilter, ok := filter.(*syncingFilter)
+		if !ok || syncFilter.status == status {
+			continue
+		}
+
+		syncFilter.status = status
+
+		if !syncFilter.push(status) {
+			overflowed = append(overflowed, id)
+		}
+	}
+
+	f.RUnlock()
+
+	f.removeOverflowedFilters(overflowed)
+}
+
+// removeOverflowedFilters removes the stream filters which exceeded the limit of the buffered updates
+func (f *FilterManager) removeOverflowedFilters(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	f.Lock()
+	for _, id := range ids {
+		f.removeFilterByID(id)
+	}
+	f.Unlock()
+
+	f.logger.Warn(fmt.Sprintf("Removed %d subscriptions not keeping up with the updates", len(ids)))
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -551,6 +886,10 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if stream, ok := filter.(streamingFilter); ok {
+		close(stream.getStreamFilter().doneCh)
+	}
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -717,9 +1056,14 @@ func (f *FilterManager) flushWsFilters() error {
 			continue
 		}
 
+		if _, ok := filter.(streamingFilter); ok {
+			// the stream filters are flushed by their own senders
+			continue
+		}
+
 		if flushErr := filter.sendUpdates(); flushErr != nil {
 			// mark as closed if the connection is closed
-			if errors.Is(flushErr, websocket.ErrCloseSent) || errors.Is(flushErr, net.ErrClosed) {
+			if isWsConnClosed(flushErr) {
 				closedFilterIDs = append(closedFilterIDs, id)
 
 				f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", id))
@@ -747,6 +1091,15 @@ func (f *FilterManager) flushWsFilters() error {
 	return nil
 }
 
+// isWsConnClosed checks if the error of writing to web socket stream means the connection is closed,
+// the connection is not usable anymore once the write times out
+func isWsConnClosed(err error) bool {
+	var netErr net.Error
+
+	return errors.Is(err, websocket.ErrCloseSent) || errors.Is(err, net.ErrClosed) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
+
 type timeHeapImpl []*filterBase
 
 func (t *timeHeapImpl) addFilter(filter *filterBase) {
@@ -546,6 +546,128 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+func TestPendingTxFilter(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashMock, hashMsgCh := newMockWsConnWithMsgCh()
+	fullMock, fullMsgCh := newMockWsConnWithMsgCh()
+
+	m.NewPendingTxFilter(false, hashMock)
+	m.NewPendingTxFilter(true, fullMock)
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(0),
+		R:        big.NewInt(0),
+		S:        big.NewInt(0),
+	}
+	tx.ComputeHash()
+
+	store.emitPendingTx(tx)
+
+	select {
+	case msg := <-hashMsgCh:
+		assert.Contains(t, string(msg), tx.Hash.String())
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending transaction hash not received in 2 seconds")
+	}
+
+	select {
+	case msg := <-fullMsgCh:
+		assert.Contains(t, string(msg), fmt.Sprintf(`"hash":"%s"`, tx.Hash))
+		assert.Contains(t, string(msg), `"nonce":"0x1"`)
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending transaction not received in 2 seconds")
+	}
+}
+
+func TestPendingTxFilter_Overflow(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	// the filter without web socket connection isn't flushed until its updates are requested
+	id := m.NewPendingTxFilter(false, nil)
+
+	for i := 0; i < maxStreamUpdates; i++ {
+		m.processPendingTx(types.StringToHash(strconv.Itoa(i)))
+	}
+
+	assert.True(t, m.Exists(id))
+
+	// the filter is removed once the buffer is full
+	m.processPendingTx(types.StringToHash("overflow"))
+
+	assert.False(t, m.Exists(id))
+}
+
+func TestSyncingFilter(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewSyncingFilter(nil)
+
+	syncing := syncStatus{
+		Syncing: true,
+		Status: progression{
+			Type:          "BULK",
+			StartingBlock: 1,
+			CurrentBlock:  5,
+			HighestBlock:  10,
+		},
+	}
+
+	// only the changes of the sync status are appended
+	m.processSyncStatus(false)
+	m.processSyncStatus(syncing)
+	m.processSyncStatus(syncing)
+	m.processSyncStatus(false)
+
+	updates, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{syncing, false}, updates)
+
+	updates, err = m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+}
+
+func TestStreamFilter_ClosedConnection(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id := m.NewPendingTxFilter(false, &MockClosedWSConnection{})
+
+	assert.True(t, m.Exists(id))
+
+	m.processPendingTx(types.StringToHash("1"))
+
+	// the filter is removed by its sender once writing to the connection fails
+	assert.Eventually(t, func() bool {
+		return !m.Exists(id)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
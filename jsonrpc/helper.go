@@ -14,16 +14,39 @@ var (
 	ErrNegativeBlockNumber      = errors.New("invalid argument 0: block number must not be negative")
 	ErrFailedFetchGenesis       = errors.New("error fetching genesis block header")
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
+	ErrEmptyBundle              = errors.New("bundle must contain at least one transaction")
+	ErrBundleTooLarge           = fmt.Errorf("bundle must contain at most %d transactions", callBundleTxsLimit)
+	ErrCheckpointsNotSupported  = errors.New("the checkpointed blocks are not supported by the consensus")
 )
 
 type latestHeaderGetter interface {
 	Header() *types.Header
 }
 
+// checkpointGetter is implemented by the stores of the consensus mechanisms
+// which checkpoint the blocks to the rootchain
+type checkpointGetter interface {
+	// GetCheckpointedBlockNumber returns the number of the latest block checkpointed to the rootchain
+	GetCheckpointedBlockNumber() (uint64, error)
+}
+
+// getCheckpointedBlockNumber returns the number of the latest checkpointed block,
+// if the store supports the checkpoints
+func getCheckpointedBlockNumber(store interface{}) (uint64, error) {
+	getter, ok := store.(checkpointGetter)
+	if !ok {
+		return 0, ErrCheckpointsNotSupported
+	}
+
+	return getter.GetCheckpointedBlockNumber()
+}
+
 // GetNumericBlockNumber returns block number based on current state or specified number
 func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64, error) {
 	switch number {
-	case LatestBlockNumber, PendingBlockNumber:
+	// the blocks sealed by the BFT consensus are final right away,
+	// so the latest block is both the finalized and the safe one
+	case LatestBlockNumber, PendingBlockNumber, FinalizedBlockNumber, SafeBlockNumber:
 		latest := store.Header()
 		if latest == nil {
 			return 0, ErrLatestNotFound
@@ -31,6 +54,9 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 		return latest.Number, nil
 
+	case CheckpointedBlockNumber:
+		return getCheckpointedBlockNumber(store)
+
 	case EarliestBlockNumber:
 		return 0, nil
 
@@ -51,9 +77,22 @@ type headerGetter interface {
 // GetBlockHeader returns a header using the provided number
 func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, error) {
 	switch number {
-	case PendingBlockNumber, LatestBlockNumber:
+	case PendingBlockNumber, LatestBlockNumber, FinalizedBlockNumber, SafeBlockNumber:
 		return store.Header(), nil
 
+	case CheckpointedBlockNumber:
+		checkpointed, err := getCheckpointedBlockNumber(store)
+		if err != nil {
+			return nil, err
+		}
+
+		header, ok := store.GetHeaderByNumber(checkpointed)
+		if !ok {
+			return nil, fmt.Errorf("error fetching checkpointed block number %d header", checkpointed)
+		}
+
+		return header, nil
+
 	case EarliestBlockNumber:
 		header, ok := store.GetHeaderByNumber(uint64(0))
 		if !ok {
@@ -99,6 +138,19 @@ func GetTxAndBlockByTxHash(txHash types.Hash, store txLookupAndBlockGetter) (*ty
 	return nil, nil
 }
 
+type stateAvailabilityChecker interface {
+	IsStateAvailable(header *types.Header) bool
+}
+
+// checkStateAvailable returns an error if the state of the block is not kept by the node
+func checkStateAvailable(header *types.Header, store stateAvailabilityChecker) error {
+	if !store.IsStateAvailable(header) {
+		return fmt.Errorf("%w: the state of block %d is not kept by the node", ErrStateNotAvailable, header.Number)
+	}
+
+	return nil
+}
+
 type blockGetter interface {
 	Header() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
@@ -240,6 +292,10 @@ func DecodeTxn(arg *txnArgs, store nonceGetter) (*types.Transaction, error) {
 		txn.To = arg.To
 	}
 
+	if arg.AccessList != nil {
+		txn.AccessList = *arg.AccessList
+	}
+
 	txn.ComputeHash()
 
 	return txn, nil
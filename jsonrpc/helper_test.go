@@ -61,6 +61,16 @@ var (
 	}
 )
 
+// checkpointMockStore is the mock store which supports the checkpointed blocks
+type checkpointMockStore struct {
+	*debugEndpointMockStore
+	checkpointedFn func() (uint64, error)
+}
+
+func (s *checkpointMockStore) GetCheckpointedBlockNumber() (uint64, error) {
+	return s.checkpointedFn()
+}
+
 func TestGetNumericBlockNumber(t *testing.T) {
 	t.Parallel()
 
@@ -139,9 +149,40 @@ func TestGetNumericBlockNumber(t *testing.T) {
 			expected: 10,
 			err:      nil,
 		},
+		{
+			name: "should return the latest block's number if finalized or safe is given",
+			num:  FinalizedBlockNumber,
+			store: &debugEndpointMockStore{
+				headerFn: func() *types.Header {
+					return &types.Header{
+						Number: 10,
+					}
+				},
+			},
+			expected: 10,
+			err:      nil,
+		},
+		{
+			name: "should return the checkpointed block's number if checkpointed is given",
+			num:  CheckpointedBlockNumber,
+			store: &checkpointMockStore{
+				checkpointedFn: func() (uint64, error) {
+					return 8, nil
+				},
+			},
+			expected: 8,
+			err:      nil,
+		},
+		{
+			name:     "should return error if checkpointed is given and checkpoints are not supported",
+			num:      CheckpointedBlockNumber,
+			store:    &debugEndpointMockStore{},
+			expected: 0,
+			err:      ErrCheckpointsNotSupported,
+		},
 		{
 			name:     "should return error if negative number is given",
-			num:      -5,
+			num:      -50,
 			store:    &debugEndpointMockStore{},
 			expected: 0,
 			err:      ErrNegativeBlockNumber,
@@ -227,6 +268,35 @@ func TestGetBlockHeader(t *testing.T) {
 			expected: testLatestHeader,
 			err:      nil,
 		},
+		{
+			name: "should return latest if safe is given",
+			num:  SafeBlockNumber,
+			store: &debugEndpointMockStore{
+				headerFn: func() *types.Header {
+					return testLatestHeader
+				},
+			},
+			expected: testLatestHeader,
+			err:      nil,
+		},
+		{
+			name: "should return the checkpointed header if checkpointed is given",
+			num:  CheckpointedBlockNumber,
+			store: &checkpointMockStore{
+				debugEndpointMockStore: &debugEndpointMockStore{
+					getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+						assert.Equal(t, uint64(10), num)
+
+						return testHeader10, true
+					},
+				},
+				checkpointedFn: func() (uint64, error) {
+					return 10, nil
+				},
+			},
+			expected: testHeader10,
+			err:      nil,
+		},
 		{
 			name: "should return header at arbitrary height",
 			num:  10,
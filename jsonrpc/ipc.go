@@ -0,0 +1,167 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
+	"github.com/hashicorp/go-hclog"
+)
+
+// ipcWriteTimeout is the timeout of writing a message to the IPC connection,
+// the connection of a client which doesn't read the messages is closed afterwards
+const ipcWriteTimeout = 10 * time.Second
+
+// ipcWrapper is a wrapping object for the IPC connection and logger,
+// it serves the same purpose as the WS wrapper so the subscriptions work over IPC
+type ipcWrapper struct {
+	sync.Mutex
+
+	conn     net.Conn     // the actual IPC connection
+	logger   hclog.Logger // module logger
+	filterID string       // filter ID
+}
+
+func (w *ipcWrapper) SetFilterID(filterID string) {
+	w.filterID = filterID
+}
+
+func (w *ipcWrapper) GetFilterID() string {
+	return w.filterID
+}
+
+// WriteMessage writes out the message to the IPC peer, the messages are delimited by new lines
+func (w *ipcWrapper) WriteMessage(_ int, data []byte) error {
+	w.Lock()
+	defer w.Unlock()
+
+	if err := w.conn.SetWriteDeadline(time.Now().Add(ipcWriteTimeout)); err != nil {
+		return err
+	}
+
+	// the messages are compacted, so the new line delimits them
+	var msg bytes.Buffer
+	if err := json.Compact(&msg, data); err != nil {
+		msg.Reset()
+		msg.Write(data)
+	}
+
+	msg.WriteByte('\n')
+
+	_, writeErr := w.conn.Write(msg.Bytes())
+	if writeErr != nil {
+		w.logger.Error(
+			fmt.Sprintf("Unable to write IPC message, %s", writeErr.Error()),
+		)
+	}
+
+	return writeErr
+}
+
+func (j *JSONRPC) setupIPC() error {
+	// the node is started without the IPC server, rather than failing on the path the socket can't be bound to
+	if err := ipc.ValidatePath(j.config.IPCPath); err != nil {
+		j.logger.Warn("ipc server is disabled, the IPC path can't be used", "path", j.config.IPCPath, "err", err)
+
+		return nil
+	}
+
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on IPC path %s: %w", j.config.IPCPath, err)
+	}
+
+	j.ipcListener = lis
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// handleIPC serves the JSON-RPC requests of the IPC connection, the requests are
+// handled the same way as the WS requests, so the subscriptions are supported
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			j.logger.Error(
+				fmt.Sprintf("Unable to gracefully close IPC connection, %s", err.Error()),
+			)
+		}
+	}()
+
+	wrapConn := &ipcWrapper{conn: conn, logger: j.logger}
+
+	j.logger.Debug("IPC connection established")
+
+	// the requests are read as a stream of JSON values
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var message json.RawMessage
+
+		if err := decoder.Decode(&message); err != nil {
+			var syntaxErr *json.SyntaxError
+
+			switch {
+			case errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed):
+				j.logger.Debug("Closing IPC connection gracefully")
+			case errors.As(err, &syntaxErr):
+				// the stream can't be read past the malformed request, so the connection is closed once replied
+				j.logger.Debug("Closing IPC connection after invalid json request")
+
+				j.writeIPCError(wrapConn, NewInvalidRequestError("Invalid json request"))
+			default:
+				j.logger.Error(fmt.Sprintf("Unable to read IPC message, %s", err.Error()))
+				j.logger.Debug("Closing IPC connection with error")
+			}
+
+			j.dispatcher.RemoveFilterByWs(wrapConn)
+
+			return
+		}
+
+		go func() {
+			resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle IPC request, %s", handleErr.Error()))
+
+				j.writeIPCError(wrapConn, NewInternalError(handleErr.Error()))
+			} else {
+				_ = wrapConn.WriteMessage(0, resp)
+			}
+		}()
+	}
+}
+
+// writeIPCError writes out the JSON-RPC error object of the request which couldn't be handled
+func (j *JSONRPC) writeIPCError(conn *ipcWrapper, err Error) {
+	resp, marshalErr := NewRPCResponse(nil, "2.0", nil, err).Bytes()
+	if marshalErr != nil {
+		j.logger.Error(fmt.Sprintf("Unable to marshal IPC error response, %s", marshalErr.Error()))
+
+		return
+	}
+
+	_ = conn.WriteMessage(0, resp)
+}
@@ -37,9 +37,10 @@ func (s serverType) String() string {
 
 // JSONRPC is an API consensus
 type JSONRPC struct {
-	logger     hclog.Logger
-	config     *Config
-	dispatcher dispatcher
+	logger      hclog.Logger
+	config      *Config
+	dispatcher  dispatcher
+	ipcListener net.Listener
 }
 
 type dispatcher interface {
@@ -68,6 +69,7 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	IPCPath                  string // the path of the IPC socket, the IPC server is disabled if empty
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -99,9 +101,25 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	// start ipc server
+	if config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
+// Close closes the IPC server, which removes the IPC socket
+func (j *JSONRPC) Close() error {
+	if j.ipcListener == nil {
+		return nil
+	}
+
+	return j.ipcListener.Close()
+}
+
 func (j *JSONRPC) setupHTTP() error {
 	j.logger.Info("http server started", "addr", j.config.Addr.String())
 
@@ -159,6 +177,10 @@ func middlewareFactory(config *Config) func(http.Handler) http.Handler {
 	}
 }
 
+// wsWriteTimeout is the timeout of writing a message to the WS connection,
+// the connection of a client which doesn't read the messages is closed afterwards
+const wsWriteTimeout = 10 * time.Second
+
 // wsUpgrader defines upgrade parameters for the WS connection
 var wsUpgrader = websocket.Upgrader{
 	// Uses the default HTTP buffer sizes for Read / Write buffers.
@@ -190,6 +212,11 @@ func (w *wsWrapper) GetFilterID() string {
 func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
 	w.Lock()
 	defer w.Unlock()
+
+	if err := w.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+
 	writeErr := w.ws.WriteMessage(messageType, data)
 
 	if writeErr != nil {
@@ -1,14 +1,21 @@
 package jsonrpc
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"net"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/ipc"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -32,6 +39,87 @@ func TestHTTPServer(t *testing.T) {
 	}
 }
 
+func TestIPCServer(t *testing.T) {
+	store := newMockStore()
+	port, portErr := tests.GetFreePort()
+
+	if portErr != nil {
+		t.Fatalf("Unable to fetch free port, %v", portErr)
+	}
+
+	ipcPath := filepath.Join(t.TempDir(), "jsonrpc.ipc")
+
+	config := &Config{
+		Store:            store,
+		Addr:             &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		ChainName:        "polygon-edge-test",
+		BatchLengthLimit: 20,
+		IPCPath:          ipcPath,
+	}
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), config)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+
+	conn, err := ipc.DialTimeout(ipcPath, 5*time.Second)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	readResponse := func() *SuccessResponse {
+		t.Helper()
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		resp := &SuccessResponse{}
+		require.NoError(t, json.Unmarshal(line, resp))
+
+		return resp
+	}
+
+	// the requests are not required to be delimited
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"web3_clientVersion","params":[]}`))
+	require.NoError(t, err)
+
+	resp := readResponse()
+	assert.Nil(t, resp.Error)
+	assert.Contains(t, string(resp.Result), "polygon-edge-test")
+
+	// the subscriptions are streamed over the connection
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":2,"method":"eth_subscribe","params":["newHeads"]}`))
+	require.NoError(t, err)
+
+	resp = readResponse()
+	require.Nil(t, resp.Error)
+
+	var subscriptionID string
+	require.NoError(t, json.Unmarshal(resp.Result, &subscriptionID))
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{
+			{
+				header: &types.Header{
+					Hash: types.StringToHash("1"),
+				},
+			},
+		},
+	})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	line, err := reader.ReadBytes('\n')
+	require.NoError(t, err)
+	assert.Contains(t, string(line), subscriptionID)
+}
+
 func Test_handleGetRequest(t *testing.T) {
 	var (
 		chainName = "polygon-edge-test"
@@ -66,3 +154,60 @@ func Test_handleGetRequest(t *testing.T) {
 		response,
 	)
 }
+
+func TestIPCServer_InvalidRequest(t *testing.T) {
+	port, portErr := tests.GetFreePort()
+	require.NoError(t, portErr)
+
+	ipcPath := filepath.Join(t.TempDir(), "jsonrpc.ipc")
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:   newMockStore(),
+		Addr:    &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		IPCPath: ipcPath,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+
+	conn, err := ipc.DialTimeout(ipcPath, 5*time.Second)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":}`))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(t, err)
+
+	// the malformed request is replied with the JSON-RPC error object
+	resp := &ErrorResponse{}
+	require.NoError(t, json.Unmarshal(line, resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, NewInvalidRequestError("").ErrorCode(), resp.Error.Code)
+}
+
+func TestIPCServer_PathTooLong(t *testing.T) {
+	port, portErr := tests.GetFreePort()
+	require.NoError(t, portErr)
+
+	ipcPath := filepath.Join(t.TempDir(), strings.Repeat("a", 128), "jsonrpc.ipc")
+	require.ErrorIs(t, ipc.ValidatePath(ipcPath), ipc.ErrPathTooLong)
+
+	// the node is started without the IPC server
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:   newMockStore(),
+		Addr:    &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		IPCPath: ipcPath,
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Close())
+
+	_, err = ipc.DialTimeout(ipcPath, time.Second)
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -50,6 +51,11 @@ type mockStore struct {
 	receipts     map[types.Hash][]*types.Receipt
 	accounts     map[types.Address]*Account
 
+	pendingTxHashes chan types.Hash
+	pendingTxsLock  sync.Mutex
+	pendingTxs      map[types.Hash]*types.Transaction
+	syncProgression *progress.Progression
+
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
 }
@@ -59,6 +65,9 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*Account{},
+
+		pendingTxHashes: make(chan types.Hash),
+		pendingTxs:      map[types.Hash]*types.Transaction{},
 	}
 	m.addHeader(m.header)
 
@@ -108,6 +117,31 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	m.subscription.Push(bEvnt)
 }
 
+func (m *mockStore) emitPendingTx(tx *types.Transaction) {
+	m.pendingTxsLock.Lock()
+	m.pendingTxs[tx.Hash] = tx
+	m.pendingTxsLock.Unlock()
+
+	m.pendingTxHashes <- tx.Hash
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	m.pendingTxsLock.Lock()
+	defer m.pendingTxsLock.Unlock()
+
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
+func (m *mockStore) SubscribePendingTxs() (<-chan types.Hash, func()) {
+	return m.pendingTxHashes, func() {}
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	return m.syncProgression
+}
+
 func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	if acc, ok := m.accounts[addr]; ok {
 		return acc, nil
@@ -116,6 +150,10 @@ func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*Account, e
 	return nil, ErrStateNotFound
 }
 
+func (m *mockStore) IsStateAvailable(header *types.Header) bool {
+	return true
+}
+
 func (m *mockStore) SetAccount(addr types.Address, account *Account) {
 	m.accounts[addr] = account
 }
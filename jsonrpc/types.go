@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -17,23 +18,24 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    *argBig        `json:"gasPrice,omitempty"`
-	GasTipCap   *argBig        `json:"gasTipCap,omitempty"`
-	GasFeeCap   *argBig        `json:"gasFeeCap,omitempty"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
-	Type        argUint64      `json:"type"`
+	Nonce       argUint64          `json:"nonce"`
+	GasPrice    *argBig            `json:"gasPrice,omitempty"`
+	GasTipCap   *argBig            `json:"gasTipCap,omitempty"`
+	GasFeeCap   *argBig            `json:"gasFeeCap,omitempty"`
+	Gas         argUint64          `json:"gas"`
+	To          *types.Address     `json:"to"`
+	Value       argBig             `json:"value"`
+	Input       argBytes           `json:"input"`
+	V           argBig             `json:"v"`
+	R           argBig             `json:"r"`
+	S           argBig             `json:"s"`
+	Hash        types.Hash         `json:"hash"`
+	From        types.Address      `json:"from"`
+	BlockHash   *types.Hash        `json:"blockHash"`
+	BlockNumber *argUint64         `json:"blockNumber"`
+	TxIndex     *argUint64         `json:"transactionIndex"`
+	Type        argUint64          `json:"type"`
+	AccessList  types.TxAccessList `json:"accessList,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -71,6 +73,10 @@ func toTransaction(
 		Type:  argUint64(t.Type),
 	}
 
+	if t.Type == types.AccessListTx || t.Type == types.DynamicFeeTx {
+		res.AccessList = t.AccessList
+	}
+
 	if t.GasPrice != nil {
 		gasPrice := argBig(*t.GasPrice)
 		res.GasPrice = &gasPrice
@@ -206,6 +212,137 @@ type receipt struct {
 	ToAddr            *types.Address `json:"to"`
 }
 
+// toReceipt converts the receipt of the transaction at the given index of the block,
+// the log indexes start at the given log index of the block
+func toReceipt(
+	src *types.Receipt,
+	txn *types.Transaction,
+	txIndex uint64,
+	header *types.Header,
+	logIndex uint64,
+) *receipt {
+	logs := make([]*Log, len(src.Logs))
+	for i, elem := range src.Logs {
+		logs[i] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   header.Hash,
+			BlockNumber: argUint64(header.Number),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(logIndex + uint64(i)),
+			Removed:     false,
+		}
+	}
+
+	return &receipt{
+		Root:              src.Root,
+		CumulativeGasUsed: argUint64(src.CumulativeGasUsed),
+		LogsBloom:         src.LogsBloom,
+		Status:            argUint64(*src.Status),
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         header.Hash,
+		BlockNumber:       argUint64(header.Number),
+		GasUsed:           argUint64(src.GasUsed),
+		ContractAddress:   src.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+	}
+}
+
+// bundleTxnResult is the result of a transaction of the simulated bundle
+type bundleTxnResult struct {
+	TxHash          types.Hash     `json:"txHash"`
+	FromAddr        types.Address  `json:"from"`
+	ToAddr          *types.Address `json:"to"`
+	GasUsed         argUint64      `json:"gasUsed"`
+	Status          argUint64      `json:"status"`
+	ReturnValue     argBytes       `json:"returnValue"`
+	Error           string         `json:"error,omitempty"`
+	Logs            []*Log         `json:"logs"`
+	ContractAddress *types.Address `json:"contractAddress"`
+}
+
+// bundleSkippedTxn is the pending transaction which couldn't be applied before the bundle
+type bundleSkippedTxn struct {
+	TxHash types.Hash `json:"txHash"`
+	Error  string     `json:"error"`
+}
+
+// callBundleResult is the result of the simulated bundle
+type callBundleResult struct {
+	Results            []*bundleTxnResult  `json:"results"`
+	SkippedPendingTxns []*bundleSkippedTxn `json:"skippedPendingTxs,omitempty"`
+	TotalGasUsed       argUint64           `json:"totalGasUsed"`
+	StateBlockNumber   argUint64           `json:"stateBlockNumber"`
+}
+
+func toCallBundleResult(
+	header *types.Header,
+	txns []*types.Transaction,
+	bundleResult *BundleResult,
+) *callBundleResult {
+	res := &callBundleResult{
+		Results:          make([]*bundleTxnResult, len(bundleResult.Txns)),
+		StateBlockNumber: argUint64(header.Number),
+	}
+
+	for _, skipped := range bundleResult.SkippedPendingTxns {
+		res.SkippedPendingTxns = append(res.SkippedPendingTxns, &bundleSkippedTxn{
+			TxHash: skipped.Hash,
+			Error:  skipped.Err.Error(),
+		})
+	}
+
+	logIndex := 0
+
+	for idx, result := range bundleResult.Txns {
+		txn := txns[idx]
+
+		txnResult := &bundleTxnResult{
+			TxHash:          txn.Hash,
+			FromAddr:        txn.From,
+			ToAddr:          txn.To,
+			GasUsed:         argUint64(result.Receipt.GasUsed),
+			ReturnValue:     argBytes(result.Result.ReturnValue),
+			Logs:            make([]*Log, len(result.Receipt.Logs)),
+			ContractAddress: result.Receipt.ContractAddress,
+		}
+
+		if result.Receipt.Status != nil {
+			txnResult.Status = argUint64(*result.Receipt.Status)
+		}
+
+		if result.Result.Reverted() {
+			txnResult.Error = constructErrorFromRevert(result.Result).Error()
+		} else if result.Result.Failed() {
+			txnResult.Error = result.Result.Err.Error()
+		}
+
+		for i, elem := range result.Receipt.Logs {
+			txnResult.Logs[i] = &Log{
+				Address:     elem.Address,
+				Topics:      elem.Topics,
+				Data:        argBytes(elem.Data),
+				BlockNumber: argUint64(header.Number),
+				TxHash:      txn.Hash,
+				TxIndex:     argUint64(idx),
+				LogIndex:    argUint64(logIndex),
+			}
+
+			logIndex++
+		}
+
+		res.Results[idx] = txnResult
+		res.TotalGasUsed += txnResult.GasUsed
+	}
+
+	return res
+}
+
 type Log struct {
 	Address     types.Address `json:"address"`
 	Topics      []types.Hash  `json:"topics"`
@@ -218,6 +355,53 @@ type Log struct {
 	Removed     bool          `json:"removed"`
 }
 
+type accountProof struct {
+	Address      types.Address  `json:"address"`
+	AccountProof []argBytes     `json:"accountProof"`
+	Balance      argBig         `json:"balance"`
+	CodeHash     types.Hash     `json:"codeHash"`
+	Nonce        argUint64      `json:"nonce"`
+	StorageHash  types.Hash     `json:"storageHash"`
+	StorageProof []storageProof `json:"storageProof"`
+}
+
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+func toProofNodes(nodes [][]byte) []argBytes {
+	res := make([]argBytes, len(nodes))
+	for i, node := range nodes {
+		res[i] = argBytes(node)
+	}
+
+	return res
+}
+
+func toAccountProof(address types.Address, p *AccountProof) *accountProof {
+	res := &accountProof{
+		Address:      address,
+		AccountProof: toProofNodes(p.Proof),
+		Balance:      argBig(*p.Balance),
+		CodeHash:     p.CodeHash,
+		Nonce:        argUint64(p.Nonce),
+		StorageHash:  p.StorageHash,
+		StorageProof: make([]storageProof, len(p.StorageProof)),
+	}
+
+	for i, sp := range p.StorageProof {
+		res.StorageProof[i] = storageProof{
+			Key:   sp.Key,
+			Value: argBig(*new(big.Int).SetBytes(sp.Value)),
+			Proof: toProofNodes(sp.Proof),
+		}
+	}
+
+	return res
+}
+
 type argBig big.Int
 
 func argBigPtr(b *big.Int) *argBig {
@@ -329,17 +513,18 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From      *types.Address
-	To        *types.Address
-	Gas       *argUint64
-	GasPrice  *argBytes
-	GasTipCap *argBytes
-	GasFeeCap *argBytes
-	Value     *argBytes
-	Data      *argBytes
-	Input     *argBytes
-	Nonce     *argUint64
-	Type      *argUint64
+	From       *types.Address
+	To         *types.Address
+	Gas        *argUint64
+	GasPrice   *argBytes
+	GasTipCap  *argBytes
+	GasFeeCap  *argBytes
+	Value      *argBytes
+	Data       *argBytes
+	Input      *argBytes
+	Nonce      *argUint64
+	Type       *argUint64
+	AccessList *types.TxAccessList
 }
 
 type progression struct {
@@ -349,6 +534,15 @@ type progression struct {
 	HighestBlock  argUint64 `json:"highestBlock"`
 }
 
+func toProgression(p *progress.Progression) progression {
+	return progression{
+		Type:          string(p.SyncType),
+		StartingBlock: argUint64(p.StartingBlock),
+		CurrentBlock:  argUint64(p.CurrentBlock),
+		HighestBlock:  argUint64(p.HighestBlock),
+	}
+}
+
 type feeHistoryResult struct {
 	OldestBlock   argUint64
 	BaseFeePerGas []argUint64
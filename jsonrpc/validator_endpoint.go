@@ -0,0 +1,66 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrLivenessNotSupported = errors.New("the liveness of the validators is not tracked by the consensus")
+
+// livenessGetter is implemented by the stores of the consensus mechanisms
+// which track the liveness of the validators
+type livenessGetter interface {
+	// GetValidatorsLiveness returns the liveness of the validators over the latest tracked blocks
+	GetValidatorsLiveness() (*consensus.ValidatorsLiveness, error)
+}
+
+// Validator is the validator jsonrpc endpoint
+type Validator struct {
+	store interface{}
+}
+
+type validatorsLiveness struct {
+	FromBlock  argUint64            `json:"fromBlock"`
+	ToBlock    argUint64            `json:"toBlock"`
+	Threshold  argUint64            `json:"threshold"`
+	Validators []*validatorLiveness `json:"validators"`
+}
+
+type validatorLiveness struct {
+	Address        types.Address `json:"address"`
+	SignedBlocks   argUint64     `json:"signedBlocks"`
+	ExpectedBlocks argUint64     `json:"expectedBlocks"`
+}
+
+// GetLiveness returns the number of the signed and the expected blocks of each validator
+// over the latest tracked blocks
+func (v *Validator) GetLiveness() (interface{}, error) {
+	getter, ok := v.store.(livenessGetter)
+	if !ok {
+		return nil, ErrLivenessNotSupported
+	}
+
+	liveness, err := getter.GetValidatorsLiveness()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &validatorsLiveness{
+		FromBlock:  argUint64(liveness.FromBlock),
+		ToBlock:    argUint64(liveness.ToBlock),
+		Threshold:  argUint64(liveness.Threshold),
+		Validators: make([]*validatorLiveness, len(liveness.Validators)),
+	}
+
+	for i, v := range liveness.Validators {
+		result.Validators[i] = &validatorLiveness{
+			Address:        v.Address,
+			SignedBlocks:   argUint64(v.SignedBlocks),
+			ExpectedBlocks: argUint64(v.ExpectedBlocks),
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// livenessMockStore is the mock store which tracks the liveness of the validators
+type livenessMockStore struct {
+	*mockStore
+	liveness *consensus.ValidatorsLiveness
+}
+
+func (s *livenessMockStore) GetValidatorsLiveness() (*consensus.ValidatorsLiveness, error) {
+	return s.liveness, nil
+}
+
+func TestValidatorEndpoint_GetLiveness(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Validator{store: newMockStore()}).GetLiveness()
+	assert.ErrorIs(t, err, ErrLivenessNotSupported)
+
+	address := types.StringToAddress("0x1")
+	endpoint := &Validator{store: &livenessMockStore{
+		mockStore: newMockStore(),
+		liveness: &consensus.ValidatorsLiveness{
+			FromBlock: 2,
+			ToBlock:   11,
+			Threshold: 50,
+			Validators: []*consensus.ValidatorLiveness{
+				{Address: address, SignedBlocks: 9, ExpectedBlocks: 10},
+			},
+		},
+	}}
+
+	res, err := endpoint.GetLiveness()
+	require.NoError(t, err)
+	assert.Equal(t, &validatorsLiveness{
+		FromBlock: argUint64(2),
+		ToBlock:   argUint64(11),
+		Threshold: argUint64(50),
+		Validators: []*validatorLiveness{
+			{Address: address, SignedBlocks: argUint64(9), ExpectedBlocks: argUint64(10)},
+		},
+	}, res)
+}
@@ -0,0 +1,179 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// bansFile is the name of the file in the networking data directory the peer bans are persisted to
+const bansFile = "bans.json"
+
+var (
+	ErrPeerNotBanned   = errors.New("peer is not banned")
+	ErrInvalidDuration = errors.New("ban duration must be positive")
+)
+
+// PeerBan is the time-limited ban of a peer
+type PeerBan struct {
+	ID     peer.ID   `json:"id"`
+	Expiry time.Time `json:"expiry"`
+	Reason string    `json:"reason"`
+}
+
+// peerBans is the list of the banned peers, persisted so the bans survive the restarts.
+// The connections from and to the banned peers are refused by the connection gater
+type peerBans struct {
+	sync.RWMutex
+
+	// path is the path of the file the bans are persisted to, the bans are not persisted if it's empty
+	path string
+	bans map[peer.ID]*PeerBan
+	now  func() time.Time
+}
+
+// newPeerBans creates the ban list and loads the persisted bans which haven't expired yet
+func newPeerBans(path string) (*peerBans, error) {
+	pb := &peerBans{
+		path: path,
+		bans: make(map[peer.ID]*PeerBan),
+		now:  time.Now,
+	}
+
+	if path == "" {
+		return pb, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return pb, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read peer bans: %w", err)
+	}
+
+	var bans []*PeerBan
+	if err := json.Unmarshal(raw, &bans); err != nil {
+		return nil, fmt.Errorf("failed to decode peer bans: %w", err)
+	}
+
+	now := pb.now()
+
+	for _, ban := range bans {
+		if ban.Expiry.After(now) {
+			pb.bans[ban.ID] = ban
+		}
+	}
+
+	return pb, nil
+}
+
+// isBanned checks if the peer is banned [Thread safe]
+func (pb *peerBans) isBanned(id peer.ID) bool {
+	pb.RLock()
+	defer pb.RUnlock()
+
+	ban, ok := pb.bans[id]
+
+	return ok && ban.Expiry.After(pb.now())
+}
+
+// add bans the peer for the given duration, replacing its previous ban if any [Thread safe]
+func (pb *peerBans) add(id peer.ID, duration time.Duration, reason string) (*PeerBan, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	pb.Lock()
+	defer pb.Unlock()
+
+	ban := &PeerBan{
+		ID:     id,
+		Expiry: pb.now().Add(duration),
+		Reason: reason,
+	}
+
+	pb.bans[id] = ban
+
+	return ban, pb.persist()
+}
+
+// remove lifts the ban of the peer [Thread safe]
+func (pb *peerBans) remove(id peer.ID) error {
+	pb.Lock()
+	defer pb.Unlock()
+
+	ban, ok := pb.bans[id]
+	if !ok || !ban.Expiry.After(pb.now()) {
+		return ErrPeerNotBanned
+	}
+
+	delete(pb.bans, id)
+
+	return pb.persist()
+}
+
+// list returns the bans which haven't expired yet, ordered by their expiry [Thread safe]
+func (pb *peerBans) list() []*PeerBan {
+	pb.RLock()
+	defer pb.RUnlock()
+
+	now := pb.now()
+	bans := make([]*PeerBan, 0, len(pb.bans))
+
+	for _, ban := range pb.bans {
+		if ban.Expiry.After(now) {
+			bans = append(bans, ban)
+		}
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Expiry.Before(bans[j].Expiry)
+	})
+
+	return bans
+}
+
+// persist writes the bans which haven't expired yet to the file [NOT Thread safe]
+func (pb *peerBans) persist() error {
+	if pb.path == "" {
+		return nil
+	}
+
+	now := pb.now()
+	bans := make([]*PeerBan, 0, len(pb.bans))
+
+	for id, ban := range pb.bans {
+		if !ban.Expiry.After(now) {
+			delete(pb.bans, id)
+
+			continue
+		}
+
+		bans = append(bans, ban)
+	}
+
+	raw, err := json.Marshal(bans)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(pb.path), 0755); err != nil {
+		return err
+	}
+
+	// write the bans to a temporary file first, so the file is never partially written
+	if err := os.WriteFile(pb.path+".tmp", raw, 0600); err != nil {
+		return fmt.Errorf("failed to persist peer bans: %w", err)
+	}
+
+	return os.Rename(pb.path+".tmp", pb.path)
+}
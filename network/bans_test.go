@@ -0,0 +1,121 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerBans_AddRemove(t *testing.T) {
+	t.Parallel()
+
+	bans, err := newPeerBans("")
+	assert.NoError(t, err)
+
+	id := peer.ID("A")
+
+	_, err = bans.add(id, 0, "reason")
+	assert.ErrorIs(t, err, ErrInvalidDuration)
+	assert.False(t, bans.isBanned(id))
+
+	ban, err := bans.add(id, time.Hour, "reason")
+	assert.NoError(t, err)
+	assert.Equal(t, id, ban.ID)
+	assert.Equal(t, "reason", ban.Reason)
+	assert.True(t, bans.isBanned(id))
+	assert.Equal(t, []*PeerBan{ban}, bans.list())
+
+	assert.NoError(t, bans.remove(id))
+	assert.False(t, bans.isBanned(id))
+	assert.Empty(t, bans.list())
+
+	assert.ErrorIs(t, bans.remove(id), ErrPeerNotBanned)
+}
+
+func TestPeerBans_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	bans, err := newPeerBans("")
+	assert.NoError(t, err)
+
+	bans.now = func() time.Time { return now }
+
+	_, err = bans.add(peer.ID("A"), 2*time.Hour, "A")
+	assert.NoError(t, err)
+
+	banB, err := bans.add(peer.ID("B"), time.Hour, "B")
+	assert.NoError(t, err)
+
+	// the bans are ordered by their expiry
+	list := bans.list()
+	assert.Len(t, list, 2)
+	assert.Equal(t, banB, list[0])
+
+	now = now.Add(time.Hour)
+
+	assert.False(t, bans.isBanned(peer.ID("B")))
+	assert.True(t, bans.isBanned(peer.ID("A")))
+	assert.Len(t, bans.list(), 1)
+	assert.ErrorIs(t, bans.remove(peer.ID("B")), ErrPeerNotBanned)
+}
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateEd25519Key(nil)
+	assert.NoError(t, err)
+
+	id, err := peer.IDFromPublicKey(pub)
+	assert.NoError(t, err)
+
+	return id
+}
+
+func TestPeerBans_Persistence(t *testing.T) {
+	t.Parallel()
+
+	idA, idB, idC := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+	path := filepath.Join(t.TempDir(), "libp2p", bansFile)
+
+	bans, err := newPeerBans(path)
+	assert.NoError(t, err)
+
+	_, err = bans.add(idA, time.Hour, "A")
+	assert.NoError(t, err)
+
+	_, err = bans.add(idB, time.Hour, "B")
+	assert.NoError(t, err)
+
+	_, err = bans.add(idC, time.Hour, "C")
+	assert.NoError(t, err)
+
+	assert.NoError(t, bans.remove(idC))
+
+	// the bans are restored after the restart
+	restored, err := newPeerBans(path)
+	assert.NoError(t, err)
+	assert.True(t, restored.isBanned(idA))
+	assert.True(t, restored.isBanned(idB))
+	assert.False(t, restored.isBanned(idC))
+
+	// the expired bans are dropped on load
+	restored.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	assert.NoError(t, restored.persist())
+
+	reloaded, err := newPeerBans(path)
+	assert.NoError(t, err)
+	assert.Empty(t, reloaded.list())
+
+	// the corrupted file is reported
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	_, err = newPeerBans(path)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -20,6 +21,10 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	StaticPeers      []*peer.AddrInfo       // the peers which are always redialed, regardless of the slot limits
+	TrustedPeers     []*peer.AddrInfo       // the peers which are exempt from the slot limits and the bans
+	PrivatePeerIDs   []peer.ID              // the peers which are never advertised to the other peers
+	PrivateMode      bool                   // flag indicating if the node connects only to its static and trusted peers
 }
 
 func DefaultConfig() *Config {
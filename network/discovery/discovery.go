@@ -83,6 +83,9 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there is an available connection slot for the set direction [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsPrivatePeer checks if the peer must not be advertised to the other peers [Thread safe]
+	IsPrivatePeer(peerID peer.ID) bool
 }
 
 // DiscoveryService is a service that finds other peers in the network
@@ -137,6 +140,12 @@ func (d *DiscoveryService) HandleNetworkEvent(peerEvent *event.PeerEvent) {
 
 	switch peerEvent.Type {
 	case event.PeerConnected:
+		// The private peers are kept out of the routing table,
+		// so they are never advertised to the other peers
+		if d.baseServer.IsPrivatePeer(peerID) {
+			return
+		}
+
 		// Add peer to the routing table and to our local peer table
 		_, err := d.routingTable.TryAddPeer(peerID, false, false)
 		if err != nil {
@@ -418,8 +427,9 @@ func (d *DiscoveryService) FindPeers(
 	filteredPeers := make([]string, 0)
 
 	for _, id := range nearestPeers {
-		if id == from {
-			// Skip the peer that's initializing the request
+		if id == from || d.baseServer.IsPrivatePeer(id) {
+			// Skip the peer that's initializing the request,
+			// and the private peers which must not be advertised
 			continue
 		}
 
@@ -8,6 +8,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/event"
+	networkGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
 	"github.com/hashicorp/go-hclog"
@@ -301,3 +303,56 @@ func TestDiscoveryService_RegularPeerDiscoveryUnconnected(t *testing.T) {
 	// Make sure that no peers were added to the peer store
 	assert.Len(t, peerStore, 0)
 }
+
+// TestDiscoveryService_PrivatePeers makes sure the private peers
+// are never advertised to the other peers
+func TestDiscoveryService_PrivatePeers(t *testing.T) {
+	peers := getRandomPeers(t, 3)
+	requester, publicPeer, privatePeer := peers[0], peers[1], peers[2]
+
+	peerStore := map[peer.ID]*peer.AddrInfo{
+		publicPeer.ID:  publicPeer,
+		privatePeer.ID: privatePeer,
+	}
+
+	// Create an instance of the discovery service
+	discoveryService, setupErr := newDiscoveryService(
+		// Set the relevant hook responses from the mock server
+		func(server *networkTesting.MockNetworkingServer) {
+			// Define the private peer hook
+			server.HookIsPrivatePeer(func(id peer.ID) bool {
+				return id == privatePeer.ID
+			})
+
+			// Define the peer info hook
+			server.HookGetPeerInfo(func(id peer.ID) *peer.AddrInfo {
+				return peerStore[id]
+			})
+		},
+	)
+	if setupErr != nil {
+		t.Fatalf("Unable to setup the discovery service")
+	}
+
+	// Both of the peers connect, but only the public one is added to the routing table
+	for _, info := range []*peer.AddrInfo{publicPeer, privatePeer} {
+		discoveryService.HandleNetworkEvent(&event.PeerEvent{
+			PeerID: info.ID,
+			Type:   event.PeerConnected,
+		})
+	}
+
+	assert.Equal(t, []peer.ID{publicPeer.ID}, discoveryService.RoutingTablePeers())
+
+	// Make sure only the public peer is advertised
+	resp, err := discoveryService.FindPeers(
+		&networkGrpc.Context{Context: context.Background(), PeerID: requester.ID},
+		&proto.FindPeersReq{Count: maxDiscoveryPeerReqCount},
+	)
+	assert.NoError(t, err)
+
+	publicAddr, err := common.AddrInfoToString(publicPeer)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{publicAddr}, resp.Nodes)
+}
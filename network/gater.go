@@ -0,0 +1,51 @@
+package network
+
+import (
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// connGater is the connection gater of the libp2p host. It refuses the connections
+// from and to the banned peers, and in the private mode the connections
+// from and to all the peers except the static and the trusted ones
+type connGater struct {
+	bans        *peerBans
+	classes     *peerClasses
+	privateMode bool
+}
+
+// isAllowed checks if the connection with the peer is allowed
+func (g *connGater) isAllowed(id peer.ID) bool {
+	if g.bans.isBanned(id) {
+		return false
+	}
+
+	return !g.privateMode || g.classes.isStatic(id) || g.classes.isTrusted(id)
+}
+
+// InterceptPeerDial refuses dialing the peers which are not allowed
+func (g *connGater) InterceptPeerDial(id peer.ID) bool {
+	return g.isAllowed(id)
+}
+
+// InterceptAddrDial refuses dialing the peers which are not allowed
+func (g *connGater) InterceptAddrDial(id peer.ID, _ multiaddr.Multiaddr) bool {
+	return g.isAllowed(id)
+}
+
+// InterceptAccept accepts all the inbound connections, the peer is not known at this point
+func (g *connGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured refuses the connections of the peers which are not allowed once they are authenticated
+func (g *connGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	return g.isAllowed(id)
+}
+
+// InterceptUpgraded accepts all the upgraded connections, they have been checked when secured
+func (g *connGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
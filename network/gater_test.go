@@ -0,0 +1,70 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnGater(t *testing.T) {
+	t.Parallel()
+
+	var (
+		banned  = peer.ID("banned")
+		static  = peer.ID("static")
+		trusted = peer.ID("trusted")
+		regular = peer.ID("regular")
+	)
+
+	bans, err := newPeerBans("")
+	assert.NoError(t, err)
+
+	_, err = bans.add(banned, time.Hour, "reason")
+	assert.NoError(t, err)
+
+	classes := newPeerClasses(peer.ID("host"), &Config{
+		StaticPeers:  []*peer.AddrInfo{{ID: static}},
+		TrustedPeers: []*peer.AddrInfo{{ID: trusted}},
+	})
+
+	cases := []struct {
+		name        string
+		privateMode bool
+		allowed     map[peer.ID]bool
+	}{
+		{
+			name:        "public mode",
+			privateMode: false,
+			allowed:     map[peer.ID]bool{banned: false, static: true, trusted: true, regular: true},
+		},
+		{
+			name:        "private mode",
+			privateMode: true,
+			allowed:     map[peer.ID]bool{banned: false, static: true, trusted: true, regular: false},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			gater := &connGater{
+				bans:        bans,
+				classes:     classes,
+				privateMode: c.privateMode,
+			}
+
+			for id, allowed := range c.allowed {
+				assert.Equal(t, allowed, gater.InterceptPeerDial(id), id)
+				assert.Equal(t, allowed, gater.InterceptAddrDial(id, nil), id)
+				assert.Equal(t, allowed, gater.InterceptSecured(network.DirInbound, id, nil), id)
+				assert.Equal(t, allowed, gater.InterceptSecured(network.DirOutbound, id, nil), id)
+			}
+		})
+	}
+}
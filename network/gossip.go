@@ -29,6 +29,9 @@ type Topic struct {
 	closeCh   chan struct{}
 	closed    atomic.Bool
 	waitGroup sync.WaitGroup
+
+	// penalize decreases the reputation score of the peer which forwarded a malformed message
+	penalize func(peer.ID, Penalty, string)
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -67,6 +70,11 @@ func (t *Topic) Publish(obj proto.Message) error {
 	return t.topic.Publish(context.Background(), data)
 }
 
+// ListPeers returns the peers subscribed to the topic
+func (t *Topic) ListPeers() []peer.ID {
+	return t.topic.ListPeers()
+}
+
 func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 	sub, err := t.topic.Subscribe(pubsub.WithBufferSize(subscribeOutputBufferSize))
 	if err != nil {
@@ -112,6 +120,10 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 				t.logger.Error("failed to unmarshal topic", "err", err)
 				metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
 
+				if t.penalize != nil {
+					t.penalize(msg.ReceivedFrom, PenaltyMalformedMessage, "malformed gossip message")
+				}
+
 				return
 			}
 
@@ -129,10 +141,11 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	}
 
 	tt := &Topic{
-		logger:  s.logger.Named(protoID),
-		topic:   topic,
-		typ:     reflect.TypeOf(obj).Elem(),
-		closeCh: make(chan struct{}),
+		logger:   s.logger.Named(protoID),
+		topic:    topic,
+		typ:      reflect.TypeOf(obj).Elem(),
+		closeCh:  make(chan struct{}),
+		penalize: s.PenalizePeer,
 	}
 	tt.closed.Store(false)
 
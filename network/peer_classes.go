@@ -0,0 +1,116 @@
+package network
+
+import (
+	"errors"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+)
+
+var (
+	ErrTrustedPeerBan     = errors.New("trusted peers can't be banned")
+	ErrPrivateModeNoPeers = errors.New("private mode requires static or trusted peers")
+)
+
+// peerClasses holds the static, the trusted and the private peers of the node.
+// It's initialized once and doesn't change, so it's safe for the concurrent reads
+type peerClasses struct {
+	// static are the peers the connections are always kept with
+	static map[peer.ID]*peer.AddrInfo
+
+	// trusted are the peers exempt from the slot limits and the bans
+	trusted map[peer.ID]*peer.AddrInfo
+
+	// private are the peers which are never advertised to the other peers
+	private map[peer.ID]struct{}
+}
+
+// newPeerClasses creates the peer classes from the configured peers, omitting the host itself
+func newPeerClasses(hostID peer.ID, config *Config) *peerClasses {
+	toMap := func(infos []*peer.AddrInfo) map[peer.ID]*peer.AddrInfo {
+		peers := make(map[peer.ID]*peer.AddrInfo, len(infos))
+
+		for _, info := range infos {
+			if info.ID != hostID {
+				peers[info.ID] = info
+			}
+		}
+
+		return peers
+	}
+
+	private := make(map[peer.ID]struct{}, len(config.PrivatePeerIDs))
+	for _, id := range config.PrivatePeerIDs {
+		private[id] = struct{}{}
+	}
+
+	return &peerClasses{
+		static:  toMap(config.StaticPeers),
+		trusted: toMap(config.TrustedPeers),
+		private: private,
+	}
+}
+
+// isStatic checks if the peer is a static peer
+func (pc *peerClasses) isStatic(id peer.ID) bool {
+	_, ok := pc.static[id]
+
+	return ok
+}
+
+// isTrusted checks if the peer is a trusted peer
+func (pc *peerClasses) isTrusted(id peer.ID) bool {
+	_, ok := pc.trusted[id]
+
+	return ok
+}
+
+// isPrivate checks if the peer is a private peer
+func (pc *peerClasses) isPrivate(id peer.ID) bool {
+	_, ok := pc.private[id]
+
+	return ok
+}
+
+// IsStaticPeer checks if the peer is one of the configured static peers
+func (s *Server) IsStaticPeer(peerID peer.ID) bool {
+	return s.peerClasses.isStatic(peerID)
+}
+
+// IsTrustedPeer checks if the peer is one of the configured trusted peers
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.peerClasses.isTrusted(peerID)
+}
+
+// IsProtectedPeer checks if the peer is a static or a trusted peer.
+// The connections of the protected peers don't take the connection slots
+func (s *Server) IsProtectedPeer(peerID peer.ID) bool {
+	return s.peerClasses.isStatic(peerID) || s.peerClasses.isTrusted(peerID)
+}
+
+// IsPrivatePeer checks if the peer is one of the configured private peers,
+// which are never advertised to the other peers
+func (s *Server) IsPrivatePeer(peerID peer.ID) bool {
+	return s.peerClasses.isPrivate(peerID)
+}
+
+// HasPrivatePeers checks if the node has private peers, i.e. it's a sentry of private validators
+func (s *Server) HasPrivatePeers() bool {
+	return len(s.peerClasses.private) > 0
+}
+
+// setupPeerClasses saves the addresses of the static and the trusted peers to the peer store
+// and lifts the persisted bans of the trusted peers
+func (s *Server) setupPeerClasses() {
+	for _, classPeers := range []map[peer.ID]*peer.AddrInfo{s.peerClasses.static, s.peerClasses.trusted} {
+		for _, info := range classPeers {
+			s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+		}
+	}
+
+	for id := range s.peerClasses.trusted {
+		if err := s.bans.remove(id); err == nil {
+			s.logger.Info("Lifted the ban of the trusted peer", "id", id)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package network
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Penalty is the amount the reputation score of a peer is decreased by on a misbehavior
+type Penalty float64
+
+const (
+	// PenaltyMalformedMessage is the penalty for a gossip message which can't be decoded
+	PenaltyMalformedMessage Penalty = 20
+
+	// PenaltyInvalidTx is the penalty for a transaction rejected by the transaction pool as invalid
+	PenaltyInvalidTx Penalty = 10
+
+	// PenaltyInvalidBlock is the penalty for a block which fails the verification
+	PenaltyInvalidBlock Penalty = 50
+
+	// PenaltyInvalidConsensusMessage is the penalty for a consensus message which is not well-formed
+	PenaltyInvalidConsensusMessage Penalty = 20
+)
+
+const (
+	// banScoreThreshold is the reputation score at which the peer gets banned
+	banScoreThreshold = -100
+
+	// scoreHalfLife is the period in which the negative score of a peer recovers by half
+	scoreHalfLife = 10 * time.Minute
+
+	// minTrackedScore is the score below which (in absolute value) the peer is not tracked anymore
+	minTrackedScore = 0.1
+
+	// DefaultBanDuration is the duration of the ban of a peer which reached the ban score
+	DefaultBanDuration = time.Hour
+)
+
+// Gossipsub thresholds applied to the reputation score of a peer
+const (
+	// gossipScoreThreshold is the score below which the gossip is not exchanged with the peer
+	gossipScoreThreshold = -40
+
+	// publishScoreThreshold is the score below which the own messages are not published to the peer
+	publishScoreThreshold = -60
+
+	// graylistScoreThreshold is the score below which all the messages of the peer are ignored
+	graylistScoreThreshold = -80
+)
+
+// peerScore is the reputation score of a peer at the time of its update
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+// decayed returns the score recovered since its update
+func (s *peerScore) decayed(now time.Time) float64 {
+	return s.value * math.Pow(0.5, float64(now.Sub(s.updated))/float64(scoreHalfLife))
+}
+
+// peerReputation tracks the reputation scores of the peers. The score of a peer is decreased by
+// the penalties for its misbehavior and recovers back to zero over time
+type peerReputation struct {
+	sync.Mutex
+
+	scores map[peer.ID]*peerScore
+	now    func() time.Time
+}
+
+func newPeerReputation() *peerReputation {
+	return &peerReputation{
+		scores: make(map[peer.ID]*peerScore),
+		now:    time.Now,
+	}
+}
+
+// score returns the current reputation score of the peer [Thread safe]
+func (r *peerReputation) score(id peer.ID) float64 {
+	r.Lock()
+	defer r.Unlock()
+
+	score, ok := r.scores[id]
+	if !ok {
+		return 0
+	}
+
+	value := score.decayed(r.now())
+	if value > -minTrackedScore {
+		delete(r.scores, id)
+
+		return 0
+	}
+
+	return value
+}
+
+// penalize decreases the reputation score of the peer and returns the new score [Thread safe]
+func (r *peerReputation) penalize(id peer.ID, penalty Penalty) float64 {
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+
+	score, ok := r.scores[id]
+	if !ok {
+		score = &peerScore{}
+		r.scores[id] = score
+	}
+
+	score.value = score.decayed(now) - float64(penalty)
+	score.updated = now
+
+	return score.value
+}
+
+// reset clears the reputation score of the peer [Thread safe]
+func (r *peerReputation) reset(id peer.ID) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.scores, id)
+}
@@ -0,0 +1,58 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerReputation_Penalize(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	reputation := newPeerReputation()
+	reputation.now = func() time.Time { return now }
+
+	id := peer.ID("A")
+
+	assert.Equal(t, float64(0), reputation.score(id))
+
+	assert.Equal(t, float64(-20), reputation.penalize(id, PenaltyMalformedMessage))
+	assert.Equal(t, float64(-30), reputation.penalize(id, PenaltyInvalidTx))
+	assert.Equal(t, float64(-30), reputation.score(id))
+
+	// the score of the other peers is not affected
+	assert.Equal(t, float64(0), reputation.score(peer.ID("B")))
+
+	reputation.reset(id)
+	assert.Equal(t, float64(0), reputation.score(id))
+}
+
+func TestPeerReputation_Decay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	reputation := newPeerReputation()
+	reputation.now = func() time.Time { return now }
+
+	id := peer.ID("A")
+
+	reputation.penalize(id, 80)
+
+	// the score recovers by half in each half-life
+	now = now.Add(scoreHalfLife)
+	assert.InDelta(t, -40, reputation.score(id), 1e-9)
+
+	// the penalties are added to the decayed score
+	assert.InDelta(t, -90, reputation.penalize(id, PenaltyInvalidBlock), 1e-9)
+
+	now = now.Add(2 * scoreHalfLife)
+	assert.InDelta(t, -22.5, reputation.score(id), 1e-9)
+
+	// the peer is not tracked anymore once its score recovers
+	now = now.Add(10 * scoreHalfLife)
+	assert.Equal(t, float64(0), reputation.score(id))
+	assert.Len(t, reputation.scores, 0)
+}
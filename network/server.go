@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -88,6 +89,11 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	reputation *peerReputation // reputation scores of the peers
+	bans       *peerBans       // time-limited bans of the peers
+
+	peerClasses *peerClasses // static and trusted peers of the node
 }
 
 // NewServer returns a new instance of the networking server
@@ -104,6 +110,35 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	bansPath := ""
+	if config.DataDir != "" {
+		bansPath = filepath.Join(config.DataDir, bansFile)
+	}
+
+	bans, err := newPeerBans(bansPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hostID, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	classes := newPeerClasses(hostID, config)
+
+	if config.PrivateMode {
+		if len(classes.static) == 0 && len(classes.trusted) == 0 {
+			return nil, ErrPrivateModeNoPeers
+		}
+
+		// the private node is reachable only by its static and trusted peers,
+		// so it doesn't discover the other peers nor is it discovered by them
+		config.NoDiscover = true
+
+		logger.Info("Private mode enabled, connecting only to the static and trusted peers")
+	}
+
 	addrsFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
 		if config.NatAddr != nil {
 			addr, _ := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", config.NatAddr.String(), config.Addr.Port))
@@ -124,6 +159,12 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		libp2p.ListenAddrs(listenAddr),
 		libp2p.AddrsFactory(addrsFactory),
 		libp2p.Identity(key),
+		// Refuse the connections of the banned peers, and of the unknown peers in the private mode
+		libp2p.ConnectionGater(&connGater{
+			bans:        bans,
+			classes:     classes,
+			privateMode: config.PrivateMode,
+		}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
@@ -154,13 +195,19 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		reputation:  newPeerReputation(),
+		bans:        bans,
+		peerClasses: classes,
 	}
 
+	srv.setupPeerClasses()
+
 	// start gossip protocol
 	ps, err := pubsub.NewGossipSub(
 		context.Background(),
 		host, pubsub.WithPeerOutboundQueueSize(peerOutboundBufferSize),
 		pubsub.WithValidateQueueSize(validateBufferSize),
+		pubsub.WithPeerScore(srv.peerScoreParams(), peerScoreThresholds()),
 	)
 	if err != nil {
 		return nil, err
@@ -267,6 +314,7 @@ func (s *Server) Start() error {
 	}
 
 	go s.runDial()
+	go s.runStaticDial()
 	go s.keepAliveMinimumPeerConnections()
 
 	// watch for disconnected peers
@@ -360,6 +408,11 @@ func (s *Server) runDial() {
 	defer cancel()
 
 	if err := s.Subscribe(ctx, func(event *peerEvent.PeerEvent) {
+		// The protected peers are dialed without taking a slot
+		if s.IsProtectedPeer(event.PeerID) {
+			return
+		}
+
 		// Return back slot on PeerFailedToConnect or PeerDisconnected
 		switch event.Type {
 		case
@@ -394,14 +447,17 @@ func (s *Server) runDial() {
 
 		peerInfo := tt.GetAddrInfo()
 
-		if s.IsConnected(peerInfo.ID) {
+		// the static peers are dialed by the static dialer
+		if s.IsConnected(peerInfo.ID) || s.bans.isBanned(peerInfo.ID) || s.IsStaticPeer(peerInfo.ID) {
 			continue
 		}
 
-		s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
+		if !s.IsProtectedPeer(peerInfo.ID) {
+			s.logger.Debug("Waiting for a dialing slot", "addr", peerInfo, "local", s.host.ID())
 
-		if closed := slots.Take(ctx); closed {
-			return
+			if closed := slots.Take(ctx); closed {
+				return
+			}
 		}
 
 		s.logger.Debug("Dialing peer", "addr", peerInfo, "local", s.host.ID())
@@ -501,9 +557,9 @@ func (s *Server) removePeerInfo(peerID peer.ID) *PeerConnInfo {
 	// Delete the peer from the peers map
 	delete(s.peers, peerID)
 
-	// Update connection counters
+	// Update connection counters, the protected peers don't take the connection slots
 	for connDirection, active := range connectionInfo.connDirections {
-		if active {
+		if active && !s.IsProtectedPeer(peerID) {
 			s.connectionCounts.UpdateConnCountByDirection(-1, connDirection)
 			s.updateConnCountMetrics(connDirection)
 			s.updateBootnodeConnCount(peerID, -1)
@@ -74,10 +74,12 @@ func (s *Server) addPeerInfo(id peer.ID, direction network.Direction) bool {
 
 	s.peers[id] = connectionInfo
 
-	// Update connection counters
-	s.connectionCounts.UpdateConnCountByDirection(1, direction)
-	s.updateConnCountMetrics(direction)
-	s.updateBootnodeConnCount(id, 1)
+	// Update connection counters, the protected peers don't take the connection slots
+	if !s.IsProtectedPeer(id) {
+		s.connectionCounts.UpdateConnCountByDirection(1, direction)
+		s.updateConnCountMetrics(direction)
+		s.updateBootnodeConnCount(id, 1)
+	}
 
 	// Update the metric stats
 	metrics.SetGauge([]string{networkMetrics, "peers"}, float32(len(s.peers)))
@@ -0,0 +1,114 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// PenalizePeer decreases the reputation score of the peer for its misbehavior,
+// the peer is banned for the default duration once its score reaches the ban threshold
+func (s *Server) PenalizePeer(peerID peer.ID, penalty Penalty, reason string) {
+	if peerID == "" || peerID == s.host.ID() {
+		return
+	}
+
+	score := s.reputation.penalize(peerID, penalty)
+
+	s.logger.Debug("Peer penalized", "id", peerID, "penalty", penalty, "score", score, "reason", reason)
+	metrics.IncrCounter([]string{networkMetrics, "peer_penalties"}, 1)
+
+	if score > banScoreThreshold || s.bans.isBanned(peerID) || s.IsTrustedPeer(peerID) {
+		return
+	}
+
+	if err := s.BanPeer(peerID, DefaultBanDuration, reason); err != nil {
+		s.logger.Error("Unable to ban peer", "id", peerID, "err", err)
+	}
+}
+
+// PeerScore returns the current reputation score of the peer
+func (s *Server) PeerScore(peerID peer.ID) float64 {
+	return s.reputation.score(peerID)
+}
+
+// BanPeer bans the peer for the given duration and disconnects from it.
+// The ban is persisted, so it's kept after the restart of the node
+func (s *Server) BanPeer(peerID peer.ID, duration time.Duration, reason string) error {
+	if peerID == s.host.ID() {
+		return fmt.Errorf("unable to ban the local node")
+	}
+
+	if s.IsTrustedPeer(peerID) {
+		return ErrTrustedPeerBan
+	}
+
+	ban, err := s.bans.add(peerID, duration, reason)
+	if ban == nil {
+		return err
+	}
+
+	// the peer is banned in memory even if the ban couldn't be persisted
+	s.logger.Info("Peer banned", "id", peerID, "expiry", ban.Expiry, "reason", reason)
+	metrics.SetGauge([]string{networkMetrics, "banned_peers"}, float32(len(s.bans.list())))
+
+	s.DisconnectFromPeer(peerID, fmt.Sprintf("banned: %s", reason))
+
+	return err
+}
+
+// UnbanPeer lifts the ban of the peer and clears its reputation score
+func (s *Server) UnbanPeer(peerID peer.ID) error {
+	if err := s.bans.remove(peerID); err != nil {
+		return err
+	}
+
+	s.reputation.reset(peerID)
+
+	s.logger.Info("Peer unbanned", "id", peerID)
+	metrics.SetGauge([]string{networkMetrics, "banned_peers"}, float32(len(s.bans.list())))
+
+	return nil
+}
+
+// IsBanned checks if the peer is banned
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.bans.isBanned(peerID)
+}
+
+// ListBans returns the active bans of the peers, ordered by their expiry
+func (s *Server) ListBans() []*PeerBan {
+	return s.bans.list()
+}
+
+// peerScoreParams returns the gossipsub peer scoring parameters.
+// The score of a peer is driven by its reputation, the topic scores are not used.
+// The trusted peers are never graylisted, so their score is neutral
+func (s *Server) peerScoreParams() *pubsub.PeerScoreParams {
+	return &pubsub.PeerScoreParams{
+		Topics: make(map[string]*pubsub.TopicScoreParams),
+		AppSpecificScore: func(id peer.ID) float64 {
+			if s.IsTrustedPeer(id) {
+				return 0
+			}
+
+			return s.reputation.score(id)
+		},
+		AppSpecificWeight: 1,
+		DecayInterval:     time.Second,
+		DecayToZero:       0.01,
+		RetainScore:       time.Hour,
+	}
+}
+
+// peerScoreThresholds returns the gossipsub thresholds of the peer scores
+func peerScoreThresholds() *pubsub.PeerScoreThresholds {
+	return &pubsub.PeerScoreThresholds{
+		GossipThreshold:   gossipScoreThreshold,
+		PublishThreshold:  publishScoreThreshold,
+		GraylistThreshold: graylistScoreThreshold,
+	}
+}
@@ -1158,3 +1158,198 @@ func generateRandomPeers(t *testing.T, count int) ([]*randomPeer, error) {
 
 	return randomPeers, nil
 }
+
+func TestPenalizePeer_Ban(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	peerID := servers[1].AddrInfo().ID
+
+	// the peer is not banned until its score reaches the ban threshold
+	servers[0].PenalizePeer(peerID, PenaltyInvalidBlock, "invalid block")
+	assert.False(t, servers[0].IsBanned(peerID))
+	assert.True(t, servers[0].IsConnected(peerID))
+
+	// the score slightly recovers in between, so it takes three penalties to reach the threshold
+	servers[0].PenalizePeer(peerID, PenaltyInvalidBlock, "invalid block")
+	servers[0].PenalizePeer(peerID, PenaltyInvalidBlock, "invalid block")
+	assert.True(t, servers[0].IsBanned(peerID))
+	assert.Len(t, servers[0].ListBans(), 1)
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	if _, disconnectErr := WaitUntilPeerDisconnectsFrom(disconnectCtx, servers[0], peerID); disconnectErr != nil {
+		t.Fatalf("Unable to disconnect from peer, %v", disconnectErr)
+	}
+
+	// the banned peer is refused, it never connects to the server which banned it
+	servers[1].joinPeer(servers[0].AddrInfo())
+
+	assert.Never(t, func() bool {
+		return servers[0].IsConnected(peerID)
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// the peer can join again once it's unbanned
+	assert.NoError(t, servers[0].UnbanPeer(peerID))
+	assert.Equal(t, float64(0), servers[0].PeerScore(peerID))
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+}
+
+func TestTrustedPeers_SlotLimitsAndBans(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	trustedPeer := servers[1].AddrInfo()
+
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 1
+			c.MaxOutboundPeers = 1
+			c.NoDiscover = true
+			c.TrustedPeers = []*peer.AddrInfo{trustedPeer}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	servers = append(servers, server)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// Server 2 takes its only outbound slot
+	if joinErr := JoinAndWait(server, servers[0], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	// the trusted peer is connected regardless of the slot limits
+	if joinErr := JoinAndWait(server, servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join trusted peer, %v", joinErr)
+	}
+
+	assert.Equal(t, int64(1), server.connectionCounts.GetOutboundConnCount())
+	assert.True(t, server.IsTrustedPeer(trustedPeer.ID))
+	assert.False(t, server.IsStaticPeer(trustedPeer.ID))
+
+	// the trusted peer can't be banned
+	assert.ErrorIs(t, server.BanPeer(trustedPeer.ID, time.Hour, "reason"), ErrTrustedPeerBan)
+
+	server.PenalizePeer(trustedPeer.ID, -2*banScoreThreshold, "reason")
+	assert.LessOrEqual(t, server.PeerScore(trustedPeer.ID), float64(banScoreThreshold))
+	assert.False(t, server.IsBanned(trustedPeer.ID))
+	assert.True(t, server.IsConnected(trustedPeer.ID))
+}
+
+func TestStaticPeers_Redial(t *testing.T) {
+	servers, createErr := createServers(1, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	staticPeer := servers[0].AddrInfo()
+
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			// no outbound slots are available for the regular peers
+			c.MaxOutboundPeers = 0
+			c.NoDiscover = true
+			c.StaticPeers = []*peer.AddrInfo{staticPeer}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	servers = append(servers, server)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	isConnected := func() bool {
+		return server.hasPeer(staticPeer.ID)
+	}
+
+	// the static peer is dialed on start
+	assert.Eventually(t, isConnected, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, int64(0), server.connectionCounts.GetOutboundConnCount())
+
+	server.DisconnectFromPeer(staticPeer.ID, "bye")
+
+	assert.Eventually(t, func() bool {
+		return !server.IsConnected(staticPeer.ID)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// the static peer is redialed once it's disconnected
+	assert.Eventually(t, isConnected, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestPrivateMode_OnlyStaticPeers(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	sentry, stranger := servers[0], servers[1]
+
+	validator, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.PrivateMode = true
+			c.StaticPeers = []*peer.AddrInfo{sentry.AddrInfo()}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create server, %v", createErr)
+	}
+
+	servers = append(servers, validator)
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// the validator connects to its sentry
+	assert.Eventually(t, func() bool {
+		return validator.hasPeer(sentry.AddrInfo().ID)
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// the inbound connections of the other peers are rejected
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the dialer may complete the upgrade before the connection is closed by the validator
+	_ = stranger.host.Connect(ctx, *validator.AddrInfo())
+
+	assert.Eventually(t, func() bool {
+		return !stranger.IsConnected(validator.AddrInfo().ID)
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.False(t, validator.hasPeer(stranger.AddrInfo().ID))
+}
+
+func TestPrivateMode_NoPeers(t *testing.T) {
+	_, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.PrivateMode = true
+		},
+	})
+
+	assert.ErrorIs(t, createErr, ErrPrivateModeNoPeers)
+}
@@ -0,0 +1,90 @@
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// staticDialInterval is the interval of checking the connections to the static peers
+	staticDialInterval = time.Second
+
+	// staticDialTimeout is the timeout of a single dial of a static peer
+	staticDialTimeout = 10 * time.Second
+
+	// staticDialMinBackoff is the initial delay of redialing a static peer after a failed dial
+	staticDialMinBackoff = time.Second
+
+	// staticDialMaxBackoff is the maximum delay of redialing a static peer after the failed dials
+	staticDialMaxBackoff = time.Minute
+)
+
+// staticDial is the redial state of a static peer
+type staticDial struct {
+	backoff time.Duration
+	next    time.Time
+}
+
+// runStaticDial keeps the connections to the static peers. The static peers are dialed
+// outside of the dial queue, so they don't wait for the outbound slots, and are redialed
+// with an exponential backoff while they can't be reached
+func (s *Server) runStaticDial() {
+	if len(s.peerClasses.static) == 0 {
+		return
+	}
+
+	dials := make(map[peer.ID]*staticDial, len(s.peerClasses.static))
+	for id := range s.peerClasses.static {
+		dials[id] = &staticDial{backoff: staticDialMinBackoff}
+	}
+
+	ticker := time.NewTicker(staticDialInterval)
+	defer ticker.Stop()
+
+	for {
+		for id, info := range s.peerClasses.static {
+			dial := dials[id]
+
+			if s.IsConnected(id) {
+				dial.backoff = staticDialMinBackoff
+				dial.next = time.Time{}
+
+				continue
+			}
+
+			if time.Now().Before(dial.next) || s.bans.isBanned(id) {
+				continue
+			}
+
+			s.dialStaticPeer(info)
+
+			// the successful dial resets the backoff once the peer is connected
+			dial.next = time.Now().Add(dial.backoff)
+			dial.backoff *= 2
+
+			if dial.backoff > staticDialMaxBackoff {
+				dial.backoff = staticDialMaxBackoff
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// dialStaticPeer connects to the static peer, the handshake is done by the identity service
+func (s *Server) dialStaticPeer(info *peer.AddrInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), staticDialTimeout)
+	defer cancel()
+
+	s.logger.Debug("Dialing static peer", "addr", info)
+
+	if err := s.host.Connect(ctx, *info); err != nil {
+		s.logger.Debug("failed to dial static peer", "addr", info, "err", err.Error())
+	}
+}
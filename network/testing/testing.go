@@ -30,6 +30,8 @@ type MockNetworkingServer struct {
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
+	isProtectedPeerFn        isProtectedPeerDelegate
+	isPrivatePeerFn          isPrivatePeerDelegate
 
 	// Discovery Hooks
 	newDiscoveryClientFn       newDiscoveryClientDelegate
@@ -74,6 +76,8 @@ type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
+type isProtectedPeerDelegate func(peer.ID) bool
+type isPrivatePeerDelegate func(peer.ID) bool
 
 // Required for Discovery
 type getRandomBootnodeDelegate func() *peer.AddrInfo
@@ -174,6 +178,30 @@ func (m *MockNetworkingServer) HookHasFreeConnectionSlot(fn hasFreeConnectionSlo
 	m.hasFreeConnectionSlotFn = fn
 }
 
+func (m *MockNetworkingServer) IsProtectedPeer(peerID peer.ID) bool {
+	if m.isProtectedPeerFn != nil {
+		return m.isProtectedPeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsProtectedPeer(fn isProtectedPeerDelegate) {
+	m.isProtectedPeerFn = fn
+}
+
+func (m *MockNetworkingServer) IsPrivatePeer(peerID peer.ID) bool {
+	if m.isPrivatePeerFn != nil {
+		return m.isPrivatePeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsPrivatePeer(fn isPrivatePeerDelegate) {
+	m.isPrivatePeerFn = fn
+}
+
 func (m *MockNetworkingServer) GetRandomBootnode() *peer.AddrInfo {
 	if m.getRandomBootnodeFn != nil {
 		return m.getRandomBootnodeFn()
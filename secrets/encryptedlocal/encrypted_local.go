@@ -0,0 +1,413 @@
+package encryptedlocal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo/keystore"
+)
+
+const (
+	// defaultScryptN is the scrypt CPU/memory cost of the key derivation (Web3 Secret Storage default)
+	defaultScryptN = 1 << 18
+
+	// defaultScryptP is the scrypt parallelization of the key derivation (Web3 Secret Storage default)
+	defaultScryptP = 1
+)
+
+// EncryptedLocalSecretsManager is a SecretsManager that stores secrets locally on disk,
+// encrypted by a password in the Web3 Secret Storage (v3) format (scrypt + AES-128-CTR)
+type EncryptedLocalSecretsManager struct {
+	// Logger object
+	logger hclog.Logger
+
+	// Path to the base working directory
+	path string
+
+	// Password which the secrets are encrypted with
+	password string
+
+	// Scrypt parameters of the key derivation
+	scryptN int
+	scryptP int
+
+	// Map of known secrets and their paths
+	secretPathMap map[string]string
+
+	// Map of the decrypted secrets, so the key is derived only once per secret
+	decrypted map[string][]byte
+
+	// Mux for the secretPathMap and the decrypted secrets
+	lock sync.RWMutex
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Grab the path to the working directory, the runtime path takes precedence over the configured one
+	path, err := getPath(config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := ResolvePassword(config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set up the base object
+	manager := &EncryptedLocalSecretsManager{
+		logger:        params.Logger.Named(string(secrets.EncryptedLocal)),
+		path:          path,
+		password:      password,
+		scryptN:       defaultScryptN,
+		scryptP:       defaultScryptP,
+		secretPathMap: make(map[string]string),
+		decrypted:     make(map[string][]byte),
+	}
+
+	// Run the initial setup
+	if err := manager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// getPath returns the path to the base working directory
+func getPath(config *secrets.SecretsManagerConfig, params *secrets.SecretsManagerParams) (string, error) {
+	rawPath, ok := params.Extra[secrets.Path]
+	if !ok && config != nil {
+		rawPath, ok = config.Extra[secrets.Path]
+	}
+
+	if !ok {
+		return "", errors.New("no path specified for encrypted local secrets manager")
+	}
+
+	path, ok := rawPath.(string)
+	if !ok {
+		return "", errors.New("invalid type assertion")
+	}
+
+	return path, nil
+}
+
+// ResolvePassword returns the password of the encrypted local storage. The password is looked up
+// in the runtime params first, then in the configured password file and environment variable,
+// and finally in the default environment variable
+func ResolvePassword(config *secrets.SecretsManagerConfig, params *secrets.SecretsManagerParams) (string, error) {
+	if params != nil {
+		if password, ok := params.Extra[secrets.Password].(string); ok && password != "" {
+			return password, nil
+		}
+	}
+
+	var extra map[string]interface{}
+	if config != nil {
+		extra = config.Extra
+	}
+
+	if passwordFile, ok := extra[secrets.PasswordFile].(string); ok && passwordFile != "" {
+		return ReadPasswordFile(passwordFile)
+	}
+
+	passwordEnv := secrets.DefaultPasswordEnv
+	if env, ok := extra[secrets.PasswordEnv].(string); ok && env != "" {
+		passwordEnv = env
+	}
+
+	if password := os.Getenv(passwordEnv); password != "" {
+		return password, nil
+	}
+
+	return "", secrets.ErrPasswordNotSet
+}
+
+// ReadPasswordFile reads the password from the file, the trailing new line is not a part of the password
+func ReadPasswordFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read password file (%s), %w", path, err)
+	}
+
+	password := strings.TrimRight(string(content), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password file (%s) is empty", path)
+	}
+
+	return password, nil
+}
+
+// Setup sets up the encrypted local SecretsManager
+func (e *EncryptedLocalSecretsManager) Setup() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	subDirectories := []string{secrets.ConsensusFolderLocal, secrets.NetworkFolderLocal}
+
+	// Set up the local directories
+	if err := common.SetupDataDir(e.path, subDirectories, 0770); err != nil {
+		return err
+	}
+
+	// baseDir/consensus/validator.key.json
+	e.secretPathMap[secrets.ValidatorKey] = filepath.Join(
+		e.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorKeyLocal+secrets.EncryptedFileSuffix,
+	)
+
+	// baseDir/consensus/validator-bls.key.json
+	e.secretPathMap[secrets.ValidatorBLSKey] = filepath.Join(
+		e.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorBLSKeyLocal+secrets.EncryptedFileSuffix,
+	)
+
+	// baseDir/libp2p/libp2p.key.json
+	e.secretPathMap[secrets.NetworkKey] = filepath.Join(
+		e.path,
+		secrets.NetworkFolderLocal,
+		secrets.NetworkKeyLocal+secrets.EncryptedFileSuffix,
+	)
+
+	return nil
+}
+
+// GetSecret reads the secret from disk and decrypts it
+func (e *EncryptedLocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	e.lock.RLock()
+	secretPath, ok := e.secretPathMap[name]
+	secret, decrypted := e.decrypted[name]
+	e.lock.RUnlock()
+
+	if !ok {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	if decrypted {
+		return secret, nil
+	}
+
+	secret, err := e.readSecret(secretPath, e.password)
+	if err != nil {
+		return nil, err
+	}
+
+	e.lock.Lock()
+	e.decrypted[name] = secret
+	e.lock.Unlock()
+
+	return secret, nil
+}
+
+// readSecret reads the secret from disk and decrypts it with the password
+func (e *EncryptedLocalSecretsManager) readSecret(secretPath, password string) ([]byte, error) {
+	content, err := os.ReadFile(secretPath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to read secret from disk (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	secret, err := keystore.DecryptV3(content, password)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to decrypt secret (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	return secret, nil
+}
+
+// SetSecret encrypts the secret and saves it to disk
+func (e *EncryptedLocalSecretsManager) SetSecret(name string, value []byte) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	secretPath, ok := e.secretPathMap[name]
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	// Checks for existing secret
+	if _, err := os.Stat(secretPath); err == nil {
+		return fmt.Errorf(
+			"%s already initialized",
+			secretPath,
+		)
+	}
+
+	if err := e.writeSecret(secretPath, value, e.password); err != nil {
+		return err
+	}
+
+	e.decrypted[name] = value
+
+	return nil
+}
+
+// writeSecret encrypts the secret with the password and writes it to disk, replacing the existing one
+func (e *EncryptedLocalSecretsManager) writeSecret(secretPath string, value []byte, password string) error {
+	tmpPath, err := e.writeTmpSecret(secretPath, value, password)
+	if err != nil {
+		return err
+	}
+
+	return renameTmpSecret(tmpPath, secretPath)
+}
+
+// writeTmpSecret encrypts the secret with the password and writes it to the temporary file next to the secret,
+// so the existing secret can be replaced atomically by renaming the temporary file
+func (e *EncryptedLocalSecretsManager) writeTmpSecret(
+	secretPath string,
+	value []byte,
+	password string,
+) (string, error) {
+	encrypted, err := keystore.EncryptV3(value, password, e.scryptN, e.scryptP)
+	if err != nil {
+		return "", fmt.Errorf("unable to encrypt secret (%s), %w", secretPath, err)
+	}
+
+	tmpPath := secretPath + ".tmp"
+
+	// the read-only temporary file left by the interrupted write can't be overwritten
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf(
+			"unable to remove stale temporary secret (%s), %w",
+			tmpPath,
+			err,
+		)
+	}
+
+	if err := common.SaveFileSafe(tmpPath, encrypted, 0440); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return "", fmt.Errorf(
+			"unable to write secret to disk (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	return tmpPath, nil
+}
+
+// renameTmpSecret replaces the secret with its temporary file
+func renameTmpSecret(tmpPath, secretPath string) error {
+	if err := os.Rename(tmpPath, secretPath); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return fmt.Errorf(
+			"unable to write secret to disk (%s), %w",
+			secretPath,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// HasSecret checks if the secret is present on disk
+func (e *EncryptedLocalSecretsManager) HasSecret(name string) bool {
+	e.lock.RLock()
+	secretPath, ok := e.secretPathMap[name]
+	e.lock.RUnlock()
+
+	return ok && common.FileExists(secretPath)
+}
+
+// RemoveSecret removes the secret from disk
+func (e *EncryptedLocalSecretsManager) RemoveSecret(name string) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	secretPath, ok := e.secretPathMap[name]
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	delete(e.secretPathMap, name)
+	delete(e.decrypted, name)
+
+	if removeErr := os.Remove(secretPath); removeErr != nil {
+		return fmt.Errorf("unable to remove secret, %w", removeErr)
+	}
+
+	return nil
+}
+
+// ChangePassword re-encrypts all the stored secrets with the new password
+func (e *EncryptedLocalSecretsManager) ChangePassword(newPassword string) error {
+	if newPassword == "" {
+		return secrets.ErrPasswordNotSet
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	// decrypt all the secrets first, so none of them is re-encrypted if the current password is wrong
+	values := make(map[string][]byte, len(e.secretPathMap))
+
+	for name, secretPath := range e.secretPathMap {
+		if !common.FileExists(secretPath) {
+			continue
+		}
+
+		value, err := e.readSecret(secretPath, e.password)
+		if err != nil {
+			return err
+		}
+
+		values[name] = value
+	}
+
+	// write all the re-encrypted secrets to the temporary files first,
+	// so none of the secrets is replaced if any of them fails to be written
+	tmpPaths := make(map[string]string, len(values))
+
+	removeTmpSecrets := func() {
+		for _, tmpPath := range tmpPaths {
+			_ = os.Remove(tmpPath)
+		}
+	}
+
+	for name, value := range values {
+		tmpPath, err := e.writeTmpSecret(e.secretPathMap[name], value, newPassword)
+		if err != nil {
+			removeTmpSecrets()
+
+			return err
+		}
+
+		tmpPaths[name] = tmpPath
+	}
+
+	for name, tmpPath := range tmpPaths {
+		delete(tmpPaths, name)
+
+		if err := renameTmpSecret(tmpPath, e.secretPathMap[name]); err != nil {
+			removeTmpSecrets()
+
+			return err
+		}
+	}
+
+	e.password = newPassword
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package encryptedlocal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassword = "test-password"
+
+func TestEncryptedLocalSecretsManagerFactory(t *testing.T) {
+	workingDirectory := t.TempDir()
+
+	passwordFile := filepath.Join(workingDirectory, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte(testPassword+"\n"), 0600))
+
+	testTable := []struct {
+		name          string
+		config        *secrets.SecretsManagerConfig
+		params        *secrets.SecretsManagerParams
+		shouldSucceed bool
+	}{
+		{
+			"Valid configuration with path and password",
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path:     workingDirectory,
+					secrets.Password: testPassword,
+				},
+			},
+			true,
+		},
+		{
+			"Valid configuration with password file",
+			&secrets.SecretsManagerConfig{
+				Type: secrets.EncryptedLocal,
+				Extra: map[string]interface{}{
+					secrets.Path:         workingDirectory,
+					secrets.PasswordFile: passwordFile,
+				},
+			},
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+			},
+			true,
+		},
+		{
+			"Invalid configuration without path info",
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Password: testPassword,
+				},
+			},
+			false,
+		},
+		{
+			"Invalid configuration without password",
+			&secrets.SecretsManagerConfig{
+				Type: secrets.EncryptedLocal,
+				Extra: map[string]interface{}{
+					secrets.PasswordEnv: "EDGE_TEST_UNSET_PASSWORD",
+				},
+			},
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path: workingDirectory,
+				},
+			},
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			secretsManager, factoryErr := SecretsManagerFactory(testCase.config, testCase.params)
+			if testCase.shouldSucceed {
+				assert.NotNil(t, secretsManager)
+				assert.NoError(t, factoryErr)
+			} else {
+				assert.Nil(t, secretsManager)
+				assert.Error(t, factoryErr)
+			}
+		})
+	}
+}
+
+func TestResolvePassword_Env(t *testing.T) {
+	t.Setenv("EDGE_TEST_PASSWORD", testPassword)
+
+	password, err := ResolvePassword(
+		&secrets.SecretsManagerConfig{
+			Extra: map[string]interface{}{
+				secrets.PasswordEnv: "EDGE_TEST_PASSWORD",
+			},
+		},
+		&secrets.SecretsManagerParams{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testPassword, password)
+
+	t.Setenv(secrets.DefaultPasswordEnv, "default-password")
+
+	password, err = ResolvePassword(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "default-password", password)
+}
+
+// getEncryptedLocalSecretsManager is a helper method for creating an instance of the
+// encrypted local secrets manager with the light key derivation
+func getEncryptedLocalSecretsManager(t *testing.T, path, password string) *EncryptedLocalSecretsManager {
+	t.Helper()
+
+	secretsManager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:     path,
+			secrets.Password: password,
+		},
+	})
+	require.NoError(t, err)
+
+	manager, ok := secretsManager.(*EncryptedLocalSecretsManager)
+	require.True(t, ok)
+
+	manager.scryptN = 1 << 12
+
+	return manager
+}
+
+func TestEncryptedLocalSecretsManager_SetGetSecret(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	value := []byte("validator secret")
+
+	manager := getEncryptedLocalSecretsManager(t, dir, testPassword)
+
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, value))
+	assert.True(t, manager.HasSecret(secrets.ValidatorKey))
+
+	// the secret can't be overwritten
+	assert.Error(t, manager.SetSecret(secrets.ValidatorKey, value))
+
+	// the secret isn't stored as plaintext
+	content, err := os.ReadFile(filepath.Join(
+		dir,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorKeyLocal+secrets.EncryptedFileSuffix,
+	))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), string(value))
+
+	// the secret is decrypted by a new instance with the same password
+	secret, err := getEncryptedLocalSecretsManager(t, dir, testPassword).GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	assert.Equal(t, value, secret)
+
+	// the secret isn't decrypted with a wrong password
+	_, err = getEncryptedLocalSecretsManager(t, dir, "wrong").GetSecret(secrets.ValidatorKey)
+	assert.Error(t, err)
+
+	_, err = manager.GetSecret("dummy")
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+
+	require.NoError(t, manager.RemoveSecret(secrets.ValidatorKey))
+	assert.False(t, manager.HasSecret(secrets.ValidatorKey))
+}
+
+func TestEncryptedLocalSecretsManager_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	const newPassword = "new-password"
+
+	dir := t.TempDir()
+	values := map[string][]byte{
+		secrets.ValidatorKey:    []byte("ecdsa secret"),
+		secrets.ValidatorBLSKey: []byte("bls secret"),
+	}
+
+	manager := getEncryptedLocalSecretsManager(t, dir, testPassword)
+
+	for name, value := range values {
+		require.NoError(t, manager.SetSecret(name, value))
+	}
+
+	// none of the secrets is re-encrypted when the current password is wrong
+	assert.Error(t, getEncryptedLocalSecretsManager(t, dir, "wrong").ChangePassword(newPassword))
+
+	// none of the secrets is replaced when any of them fails to be written
+	blsTmpPath := manager.secretPathMap[secrets.ValidatorBLSKey] + ".tmp"
+	require.NoError(t, os.MkdirAll(filepath.Join(blsTmpPath, "dir"), 0750))
+	assert.Error(t, manager.ChangePassword(newPassword))
+	assert.NoFileExists(t, manager.secretPathMap[secrets.ValidatorKey]+".tmp")
+	require.NoError(t, os.RemoveAll(blsTmpPath))
+
+	for name, value := range values {
+		secret, err := getEncryptedLocalSecretsManager(t, dir, testPassword).GetSecret(name)
+		require.NoError(t, err)
+		assert.Equal(t, value, secret)
+	}
+
+	// the read-only temporary file left by the interrupted write is replaced
+	require.NoError(t, os.WriteFile(blsTmpPath, []byte("stale"), 0440))
+
+	require.NoError(t, manager.ChangePassword(newPassword))
+	assert.NoFileExists(t, blsTmpPath)
+
+	_, err := getEncryptedLocalSecretsManager(t, dir, testPassword).GetSecret(secrets.ValidatorKey)
+	assert.Error(t, err)
+
+	reopened := getEncryptedLocalSecretsManager(t, dir, newPassword)
+
+	for name, value := range values {
+		secret, err := reopened.GetSecret(name)
+		require.NoError(t, err)
+		assert.Equal(t, value, secret)
+	}
+
+	assert.False(t, reopened.HasSecret(secrets.NetworkKey))
+}
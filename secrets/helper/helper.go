@@ -9,9 +9,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/awsssm"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
 	"github.com/0xPolygon/polygon-edge/secrets/gcpssm"
 	"github.com/0xPolygon/polygon-edge/secrets/hashicorpvault"
 	"github.com/0xPolygon/polygon-edge/secrets/local"
+	"github.com/0xPolygon/polygon-edge/secrets/remotesigner"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
@@ -34,6 +36,68 @@ func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
 	)
 }
 
+// SetupEncryptedLocalSecretsManager is a helper method for boilerplate encrypted local secrets manager setup
+func SetupEncryptedLocalSecretsManager(dataDir, password string) (secrets.SecretsManager, error) {
+	return encryptedlocal.SecretsManagerFactory(
+		nil, // The password is passed directly, so no config is required
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path:     dataDir,
+				secrets.Password: password,
+			},
+		},
+	)
+}
+
+// setupEncryptedLocal is a helper method for boilerplate encrypted local secrets manager setup from the config
+func setupEncryptedLocal(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return encryptedlocal.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// ImportLocalSecrets moves the plaintext secrets of the local secrets manager in dataDir
+// to the provided secrets manager, and returns the names of the imported secrets.
+// The secrets already present in the provided secrets manager are left intact
+func ImportLocalSecrets(dataDir string, secretsManager secrets.SecretsManager) ([]string, error) {
+	localManager, err := SetupLocalSecretsManager(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var imported []string
+
+	for _, name := range []string{secrets.ValidatorKey, secrets.ValidatorBLSKey, secrets.NetworkKey} {
+		if !localManager.HasSecret(name) || secretsManager.HasSecret(name) {
+			continue
+		}
+
+		value, err := localManager.GetSecret(name)
+		if err != nil {
+			return imported, err
+		}
+
+		if err := secretsManager.SetSecret(name, value); err != nil {
+			return imported, fmt.Errorf("unable to import secret %s, %w", name, err)
+		}
+
+		// the plaintext secret is removed only once the secret is stored by the new secrets manager
+		if err := localManager.RemoveSecret(name); err != nil {
+			return imported, err
+		}
+
+		imported = append(imported, name)
+	}
+
+	return imported, nil
+}
+
 // setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
 func setupHashicorpVault(
 	secretsConfig *secrets.SecretsManagerConfig,
@@ -70,6 +134,18 @@ func setupGCPSSM(
 	)
 }
 
+// setupRemoteSigner is a helper method for boilerplate remote signer secrets manager setup
+func setupRemoteSigner(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return remotesigner.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
 // InitECDSAValidatorKey creates new ECDSA key and set as a validator key
 func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
 	if secretsManager.HasSecret(secrets.ValidatorKey) {
@@ -235,6 +311,20 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = GCPSSM
+	case secrets.EncryptedLocal:
+		encryptedLocal, err := setupEncryptedLocal(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = encryptedLocal
+	case secrets.RemoteSigner:
+		remoteSigner, err := setupRemoteSigner(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = remoteSigner
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
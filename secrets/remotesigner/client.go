@@ -0,0 +1,103 @@
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DefaultRequestTimeout is the timeout of a single request to the remote signer
+const DefaultRequestTimeout = 5 * time.Second
+
+var _ secrets.Signer = (*Client)(nil)
+
+// Client is the secrets.Signer which requests the signatures from the remote signer over gRPC
+type Client struct {
+	conn    *grpc.ClientConn
+	client  proto.SignerClient
+	timeout time.Duration
+}
+
+// NewClient creates the client of the remote signer listening on the given address.
+// The connection is insecure only if no TLS file is set and the signer runs on the loopback interface
+func NewClient(addr string, timeout time.Duration, tlsConfig *TLSConfig) (*Client, error) {
+	creds := insecure.NewCredentials()
+
+	if tlsConfig.IsEnabled() {
+		config, err := tlsConfig.ClientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		creds = credentials.NewTLS(config)
+	} else if !IsLoopbackAddr(addr) {
+		return nil, errInsecureRemoteAddr
+	}
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to remote signer %s, %w", addr, err)
+	}
+
+	return &Client{
+		conn:    conn,
+		client:  proto.NewSignerClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Address returns the address of the validator ECDSA key held by the remote signer
+func (c *Client) Address() (types.Address, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.Address(ctx, &empty.Empty{})
+	if err != nil {
+		return types.ZeroAddress, fmt.Errorf("unable to get address from remote signer, %w", err)
+	}
+
+	if len(resp.Address) != types.AddressLength {
+		return types.ZeroAddress, fmt.Errorf("invalid address length returned by remote signer: %d", len(resp.Address))
+	}
+
+	return types.BytesToAddress(resp.Address), nil
+}
+
+// SignDigest requests the signature of the digest by the validator ECDSA key
+func (c *Client) SignDigest(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.SignDigest(ctx, &proto.SignRequest{Digest: digest})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign digest by remote signer, %w", err)
+	}
+
+	return resp.Signature, nil
+}
+
+// SignWithDomain requests the signature of the digest by the validator BLS key and the domain
+func (c *Client) SignWithDomain(digest, domain []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.SignWithDomain(ctx, &proto.SignRequest{Digest: digest, Domain: domain})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign digest with domain by remote signer, %w", err)
+	}
+
+	return resp.Signature, nil
+}
+
+// Close closes the connection to the remote signer
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/encryptedlocal"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/secrets/remotesigner"
+	"github.com/0xPolygon/polygon-edge/secrets/remotesigner/proto"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const defaultAddr = "127.0.0.1:9700"
+
+// The reference remote signer, which holds the validator keys of a single node and serves
+// the signatures over gRPC. The node connects to it by the secrets config of the remote-signer type
+// with the server URL of the signer. It listens on the non-loopback address only with mutual TLS,
+// so only the nodes holding the certificate signed by the client CA can request the signatures
+func main() {
+	var (
+		dataDir      = flag.String("data-dir", "", "the directory with the validator keys")
+		configPath   = flag.String("config", "", "the path to the SecretsManager config file holding the keys")
+		passwordFile = flag.String("password-file", "", "the path to the password file of the encrypted keys")
+		addr         = flag.String("addr", defaultAddr, "the address the signer listens on")
+		logLevel     = flag.String("log-level", "info", "the log level")
+		tlsCertFile  = flag.String("tls-cert-file", "", "the path to the TLS certificate of the signer")
+		tlsKeyFile   = flag.String("tls-key-file", "", "the path to the private key of the TLS certificate")
+		tlsCAFile    = flag.String("tls-client-ca-file", "", "the path to the CA certificate of the nodes")
+	)
+
+	flag.Parse()
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "remote-signer",
+		Level: hclog.LevelFromString(*logLevel),
+	})
+
+	secretsManager, err := getSecretsManager(*dataDir, *configPath, *passwordFile)
+	if err != nil {
+		log.Fatalf("unable to set up secrets manager: %v", err)
+	}
+
+	service, err := remotesigner.NewService(logger, secretsManager)
+	if err != nil {
+		log.Fatalf("unable to set up signer: %v", err)
+	}
+
+	tlsConfig := &remotesigner.TLSConfig{
+		CAFile:   *tlsCAFile,
+		CertFile: *tlsCertFile,
+		KeyFile:  *tlsKeyFile,
+	}
+
+	serverOpts, err := getServerOptions(*addr, tlsConfig)
+	if err != nil {
+		log.Fatalf("unable to set up TLS: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("unable to listen on %s: %v", *addr, err)
+	}
+
+	server := grpc.NewServer(serverOpts...)
+	proto.RegisterSignerServer(server, service)
+
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			logger.Error("signer stopped", "err", err)
+		}
+	}()
+
+	logger.Info("remote signer started", "addr", lis.Addr().String(), "tls", tlsConfig.IsEnabled())
+
+	<-common.GetTerminationSignalCh()
+
+	logger.Info("stopping remote signer")
+	server.GracefulStop()
+}
+
+// getSecretsManager returns the secrets manager holding the validator keys
+func getSecretsManager(dataDir, configPath, passwordFile string) (secrets.SecretsManager, error) {
+	if configPath != "" {
+		secretsConfig, err := secrets.ReadConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return helper.InitCloudSecretsManager(secretsConfig)
+	}
+
+	if passwordFile != "" {
+		password, err := encryptedlocal.ReadPasswordFile(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return helper.SetupEncryptedLocalSecretsManager(dataDir, password)
+	}
+
+	return helper.SetupLocalSecretsManager(dataDir)
+}
+
+// getServerOptions returns the options of the gRPC server, the signer listening on the non-loopback
+// address has to authenticate the nodes by their TLS certificates
+func getServerOptions(addr string, tlsConfig *remotesigner.TLSConfig) ([]grpc.ServerOption, error) {
+	if !remotesigner.IsLoopbackAddr(addr) && (tlsConfig.CAFile == "" || tlsConfig.CertFile == "") {
+		return nil, fmt.Errorf("listening on non-loopback address %s requires TLS certificate, key and client CA", addr)
+	}
+
+	if !tlsConfig.IsEnabled() {
+		return nil, nil
+	}
+
+	config, err := tlsConfig.ServerTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}, nil
+}
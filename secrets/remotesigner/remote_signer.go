@@ -0,0 +1,128 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
+)
+
+// RemoteSignerSecretsManager is a SecretsManager whose validator keys are held
+// by the remote signer, so they never live on the node and only the signatures
+// are requested. The networking key isn't a validator key, it is kept on the local FS
+type RemoteSignerSecretsManager struct {
+	*Client
+
+	// The secrets manager of the networking key
+	local secrets.SecretsManager
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Check if the server URL of the remote signer is present
+	if config == nil || config.ServerURL == "" {
+		return nil, errors.New("no server URL specified for remote signer secrets manager")
+	}
+
+	// The networking key is stored in the base working directory,
+	// the runtime path takes precedence over the configured one
+	localParams := &secrets.SecretsManagerParams{
+		Logger: params.Logger,
+		Extra:  map[string]interface{}{},
+	}
+
+	if path, ok := config.Extra[secrets.Path]; ok {
+		localParams.Extra[secrets.Path] = path
+	}
+
+	if path, ok := params.Extra[secrets.Path]; ok {
+		localParams.Extra[secrets.Path] = path
+	}
+
+	localManager, err := local.SecretsManagerFactory(nil, localParams)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := getTLSConfig(config)
+
+	client, err := NewClient(config.ServerURL, DefaultRequestTimeout, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Logger.Named(string(secrets.RemoteSigner)).Info("validator keys are held by the remote signer",
+		"addr", config.ServerURL, "tls", tlsConfig.IsEnabled())
+
+	return &RemoteSignerSecretsManager{
+		Client: client,
+		local:  localManager,
+	}, nil
+}
+
+// getTLSConfig returns the TLS files of the connection to the remote signer set in the secrets config
+func getTLSConfig(config *secrets.SecretsManagerConfig) *TLSConfig {
+	getFile := func(key string) string {
+		if file, ok := config.Extra[key]; ok {
+			return fmt.Sprintf("%v", file)
+		}
+
+		return ""
+	}
+
+	return &TLSConfig{
+		CAFile:   getFile(TLSCAFile),
+		CertFile: getFile(TLSCertFile),
+		KeyFile:  getFile(TLSKeyFile),
+	}
+}
+
+// isValidatorKey checks if the secret is a validator key held by the remote signer
+func isValidatorKey(name string) bool {
+	return name == secrets.ValidatorKey || name == secrets.ValidatorBLSKey
+}
+
+// Setup sets up the secrets manager of the networking key
+func (r *RemoteSignerSecretsManager) Setup() error {
+	return r.local.Setup()
+}
+
+// GetSecret gets the networking key, the validator keys are never exposed by the remote signer
+func (r *RemoteSignerSecretsManager) GetSecret(name string) ([]byte, error) {
+	if isValidatorKey(name) {
+		return nil, secrets.ErrRemoteSignerOnly
+	}
+
+	return r.local.GetSecret(name)
+}
+
+// SetSecret sets the networking key, the validator keys are set on the remote signer
+func (r *RemoteSignerSecretsManager) SetSecret(name string, value []byte) error {
+	if isValidatorKey(name) {
+		return secrets.ErrRemoteSignerOnly
+	}
+
+	return r.local.SetSecret(name, value)
+}
+
+// HasSecret checks if the networking key is present, the validator keys are never present on the node
+func (r *RemoteSignerSecretsManager) HasSecret(name string) bool {
+	if isValidatorKey(name) {
+		return false
+	}
+
+	return r.local.HasSecret(name)
+}
+
+// RemoveSecret removes the networking key, the validator keys are removed on the remote signer
+func (r *RemoteSignerSecretsManager) RemoveSecret(name string) error {
+	if isValidatorKey(name) {
+		return secrets.ErrRemoteSignerOnly
+	}
+
+	return r.local.RemoveSecret(name)
+}
@@ -0,0 +1,268 @@
+package remotesigner
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
+	"github.com/0xPolygon/polygon-edge/secrets/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// startTestSigner starts the remote signer holding the keys of a new account
+func startTestSigner(t *testing.T, opts ...grpc.ServerOption) (*wallet.Account, string) {
+	t.Helper()
+
+	signerSecrets, err := local.SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra:  map[string]interface{}{secrets.Path: t.TempDir()},
+	})
+	require.NoError(t, err)
+
+	account, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+	require.NoError(t, account.Save(signerSecrets))
+
+	service, err := NewService(hclog.NewNullLogger(), signerSecrets)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(opts...)
+	proto.RegisterSignerServer(server, service)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return account, lis.Addr().String()
+}
+
+func TestRemoteSigner_Sign(t *testing.T) {
+	t.Parallel()
+
+	account, addr := startTestSigner(t)
+
+	client, err := NewClient(addr, DefaultRequestTimeout, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	address, err := client.Address()
+	require.NoError(t, err)
+	assert.Equal(t, account.Address(), address)
+
+	digest := crypto.Keccak256([]byte("digest"))
+
+	signature, err := client.SignDigest(digest)
+	require.NoError(t, err)
+
+	signer, err := wallet.RecoverAddressFromSignature(signature, []byte("digest"))
+	require.NoError(t, err)
+	assert.Equal(t, address, signer)
+
+	// the digest of an invalid length is rejected
+	_, err = client.SignDigest([]byte("digest"))
+	assert.Error(t, err)
+
+	blsSignature, err := client.SignWithDomain(digest, bls.DomainCheckpointManager)
+	require.NoError(t, err)
+
+	sig, err := bls.UnmarshalSignature(blsSignature)
+	require.NoError(t, err)
+	assert.True(t, sig.Verify(account.Bls.PublicKey(), digest, bls.DomainCheckpointManager))
+}
+
+func TestRemoteSignerSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	_, addr := startTestSigner(t)
+
+	_, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Type: secrets.RemoteSigner},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	assert.Error(t, err)
+
+	secretsManager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{
+			Type:      secrets.RemoteSigner,
+			ServerURL: addr,
+		},
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra:  map[string]interface{}{secrets.Path: t.TempDir()},
+		},
+	)
+	require.NoError(t, err)
+
+	signer, ok := secretsManager.(secrets.Signer)
+	require.True(t, ok)
+
+	address, err := signer.Address()
+	require.NoError(t, err)
+	assert.NotEqual(t, types.ZeroAddress, address)
+
+	// the validator keys are never exposed
+	for _, name := range []string{secrets.ValidatorKey, secrets.ValidatorBLSKey} {
+		assert.False(t, secretsManager.HasSecret(name))
+
+		_, err := secretsManager.GetSecret(name)
+		assert.ErrorIs(t, err, secrets.ErrRemoteSignerOnly)
+		assert.ErrorIs(t, secretsManager.SetSecret(name, []byte{1}), secrets.ErrRemoteSignerOnly)
+	}
+
+	// the networking key is stored locally
+	require.NoError(t, secretsManager.SetSecret(secrets.NetworkKey, []byte("network key")))
+	assert.True(t, secretsManager.HasSecret(secrets.NetworkKey))
+
+	networkKey, err := secretsManager.GetSecret(secrets.NetworkKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("network key"), networkKey)
+}
+
+// writeTestCert writes the PEM encoded certificate signed by the parent and its private key to the directory,
+// the certificate is self-signed if the parent is nil
+func writeTestCert(
+	t *testing.T,
+	dir, name string,
+	parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func TestRemoteSigner_TLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	caCert, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "signer", caCert, caKey)
+	writeTestCert(t, dir, "node", caCert, caKey)
+
+	serverConfig, err := (&TLSConfig{
+		CAFile:   file("ca.crt"),
+		CertFile: file("signer.crt"),
+		KeyFile:  file("signer.key"),
+	}).ServerTLSConfig()
+	require.NoError(t, err)
+
+	account, addr := startTestSigner(t, grpc.Creds(credentials.NewTLS(serverConfig)))
+
+	// the node authenticated by its certificate gets the signatures
+	client, err := NewClient(addr, DefaultRequestTimeout, &TLSConfig{
+		CAFile:   file("ca.crt"),
+		CertFile: file("node.crt"),
+		KeyFile:  file("node.key"),
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	address, err := client.Address()
+	require.NoError(t, err)
+	assert.Equal(t, account.Address(), address)
+
+	// the node without the certificate is rejected
+	anonymous, err := NewClient(addr, DefaultRequestTimeout, &TLSConfig{CAFile: file("ca.crt")})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = anonymous.Close()
+	})
+
+	_, err = anonymous.Address()
+	assert.Error(t, err)
+
+	// the certificate without the key is rejected
+	_, err = NewClient(addr, DefaultRequestTimeout, &TLSConfig{CertFile: file("node.crt")})
+	assert.ErrorIs(t, err, errCertWithoutKey)
+
+	// the insecure connection is allowed only on the loopback interface
+	_, err = NewClient("10.0.0.1:9700", DefaultRequestTimeout, nil)
+	assert.ErrorIs(t, err, errInsecureRemoteAddr)
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"127.0.0.1:9700": true,
+		"localhost:9700": true,
+		"[::1]:9700":     true,
+		"0.0.0.0:9700":   false,
+		":9700":          false,
+		"10.0.0.1:9700":  false,
+		"signer:9700":    false,
+		"127.0.0.1":      false,
+	}
+
+	for addr, expected := range cases {
+		assert.Equal(t, expected, IsLoopbackAddr(addr), addr)
+	}
+}
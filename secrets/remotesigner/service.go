@@ -0,0 +1,101 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	bls "github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/remotesigner/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Service is the gRPC service of the remote signer. It holds the validator keys
+// loaded from its own secrets manager and signs with them on behalf of the node
+type Service struct {
+	proto.UnimplementedSignerServer
+
+	logger   hclog.Logger
+	ecdsaKey *ecdsa.PrivateKey
+	address  types.Address
+
+	// blsKey is nil if the secrets manager doesn't hold the BLS key of PolyBFT
+	blsKey *bls.PrivateKey
+}
+
+// NewService creates the signer service from the validator keys of the secrets manager
+func NewService(logger hclog.Logger, secretsManager secrets.SecretsManager) (*Service, error) {
+	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ecdsa key: %w", err)
+	}
+
+	ecdsaKey, err := crypto.BytesToECDSAPrivateKey(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ecdsa key: %w", err)
+	}
+
+	service := &Service{
+		logger:   logger,
+		ecdsaKey: ecdsaKey,
+		address:  crypto.PubKeyToAddress(&ecdsaKey.PublicKey),
+	}
+
+	// the BLS key is only used by PolyBFT, the signer works without it for IBFT
+	if encodedKey, err = secretsManager.GetSecret(secrets.ValidatorBLSKey); err == nil {
+		if service.blsKey, err = bls.UnmarshalPrivateKey(encodedKey); err != nil {
+			logger.Warn("unable to parse BLS key, signing with domain is disabled", "err", err)
+		}
+	}
+
+	return service, nil
+}
+
+// Address returns the address of the validator ECDSA key
+func (s *Service) Address(_ context.Context, _ *empty.Empty) (*proto.AddressResponse, error) {
+	return &proto.AddressResponse{Address: s.address.Bytes()}, nil
+}
+
+// SignDigest signs the digest with the validator ECDSA key
+func (s *Service) SignDigest(_ context.Context, req *proto.SignRequest) (*proto.SignResponse, error) {
+	if len(req.Digest) != types.HashLength {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid digest length: %d", len(req.Digest))
+	}
+
+	signature, err := crypto.Sign(s.ecdsaKey, req.Digest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to sign digest: %v", err)
+	}
+
+	s.logger.Debug("signed digest", "digest", hex.EncodeToHex(req.Digest))
+
+	return &proto.SignResponse{Signature: signature}, nil
+}
+
+// SignWithDomain signs the digest with the validator BLS key and the domain
+func (s *Service) SignWithDomain(_ context.Context, req *proto.SignRequest) (*proto.SignResponse, error) {
+	if s.blsKey == nil {
+		return nil, status.Error(codes.FailedPrecondition, "BLS key is not available")
+	}
+
+	signature, err := s.blsKey.Sign(req.Digest, req.Domain)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to sign digest: %v", err)
+	}
+
+	raw, err := signature.Marshal()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal signature: %v", err)
+	}
+
+	s.logger.Debug("signed digest with domain", "digest", hex.EncodeToHex(req.Digest))
+
+	return &proto.SignResponse{Signature: raw}, nil
+}
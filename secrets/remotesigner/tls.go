@@ -0,0 +1,136 @@
+package remotesigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+)
+
+const (
+	// TLSCAFile is the secrets config extra key of the CA certificate the remote signer is verified with
+	TLSCAFile = "tls-ca-file"
+
+	// TLSCertFile is the secrets config extra key of the certificate the node authenticates with
+	TLSCertFile = "tls-cert-file"
+
+	// TLSKeyFile is the secrets config extra key of the private key of the node certificate
+	TLSKeyFile = "tls-key-file"
+)
+
+var (
+	errInsecureRemoteAddr = errors.New("TLS is required to connect to the remote signer on a non-loopback address")
+	errCertWithoutKey     = errors.New("both TLS certificate and key have to be set")
+)
+
+// TLSConfig defines the files of the TLS connection to the remote signer.
+// The CA certificate verifies the peer, while the certificate and the key authenticate the own side
+type TLSConfig struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// IsEnabled checks if any of the TLS files is set
+func (c *TLSConfig) IsEnabled() bool {
+	return c != nil && (c.CAFile != "" || c.CertFile != "" || c.KeyFile != "")
+}
+
+// ClientTLSConfig returns the TLS config of the node connecting to the remote signer,
+// the system CA certificates are used if the CA file is not set
+func (c *TLSConfig) ClientTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := c.loadKeyPair()
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// ServerTLSConfig returns the TLS config of the remote signer, the nodes have to present
+// the certificate signed by the CA if the CA file is set
+func (c *TLSConfig) ServerTLSConfig() (*tls.Config, error) {
+	cert, err := c.loadKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// loadKeyPair loads the certificate along with its private key
+func (c *TLSConfig) loadKeyPair() (tls.Certificate, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return tls.Certificate{}, errCertWithoutKey
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to load TLS certificate, %w", err)
+	}
+
+	return cert, nil
+}
+
+// loadCertPool loads the pool of the CA certificates from the PEM file
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read TLS CA certificate, %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no valid certificate in TLS CA file %s", caFile)
+	}
+
+	return pool, nil
+}
+
+// IsLoopbackAddr checks if the host of the address is the loopback interface
+func IsLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
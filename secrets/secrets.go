@@ -3,6 +3,7 @@ package secrets
 import (
 	"errors"
 
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -19,8 +20,21 @@ const (
 
 	// Name is the name of the current node
 	Name = "name"
+
+	// Password is the password of the encrypted local storage
+	Password = "password"
+
+	// PasswordFile is the path to the file holding the password of the encrypted local storage
+	PasswordFile = "password-file"
+
+	// PasswordEnv is the name of the environment variable holding the password of the encrypted local storage
+	PasswordEnv = "password-env"
 )
 
+// DefaultPasswordEnv is the environment variable holding the password of the encrypted local storage,
+// if neither the password file nor the environment variable is configured
+const DefaultPasswordEnv = "EDGE_SECRETS_PASSWORD"
+
 // Define constant names for available secrets
 const (
 	// ValidatorKey is the private key secret of the validator node
@@ -40,6 +54,9 @@ const (
 	NetworkKeyLocal      = "libp2p.key"
 )
 
+// EncryptedFileSuffix is the suffix of the files of the encrypted local StorageManager
+const EncryptedFileSuffix = ".json"
+
 // Define constant folder names for the local StorageManager
 const (
 	ConsensusFolderLocal = "consensus"
@@ -47,7 +64,9 @@ const (
 )
 
 var (
-	ErrSecretNotFound = errors.New("secret not found")
+	ErrSecretNotFound   = errors.New("secret not found")
+	ErrPasswordNotSet   = errors.New("password of the encrypted local storage not set")
+	ErrRemoteSignerOnly = errors.New("validator keys are held by the remote signer")
 )
 
 type SecretsManagerType string
@@ -57,6 +76,9 @@ const (
 	// Local pertains to the local FS [Default]
 	Local SecretsManagerType = "local"
 
+	// EncryptedLocal pertains to the local FS, with the secrets encrypted by a password
+	EncryptedLocal SecretsManagerType = "encrypted-local"
+
 	// HashicorpVault pertains to the Hashicorp Vault server
 	HashicorpVault SecretsManagerType = "hashicorp-vault"
 
@@ -65,6 +87,9 @@ const (
 
 	// GCPSSM pertains to the Google Cloud Computing secret store manager
 	GCPSSM SecretsManagerType = "gcp-ssm"
+
+	// RemoteSigner pertains to the out-of-process signer holding the validator keys
+	RemoteSigner SecretsManagerType = "remote-signer"
 )
 
 // SecretsManager defines the base public interface that all
@@ -86,6 +111,19 @@ type SecretsManager interface {
 	RemoveSecret(name string) error
 }
 
+// Signer defines the signing-only interface of the secrets managers
+// which never expose the validator keys to the node
+type Signer interface {
+	// Address returns the address of the validator ECDSA key
+	Address() (types.Address, error)
+
+	// SignDigest signs the digest with the validator ECDSA key
+	SignDigest(digest []byte) ([]byte, error)
+
+	// SignWithDomain signs the digest with the validator BLS key and the provided domain
+	SignWithDomain(digest, domain []byte) ([]byte, error)
+}
+
 // SecretsManagerParams defines the configuration params for the
 // secrets manager
 type SecretsManagerParams struct {
@@ -110,5 +148,6 @@ type SecretsManagerFactory func(
 // SupportedServiceManager checks if the passed in service manager type is supported
 func SupportedServiceManager(service SecretsManagerType) bool {
 	return service == HashicorpVault || service == AWSSSM ||
-		service == Local || service == GCPSSM
+		service == Local || service == GCPSSM || service == EncryptedLocal ||
+		service == RemoteSigner
 }
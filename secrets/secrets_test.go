@@ -32,6 +32,16 @@ func TestSupportedServiceManager(t *testing.T) {
 			GCPSSM,
 			true,
 		},
+		{
+			"Valid encrypted local secrets manager",
+			EncryptedLocal,
+			true,
+		},
+		{
+			"Valid remote signer secrets manager",
+			RemoteSigner,
+			true,
+		},
 		{
 			"Invalid secrets manager",
 			"MarsSecretsManager",
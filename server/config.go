@@ -2,12 +2,15 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer"
 )
 
 const DefaultGRPCPort int = 9632
@@ -21,9 +24,11 @@ type Config struct {
 	GRPCAddr   *net.TCPAddr
 	LibP2PAddr *net.TCPAddr
 
-	PriceLimit         uint64
-	MaxAccountEnqueued uint64
-	MaxSlots           uint64
+	PriceLimit            uint64
+	MaxAccountEnqueued    uint64
+	MaxSlots              uint64
+	TxPoolJournal         bool
+	TxPoolJournalRotation time.Duration
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -44,6 +49,13 @@ type Config struct {
 	Relayer bool
 
 	NumBlockConfirmations uint64
+
+	NodeMode itrie.NodeMode
+	Pruning  *Pruning
+
+	StateSync *syncer.StateSyncConfig
+
+	StateSnapshot *StateSnapshot
 }
 
 // Telemetry holds the config details for metric services
@@ -51,10 +63,25 @@ type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
 }
 
+// Pruning holds the config details for the state pruning, used by the full and the pruned node modes.
+// The checkpoint interval is zero in the pruned mode
+type Pruning struct {
+	RetainBlocks       uint64
+	CheckpointInterval uint64
+	Interval           uint64
+}
+
+// StateSnapshot holds the config details for the flat state snapshot
+type StateSnapshot struct {
+	Enabled    bool
+	DiffLayers uint64
+}
+
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	IPCPath                  string
 }
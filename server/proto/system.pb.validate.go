@@ -360,6 +360,12 @@ func (m *Peer) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for Static
+
+	// no validation rules for Trusted
+
+	// no validation rules for Private
+
 	if len(errors) > 0 {
 		return PeerMultiError(errors)
 	}
@@ -903,6 +909,480 @@ var _ interface {
 	ErrorName() string
 } = PeersListResponseValidationError{}
 
+// Validate checks the field values on PeersBanRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersBanRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersBanRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersBanRequestMultiError, or nil if none found.
+func (m *PeersBanRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersBanRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_PeersBanRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersBanRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{1,}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Duration
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return PeersBanRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersBanRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersBanRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersBanRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersBanRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersBanRequestMultiError) AllErrors() []error { return m }
+
+// PeersBanRequestValidationError is the validation error returned by
+// PeersBanRequest.Validate if the designated constraints aren't met.
+type PeersBanRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersBanRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersBanRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersBanRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersBanRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersBanRequestValidationError) ErrorName() string {
+	return "PeersBanRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersBanRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersBanRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersBanRequestValidationError{}
+
+var _PeersBanRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]{1,}$")
+
+// Validate checks the field values on PeersUnbanRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PeersUnbanRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersUnbanRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersUnbanRequestMultiError, or nil if none found.
+func (m *PeersUnbanRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersUnbanRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_PeersUnbanRequest_Id_Pattern.MatchString(m.GetId()) {
+		err := PeersUnbanRequestValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{1,}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PeersUnbanRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersUnbanRequestMultiError is an error wrapping multiple validation errors
+// returned by PeersUnbanRequest.ValidateAll() if the designated constraints
+// aren't met.
+type PeersUnbanRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersUnbanRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersUnbanRequestMultiError) AllErrors() []error { return m }
+
+// PeersUnbanRequestValidationError is the validation error returned by
+// PeersUnbanRequest.Validate if the designated constraints aren't met.
+type PeersUnbanRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersUnbanRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersUnbanRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersUnbanRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersUnbanRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersUnbanRequestValidationError) ErrorName() string {
+	return "PeersUnbanRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersUnbanRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersUnbanRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersUnbanRequestValidationError{}
+
+var _PeersUnbanRequest_Id_Pattern = regexp.MustCompile("^[A-Za-z0-9]{1,}$")
+
+// Validate checks the field values on PeerBan with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PeerBan) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeerBan with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PeerBanMultiError, or nil if none found.
+func (m *PeerBan) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeerBan) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Expiry
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return PeerBanMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeerBanMultiError is an error wrapping multiple validation errors returned
+// by PeerBan.ValidateAll() if the designated constraints aren't met.
+type PeerBanMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeerBanMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeerBanMultiError) AllErrors() []error { return m }
+
+// PeerBanValidationError is the validation error returned by PeerBan.Validate
+// if the designated constraints aren't met.
+type PeerBanValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeerBanValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeerBanValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeerBanValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeerBanValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeerBanValidationError) ErrorName() string { return "PeerBanValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PeerBanValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeerBanValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeerBanValidationError{}
+
+// Validate checks the field values on PeersListBansResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PeersListBansResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PeersListBansResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PeersListBansResponseMultiError, or nil if none found.
+func (m *PeersListBansResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PeersListBansResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetBans() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PeersListBansResponseValidationError{
+						field:  fmt.Sprintf("Bans[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PeersListBansResponseValidationError{
+						field:  fmt.Sprintf("Bans[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PeersListBansResponseValidationError{
+					field:  fmt.Sprintf("Bans[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return PeersListBansResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PeersListBansResponseMultiError is an error wrapping multiple validation
+// errors returned by PeersListBansResponse.ValidateAll() if the designated
+// constraints aren't met.
+type PeersListBansResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PeersListBansResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PeersListBansResponseMultiError) AllErrors() []error { return m }
+
+// PeersListBansResponseValidationError is the validation error returned by
+// PeersListBansResponse.Validate if the designated constraints aren't met.
+type PeersListBansResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PeersListBansResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PeersListBansResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PeersListBansResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PeersListBansResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PeersListBansResponseValidationError) ErrorName() string {
+	return "PeersListBansResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PeersListBansResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPeersListResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PeersListBansResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PeersListBansResponseValidationError{}
+
 // Validate checks the field values on BlockByNumberRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
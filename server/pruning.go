@@ -0,0 +1,142 @@
+package server
+
+import (
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// pruningBlockchain is the blockchain interface required by the state pruner
+type pruningBlockchain interface {
+	SubscribeEvents() blockchain.Subscription
+	GetHeaderByNumber(n uint64) (*types.Header, bool)
+}
+
+// statePruner removes the states of the blocks out of the retention window,
+// periodically as the new blocks are written
+type statePruner struct {
+	logger     hclog.Logger
+	config     *Pruning
+	blockchain pruningBlockchain
+	state      *itrie.State
+
+	// running is set while the pruning is in progress
+	running int32
+	// lastPruned is the number of the block at which the last pruning was started
+	lastPruned uint64
+
+	closeCh chan struct{}
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	config *Pruning,
+	blockchain pruningBlockchain,
+	state *itrie.State,
+) *statePruner {
+	return &statePruner{
+		logger:     logger.Named("state_pruner"),
+		config:     config,
+		blockchain: blockchain,
+		state:      state,
+		closeCh:    make(chan struct{}),
+	}
+}
+
+// start runs the loop which prunes the state every configured number of blocks
+func (p *statePruner) start() {
+	sub := p.blockchain.SubscribeEvents()
+
+	go func() {
+		defer sub.Close()
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case ev := <-eventCh:
+				if ev == nil || len(ev.NewChain) == 0 {
+					continue
+				}
+
+				p.onNewHead(ev.Header().Number)
+			}
+		}
+	}()
+}
+
+// close stops the pruning loop, the running pruning is finished in the background
+func (p *statePruner) close() {
+	close(p.closeCh)
+}
+
+// onNewHead starts the pruning in the background once the interval since the last pruning is passed,
+// the new head is ignored if the previous pruning is still running
+func (p *statePruner) onNewHead(number uint64) {
+	if number < p.lastPruned+p.config.Interval || number <= p.config.RetainBlocks {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return
+	}
+
+	p.lastPruned = number
+
+	go func() {
+		defer atomic.StoreInt32(&p.running, 0)
+
+		p.prune(number)
+	}()
+}
+
+// isStateAvailable checks if the state of the block is kept by the pruning, for the given head block
+func (c *Pruning) isStateAvailable(number, head uint64) bool {
+	if number == 0 || number+c.RetainBlocks >= head {
+		return true
+	}
+
+	return c.CheckpointInterval > 0 && number%c.CheckpointInterval == 0
+}
+
+// prune removes all the states except the genesis one, the ones of the last retained blocks
+// and the ones of the checkpoint blocks
+func (p *statePruner) prune(head uint64) {
+	roots := []types.Hash{}
+
+	addRoot := func(number uint64) {
+		if header, ok := p.blockchain.GetHeaderByNumber(number); ok {
+			roots = append(roots, header.StateRoot)
+		}
+	}
+
+	addRoot(0)
+
+	for number := head - p.config.RetainBlocks; number <= head; number++ {
+		addRoot(number)
+	}
+
+	if p.config.CheckpointInterval > 0 {
+		for number := p.config.CheckpointInterval; number < head-p.config.RetainBlocks; number +=
+			p.config.CheckpointInterval {
+			addRoot(number)
+		}
+	}
+
+	p.logger.Info("pruning the state", "head", head, "retained", len(roots))
+
+	pruned, err := p.state.Prune(roots)
+	if err != nil {
+		p.logger.Error("failed to prune the state", "head", head, "err", err)
+
+		return
+	}
+
+	p.logger.Info("state pruned", "head", head, "pruned nodes", pruned)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
 	"net/http"
@@ -94,6 +95,9 @@ type Server struct {
 
 	// gasHelper is providing functions regarding gas and fees
 	gasHelper *gasprice.GasHelper
+
+	// statePruner is removing the states out of the retention window
+	statePruner *statePruner
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -205,7 +209,22 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.stateStorage = stateStorage
 
-	st := itrie.NewState(stateStorage)
+	// the state storage refuses the archive mode once its states were pruned
+	if err := itrie.SetNodeMode(stateStorage, config.NodeMode); err != nil {
+		return nil, err
+	}
+
+	if config.NodeMode.IsPruned() {
+		prunableStorage, ok := stateStorage.(itrie.PrunableStorage)
+		if !ok {
+			return nil, itrie.ErrPruningNotSupported
+		}
+
+		// the pruning storage tracks the written nodes, so the pruning doesn't remove the ones of the new states
+		m.stateStorage = itrie.NewPruningStorage(prunableStorage, logger)
+	}
+
+	st := itrie.NewState(m.stateStorage)
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
@@ -299,13 +318,18 @@ func NewServer(config *Config) (*Server, error) {
 	// compute the genesis root state
 	config.Chain.Genesis.StateRoot = genesisRoot
 
-	// Use the london signer with eip-155 as a fallback one
+	// Use the london signer with berlin and eip-155 as fallback ones
 	var signer crypto.TxSigner = crypto.NewLondonSigner(
 		uint64(m.config.Chain.Params.ChainID),
 		config.Chain.Params.Forks.IsActive(chain.Homestead, 0),
-		crypto.NewEIP155Signer(
+		config.Chain.Params.Forks.IsActive(chain.Berlin, 0),
+		crypto.NewBerlinSigner(
 			uint64(m.config.Chain.Params.ChainID),
 			config.Chain.Params.Forks.IsActive(chain.Homestead, 0),
+			crypto.NewEIP155Signer(
+				uint64(m.config.Chain.Params.ChainID),
+				config.Chain.Params.Forks.IsActive(chain.Homestead, 0),
+			),
 		),
 	)
 
@@ -350,6 +374,12 @@ func NewServer(config *Config) (*Server, error) {
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
 	{
+		// the journal of the local transactions is kept in the data directory
+		journalPath := ""
+		if m.config.TxPoolJournal {
+			journalPath = filepath.Join(m.config.DataDir, "txpool", "journal")
+		}
+
 		hub := &txpoolHub{
 			state:      m.state,
 			Blockchain: m.blockchain,
@@ -366,6 +396,8 @@ func NewServer(config *Config) (*Server, error) {
 				MaxSlots:           m.config.MaxSlots,
 				PriceLimit:         m.config.PriceLimit,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
+				JournalPath:        journalPath,
+				JournalRotation:    m.config.TxPoolJournalRotation,
 			},
 		)
 		if err != nil {
@@ -390,6 +422,23 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if config.StateSnapshot != nil && config.StateSnapshot.Enabled {
+		// the flat snapshot is written to the underlying storage, so it isn't tracked by the state pruning
+		snapshotStorage, ok := stateStorage.(itrie.SnapshotStorage)
+		if !ok {
+			return nil, itrie.ErrFlatSnapshotNotSupported
+		}
+
+		if err := st.EnableFlatSnapshot(
+			snapshotStorage,
+			m.blockchain.Header().StateRoot,
+			int(config.StateSnapshot.DiffLayers),
+			logger,
+		); err != nil {
+			return nil, err
+		}
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -428,6 +477,11 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.txpool.Start()
 
+	if config.NodeMode.IsPruned() {
+		m.statePruner = newStatePruner(logger, config.Pruning, m.blockchain, st)
+		m.statePruner.start()
+	}
+
 	return m, nil
 }
 
@@ -466,6 +520,10 @@ type txpoolHub struct {
 func getAccountImpl(state state.State, root types.Hash, addr types.Address) (*state.Account, error) {
 	snap, err := state.NewSnapshotAt(root)
 	if err != nil {
+		if errors.Is(err, itrie.ErrStateNotFound) {
+			return nil, fmt.Errorf("%w: %s", jsonrpc.ErrStateNotAvailable, err.Error())
+		}
+
 		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
 	}
 
@@ -520,9 +578,12 @@ func (s *Server) setupSecretsManager() error {
 		Logger: s.logger,
 	}
 
-	if secretsManagerType == secrets.Local {
+	if secretsManagerType == secrets.Local || secretsManagerType == secrets.EncryptedLocal ||
+		secretsManagerType == secrets.RemoteSigner {
 		// Only the base directory is required for
-		// the local secrets manager
+		// the local secrets managers, the password of the
+		// encrypted one is resolved from its config, and
+		// the remote signer keeps the networking key there
 		secretsManagerParams.Extra = map[string]interface{}{
 			secrets.Path: s.config.DataDir,
 		}
@@ -594,6 +655,8 @@ func (s *Server) setupConsensus() error {
 			SecretsManager:        s.secretsManager,
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
+			StateStorage:          s.stateStorage,
+			StateSync:             s.config.StateSync,
 		},
 	)
 
@@ -634,7 +697,7 @@ func extractBlockTime(engineConfig map[string]interface{}) (common.Duration, err
 
 // setupRelayer sets up the relayer
 func (s *Server) setupRelayer() error {
-	account, err := wallet.NewAccountFromSecret(s.secretsManager)
+	key, err := wallet.NewKeyFromSecret(s.secretsManager)
 	if err != nil {
 		return fmt.Errorf("failed to create account from secret: %w", err)
 	}
@@ -655,7 +718,7 @@ func (s *Server) setupRelayer() error {
 		ethgo.Address(contracts.StateReceiverContract),
 		trackerStartBlockConfig[contracts.StateReceiverContract],
 		s.logger.Named("relayer"),
-		wallet.NewEcdsaSigner(wallet.NewKey(account)),
+		wallet.NewEcdsaSigner(key),
 	)
 
 	// start relayer
@@ -669,6 +732,9 @@ func (s *Server) setupRelayer() error {
 type jsonRPCHub struct {
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
+	stateStorage       itrie.Storage
+	nodeMode           itrie.NodeMode
+	pruning            *Pruning
 
 	*blockchain.Blockchain
 	*txpool.TxPool
@@ -697,6 +763,21 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.A
 	return account, nil
 }
 
+// IsStateAvailable checks if the node keeps the state of the block, according to its node mode
+// and the lowest block whose state was imported
+func (j *jsonRPCHub) IsStateAvailable(header *types.Header) bool {
+	// the blocks before the state synced from the peers were never executed by the node
+	if header.Number != 0 && header.Number < itrie.GetLowestState(j.stateStorage) {
+		return false
+	}
+
+	if !j.nodeMode.IsPruned() {
+		return true
+	}
+
+	return j.pruning.isStateAvailable(header.Number, j.Header().Number)
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return j.Executor.GetForksInTime(blockNumber)
@@ -732,11 +813,89 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the Merkle proof of the account and of the storage slots at the given state root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	keys []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	prover, ok := snap.(state.Prover)
+	if !ok {
+		return nil, errors.New("state does not support proofs")
+	}
+
+	accountProof, err := prover.GetAccountProof(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		// the proof of the absence of the account
+		account = &state.Account{
+			Balance:  big.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash.Bytes(),
+		}
+	}
+
+	res := &jsonrpc.AccountProof{
+		Balance:      account.Balance,
+		Nonce:        account.Nonce,
+		CodeHash:     types.BytesToHash(account.CodeHash),
+		StorageHash:  account.Root,
+		Proof:        accountProof,
+		StorageProof: make([]jsonrpc.StorageProof, len(keys)),
+	}
+
+	for i, key := range keys {
+		storageProof, err := prover.GetStorageProof(account.Root, key)
+		if err != nil {
+			return nil, err
+		}
+
+		res.StorageProof[i] = jsonrpc.StorageProof{
+			Key:   key,
+			Value: snap.GetStorage(addr, account.Root, key).Bytes(),
+			Proof: storageProof,
+		}
+	}
+
+	return res, nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
 	override types.StateOverride,
+	blockOverride *types.BlockOverride,
 ) (result *runtime.ExecutionResult, err error) {
+	transition, err := j.beginCallTxn(header, override, blockOverride)
+	if err != nil {
+		return
+	}
+
+	result, err = transition.Apply(txn)
+
+	return
+}
+
+// beginCallTxn creates the transition on top of the given header for the calls which are not part of the chain,
+// applying the overrides of the state and of the block context (if any)
+func (j *jsonRPCHub) beginCallTxn(
+	header *types.Header,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*state.Transition, error) {
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
 	if err != nil {
 		return nil, err
@@ -744,18 +903,66 @@ func (j *jsonRPCHub) ApplyTxn(
 
 	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
 	if err != nil {
-		return
+		return nil, err
 	}
 
 	if override != nil {
 		if err = transition.WithStateOverride(override); err != nil {
-			return
+			return nil, err
 		}
 	}
 
-	result, err = transition.Apply(txn)
+	if blockOverride != nil {
+		transition.WithBlockOverride(blockOverride)
+	}
 
-	return
+	return transition, nil
+}
+
+// ApplyBundle applies the pending transactions (if any) and then the bundle of transactions
+// one after another on top of the given header. Nothing is persisted
+func (j *jsonRPCHub) ApplyBundle(
+	header *types.Header,
+	pendingTxs []*types.Transaction,
+	txns []*types.Transaction,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
+) (*jsonrpc.BundleResult, error) {
+	transition, err := j.beginCallTxn(header, override, blockOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	// the simulated transactions are limited only by their own gas
+	transition.WithGasPool(math.MaxUint64)
+
+	bundleResult := &jsonrpc.BundleResult{
+		Txns: make([]*jsonrpc.BundleTxnResult, len(txns)),
+	}
+
+	for _, tx := range pendingTxs {
+		// the pending transactions which can't be applied are skipped, as in the block building
+		if _, _, err := transition.Simulate(tx); err != nil {
+			bundleResult.SkippedPendingTxns = append(bundleResult.SkippedPendingTxns, &jsonrpc.BundleSkippedTxn{
+				Hash: tx.Hash,
+				Err:  err,
+			})
+		}
+	}
+
+	for idx, tx := range txns {
+		result, receipt, err := transition.Simulate(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply the transaction %d of the bundle: %w", idx, err)
+		}
+
+		bundleResult.Txns[idx] = &jsonrpc.BundleTxnResult{
+			Result:  result,
+			Receipt: receipt,
+		}
+	}
+
+	return bundleResult, nil
 }
 
 // TraceBlock traces all transactions in the given block and returns all results
@@ -857,14 +1064,11 @@ func (j *jsonRPCHub) TraceTxn(
 func (j *jsonRPCHub) TraceCall(
 	tx *types.Transaction,
 	parentHeader *types.Header,
+	override types.StateOverride,
+	blockOverride *types.BlockOverride,
 	tracer tracer.Tracer,
 ) (interface{}, error) {
-	blockCreator, err := j.GetConsensus().GetBlockCreator(parentHeader)
-	if err != nil {
-		return nil, err
-	}
-
-	transition, err := j.BeginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
+	transition, err := j.beginCallTxn(parentHeader, override, blockOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -878,6 +1082,27 @@ func (j *jsonRPCHub) TraceCall(
 	return tracer.GetResult()
 }
 
+// GetCheckpointedBlockNumber returns the number of the latest block checkpointed to the rootchain,
+// if the consensus checkpoints the blocks
+func (j *jsonRPCHub) GetCheckpointedBlockNumber() (uint64, error) {
+	if j.BridgeDataProvider == nil {
+		return 0, jsonrpc.ErrCheckpointsNotSupported
+	}
+
+	return j.BridgeDataProvider.GetCheckpointedBlockNumber()
+}
+
+// GetValidatorsLiveness returns the liveness of the validators over the latest tracked blocks,
+// if the consensus tracks the liveness of the validators
+func (j *jsonRPCHub) GetValidatorsLiveness() (*consensus.ValidatorsLiveness, error) {
+	provider, ok := j.Consensus.(consensus.LivenessDataProvider)
+	if !ok {
+		return nil, jsonrpc.ErrLivenessNotSupported
+	}
+
+	return provider.GetValidatorsLiveness()
+}
+
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 	// restore progression
 	if restoreProg := j.restoreProgression.GetProgression(); restoreProg != nil {
@@ -899,6 +1124,9 @@ func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
+		stateStorage:       s.stateStorage,
+		nodeMode:           s.config.NodeMode,
+		pruning:            s.config.Pruning,
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
@@ -917,6 +1145,7 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		IPCPath:                  s.config.JSONRPC.IPCPath,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -962,6 +1191,11 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Stop the state pruning
+	if s.statePruner != nil {
+		s.statePruner.close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -977,6 +1211,13 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Write the flat state snapshot to the disk, so it isn't rebuilt on the next start
+	if st, ok := s.state.(*itrie.State); ok {
+		if err := st.FlushFlatSnapshot(s.blockchain.Header().StateRoot); err != nil {
+			s.logger.Error("failed to flush flat state snapshot", "err", err.Error())
+		}
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -993,6 +1234,13 @@ func (s *Server) Close() {
 		s.stateSyncRelayer.Stop()
 	}
 
+	// Close the JSON-RPC IPC server
+	if s.jsonrpcServer != nil {
+		if err := s.jsonrpcServer.Close(); err != nil {
+			s.logger.Error("failed to close JSON-RPC IPC server", "err", err.Error())
+		}
+	}
+
 	// Close the txpool's main loop
 	s.txpool.Close()
 
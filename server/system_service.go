@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -134,6 +136,9 @@ func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 		Id:        id.String(),
 		Protocols: protocols,
 		Addrs:     addrs,
+		Static:    s.server.network.IsStaticPeer(id),
+		Trusted:   s.server.network.IsTrustedPeer(id),
+		Private:   s.server.network.IsPrivatePeer(id),
 	}
 
 	return peer, nil
@@ -161,6 +166,59 @@ func (s *systemService) PeersList(
 	return resp, nil
 }
 
+// PeersBan implements the 'peers ban' operator service
+func (s *systemService) PeersBan(_ context.Context, req *proto.PeersBanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := network.DefaultBanDuration
+	if req.Duration > 0 {
+		duration = time.Duration(req.Duration) * time.Second
+	}
+
+	if err := s.server.network.BanPeer(peerID, duration, req.Reason); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersUnban implements the 'peers unban' operator service
+func (s *systemService) PeersUnban(_ context.Context, req *proto.PeersUnbanRequest) (*empty.Empty, error) {
+	peerID, err := peer.Decode(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.server.network.UnbanPeer(peerID); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// PeersListBans implements the 'peers list-bans' operator service
+func (s *systemService) PeersListBans(
+	_ context.Context,
+	_ *empty.Empty,
+) (*proto.PeersListBansResponse, error) {
+	resp := &proto.PeersListBansResponse{
+		Bans: []*proto.PeerBan{},
+	}
+
+	for _, ban := range s.server.network.ListBans() {
+		resp.Bans = append(resp.Bans, &proto.PeerBan{
+			Id:     ban.ID.String(),
+			Expiry: ban.Expiry.Unix(),
+			Reason: ban.Reason,
+		})
+	}
+
+	return resp, nil
+}
+
 // BlockByNumber implements the BlockByNumber operator service
 func (s *systemService) BlockByNumber(
 	ctx context.Context,
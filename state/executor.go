@@ -22,10 +22,16 @@ import (
 
 const (
 	SpuriousDragonMaxCodeSize = 24576
-	TxPoolMaxInitCodeSize     = 2 * SpuriousDragonMaxCodeSize
+	MaxInitCodeSize           = 2 * SpuriousDragonMaxCodeSize
+	TxPoolMaxInitCodeSize     = MaxInitCodeSize
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP 2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP 2930 access list
+
+	TxInitCodeWordGas uint64 = 2 // Per word of the init code of a contract creation transaction (EIP 3860)
 )
 
 // GetHashByNumber returns the hash function of a block number
@@ -320,6 +326,36 @@ func (t *Transition) WithStateOverride(override types.StateOverride) error {
 	return nil
 }
 
+// WithBlockOverride replaces the fields of the block context of the transition
+func (t *Transition) WithBlockOverride(override *types.BlockOverride) {
+	if override.Number != nil {
+		t.ctx.Number = int64(*override.Number)
+	}
+
+	if override.Timestamp != nil {
+		t.ctx.Timestamp = int64(*override.Timestamp)
+	}
+
+	if override.Coinbase != nil {
+		t.ctx.Coinbase = *override.Coinbase
+	}
+
+	if override.GasLimit != nil {
+		t.ctx.GasLimit = int64(*override.GasLimit)
+		t.gasPool = *override.GasLimit
+	}
+
+	if override.BaseFee != nil {
+		t.ctx.BaseFee = new(big.Int).Set(override.BaseFee)
+	}
+}
+
+// WithGasPool sets the amount of gas available for the transactions of the transition,
+// regardless of the gas limit of the block
+func (t *Transition) WithGasPool(gas uint64) {
+	t.gasPool = gas
+}
+
 func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
@@ -335,7 +371,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	var err error
 
 	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+		(txn.Type == types.LegacyTx || txn.Type == types.AccessListTx || txn.Type == types.DynamicFeeTx) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -345,14 +381,29 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
+	if _, _, err = t.write(txn); err != nil {
+		t.logger.Error("failed to apply tx", "err", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Simulate writes the transaction with the already set sender, without recovering it from the signature,
+// and returns the result of the execution along with the receipt
+func (t *Transition) Simulate(txn *types.Transaction) (*runtime.ExecutionResult, *types.Receipt, error) {
+	return t.write(txn)
+}
+
+// write applies the transaction and records its receipt
+func (t *Transition) write(txn *types.Transaction) (*runtime.ExecutionResult, *types.Receipt, error) {
 	// Make a local copy and apply the transaction
 	msg := txn.Copy()
 
-	result, e := t.Apply(msg)
-	if e != nil {
-		t.logger.Error("failed to apply tx", "err", e)
-
-		return e
+	result, err := t.Apply(msg)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	t.totalGas += result.GasUsed
@@ -368,7 +419,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 
 	// The suicided accounts are set as deleted for the next iteration
 	if err := t.state.CleanDeleteObjects(true); err != nil {
-		return fmt.Errorf("failed to clean deleted objects: %w", err)
+		return nil, nil, fmt.Errorf("failed to clean deleted objects: %w", err)
 	}
 
 	if result.Failed() {
@@ -387,7 +438,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	receipt.LogsBloom = types.CreateBloom([]*types.Receipt{receipt})
 	t.receipts = append(t.receipts, receipt)
 
-	return nil
+	return result, receipt, nil
 }
 
 // Commit commits the final result
@@ -571,6 +622,10 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	if t.ctx.Tracer != nil {
+		t.ctx.Tracer.TxStart(msg, t.ctx.Coinbase, t)
+	}
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -586,12 +641,9 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
 
-	if t.ctx.Tracer != nil {
-		t.ctx.Tracer.TxStart(msg.Gas)
-	}
-
 	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	intrinsicGasCost, err := TransactionGasCost(
+		msg, t.config.Homestead, t.config.Istanbul, t.config.Shanghai, t.config.Berlin)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
@@ -610,6 +662,21 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
 
+	// the init code of the contract creation transaction is limited in size (EIP-3860)
+	if t.config.Shanghai && msg.IsContractCreation() && len(msg.Input) > MaxInitCodeSize {
+		return nil, NewTransitionApplicationError(runtime.ErrMaxInitCodeSizeExceeded, false)
+	}
+
+	// initialize the access list (EIP-2929, EIP-2930)
+	if t.config.Berlin {
+		t.prepareAccessList(msg)
+	}
+
+	// transient storage is scoped to the transaction (EIP-1153)
+	if t.config.Cancun {
+		t.state.ClearTransientStorage()
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -621,10 +688,6 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	refund := t.state.GetRefund()
 	result.UpdateGasUsed(msg.Gas, refund)
 
-	if t.ctx.Tracer != nil {
-		t.ctx.Tracer.TxEnd(result.GasLeft)
-	}
-
 	// Refund the sender
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
 	t.state.AddBalance(msg.From, remaining)
@@ -655,9 +718,42 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
+	if t.ctx.Tracer != nil {
+		t.ctx.Tracer.TxEnd(result.GasLeft)
+	}
+
 	return result, nil
 }
 
+// prepareAccessList resets the access list and warms up the sender, the destination,
+// the precompiles and the entries of the transaction access list
+func (t *Transition) prepareAccessList(msg *types.Transaction) {
+	t.state.ClearAccessList()
+
+	t.state.AddAddressToAccessList(msg.From)
+
+	if msg.To != nil {
+		t.state.AddAddressToAccessList(*msg.To)
+	}
+
+	for _, addr := range t.precompiles.Addrs(&t.config) {
+		t.state.AddAddressToAccessList(addr)
+	}
+
+	// the coinbase is warm from the start of the transaction (EIP-3651)
+	if t.config.Shanghai {
+		t.state.AddAddressToAccessList(t.ctx.Coinbase)
+	}
+
+	for _, el := range msg.AccessList {
+		t.state.AddAddressToAccessList(el.Address)
+
+		for _, key := range el.StorageKeys {
+			t.state.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -808,7 +904,7 @@ func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	return codeHash != types.EmptyCodeHash && codeHash != types.ZeroHash
 }
 
-func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) (result *runtime.ExecutionResult) {
 	gasLimit := c.Gas
 
 	if c.Depth > int(1024)+1 {
@@ -821,6 +917,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// The created address is accessed even if the creation fails (EIP-2929)
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -846,9 +947,12 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
-	var result *runtime.ExecutionResult
+	callType := runtime.Create
+	if c.Type == runtime.Create2 {
+		callType = runtime.Create2
+	}
 
-	t.captureCallStart(c, evm.CREATE)
+	t.captureCallStart(c, callType)
 
 	defer func() {
 		// pass result to be set later
@@ -1042,7 +1146,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -1094,7 +1198,31 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.state.AddressInAccessList(addr)
+}
+
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.SlotInAccessList(addr, slot)
+}
+
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
+func (t *Transition) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isShanghai, isBerlin bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -1132,6 +1260,29 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		}
 
 		cost += zeros * 4
+
+		// The init code is charged per word (EIP-3860)
+		if msg.IsContractCreation() && isShanghai {
+			words := (uint64(len(payload)) + 31) / 32
+
+			if (math.MaxUint64-cost)/TxInitCodeWordGas < words {
+				return 0, ErrIntrinsicGasOverflow
+			}
+
+			cost += words * TxInitCodeWordGas
+		}
+	}
+
+	// Access list entries are paid upfront (EIP-2930), the access list is ignored before Berlin
+	if isBerlin && len(msg.AccessList) > 0 {
+		addressesCost := uint64(len(msg.AccessList)) * TxAccessListAddressGas
+		storageKeysCost := uint64(msg.AccessList.StorageKeys()) * TxAccessListStorageKeyGas
+
+		if math.MaxUint64-cost < addressesCost+storageKeysCost {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += addressesCost + storageKeysCost
 	}
 
 	return cost, nil
@@ -1215,9 +1366,15 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 		return
 	}
 
+	gasUsed := uint64(0)
+	if c.Gas > result.GasLeft {
+		gasUsed = c.Gas - result.GasLeft
+	}
+
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
+		gasUsed,
 		result.Err,
 	)
 }
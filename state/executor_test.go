@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -66,6 +68,44 @@ func TestOverride(t *testing.T) {
 	require.Equal(t, types.Hash{0x1}, tt.state.GetState(types.Address{0x1}, types.Hash{0x1}))
 }
 
+func TestBlockOverride(t *testing.T) {
+	t.Parallel()
+
+	var (
+		number    = uint64(10)
+		timestamp = uint64(1000)
+		coinbase  = types.StringToAddress("1")
+		gasLimit  = uint64(5000000)
+		baseFee   = big.NewInt(7)
+	)
+
+	state := newStateWithPreState(nil)
+
+	tt := NewTransition(chain.ForksInTime{}, state, newTxn(state))
+	tt.ctx = runtime.TxContext{Number: 1, Timestamp: 1, GasLimit: 1, BaseFee: big.NewInt(1)}
+
+	tt.WithBlockOverride(&types.BlockOverride{
+		Number:    &number,
+		Timestamp: &timestamp,
+		Coinbase:  &coinbase,
+		GasLimit:  &gasLimit,
+		BaseFee:   baseFee,
+	})
+
+	require.Equal(t, int64(number), tt.ctx.Number)
+	require.Equal(t, int64(timestamp), tt.ctx.Timestamp)
+	require.Equal(t, coinbase, tt.ctx.Coinbase)
+	require.Equal(t, int64(gasLimit), tt.ctx.GasLimit)
+	require.Equal(t, gasLimit, tt.gasPool)
+	require.Equal(t, baseFee, tt.ctx.BaseFee)
+
+	// the fields which are not overridden are kept
+	tt.WithBlockOverride(&types.BlockOverride{})
+
+	require.Equal(t, int64(number), tt.ctx.Number)
+	require.Equal(t, coinbase, tt.ctx.Coinbase)
+}
+
 func Test_Transition_checkDynamicFees(t *testing.T) {
 	t.Parallel()
 
@@ -154,3 +194,207 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGasCost_AccessList(t *testing.T) {
+	t.Parallel()
+
+	to := types.StringToAddress("1")
+	tx := &types.Transaction{
+		To: &to,
+		AccessList: types.TxAccessList{
+			{
+				Address:     types.StringToAddress("2"),
+				StorageKeys: []types.Hash{types.StringToHash("1"), types.StringToHash("2")},
+			},
+			{
+				Address: types.StringToAddress("3"),
+			},
+		},
+	}
+
+	cost, err := TransactionGasCost(tx, true, true, true, true)
+	require.NoError(t, err)
+	require.Equal(t, TxGas+2*TxAccessListAddressGas+2*TxAccessListStorageKeyGas, cost)
+
+	// the access list is not paid before Berlin
+	cost, err = TransactionGasCost(tx, true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGas, cost)
+}
+
+func TestTransactionGasCost_InitCode(t *testing.T) {
+	t.Parallel()
+
+	// 33 non zero bytes take two words
+	tx := &types.Transaction{
+		Input: bytes.Repeat([]byte{1}, 33),
+	}
+
+	cost, err := TransactionGasCost(tx, true, true, false, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGasContractCreation+33*16, cost)
+
+	cost, err = TransactionGasCost(tx, true, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGasContractCreation+33*16+2*TxInitCodeWordGas, cost)
+}
+
+func TestTransition_MaxInitCodeSize(t *testing.T) {
+	t.Parallel()
+
+	from := types.StringToAddress("1")
+	tx := &types.Transaction{
+		From:     from,
+		Gas:      10_000_000,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+		Input:    make([]byte, MaxInitCodeSize+1),
+	}
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		from: {Balance: 100_000_000},
+	})
+
+	tt := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+	tt.ctx.BaseFee = big.NewInt(0)
+	tt.gasPool = tx.Gas
+
+	_, err := tt.Apply(tx)
+
+	var appErr *TransitionApplicationError
+
+	require.ErrorAs(t, err, &appErr)
+	require.ErrorIs(t, appErr.Err, runtime.ErrMaxInitCodeSizeExceeded)
+}
+
+func TestTransition_Simulate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from = types.StringToAddress("1")
+		to   = types.StringToAddress("1000")
+	)
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		from: {Balance: 1_000_000},
+	})
+
+	tt := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+	tt.ctx.BaseFee = big.NewInt(0)
+	tt.WithGasPool(2 * TxGas)
+
+	// the transactions follow each other, the second one requires the nonce incremented by the first one
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		result, receipt, err := tt.Simulate(&types.Transaction{
+			From:     from,
+			To:       &to,
+			Nonce:    nonce,
+			Gas:      TxGas,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(10),
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+		require.Equal(t, types.ReceiptSuccess, *receipt.Status)
+		require.Equal(t, TxGas, receipt.GasUsed)
+		require.Equal(t, TxGas*(nonce+1), receipt.CumulativeGasUsed)
+	}
+
+	require.Len(t, tt.Receipts(), 2)
+	require.Equal(t, big.NewInt(20), tt.GetBalance(to))
+	require.Equal(t, uint64(2), tt.GetNonce(from))
+}
+
+func TestTransition_PrepareAccessList(t *testing.T) {
+	t.Parallel()
+
+	from := types.StringToAddress("1")
+	to := types.StringToAddress("2")
+	listed := types.StringToAddress("3")
+	slot := types.StringToHash("4")
+
+	coinbase := types.StringToAddress("0xc0ffee")
+
+	state := newStateWithPreState(nil)
+	tt := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+	tt.ctx.Coinbase = coinbase
+
+	tt.state.AddAddressToAccessList(types.StringToAddress("0xabcd"))
+
+	tt.prepareAccessList(&types.Transaction{
+		From: from,
+		To:   &to,
+		AccessList: types.TxAccessList{
+			{Address: listed, StorageKeys: []types.Hash{slot}},
+		},
+	})
+
+	// access list of the previous transaction is dropped
+	require.False(t, tt.state.AddressInAccessList(types.StringToAddress("0xabcd")))
+
+	require.True(t, tt.state.AddressInAccessList(from))
+	require.True(t, tt.state.AddressInAccessList(to))
+	require.True(t, tt.state.AddressInAccessList(types.StringToAddress("1")))
+	require.True(t, tt.state.AddressInAccessList(types.StringToAddress("9")))
+	require.True(t, tt.state.AddressInAccessList(coinbase))
+
+	addressOk, slotOk := tt.state.SlotInAccessList(listed, slot)
+	require.True(t, addressOk)
+	require.True(t, slotOk)
+
+	addressOk, slotOk = tt.state.SlotInAccessList(to, slot)
+	require.True(t, addressOk)
+	require.False(t, slotOk)
+}
+
+func TestTransition_Write_PreBerlinAccessList(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 100
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	from := crypto.PubKeyToAddress(&key.PublicKey)
+	to := types.StringToAddress("1000")
+
+	forks := chain.AllForksEnabled.At(0)
+	forks.Berlin = false
+
+	// the access list of the dynamic fee transaction is ignored before Berlin,
+	// so the transaction is signed without it
+	tx, err := crypto.NewSigner(forks, chainID).SignTx(&types.Transaction{
+		Type:      types.DynamicFeeTx,
+		To:        &to,
+		Gas:       TxGas,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Value:     big.NewInt(1),
+		AccessList: types.TxAccessList{
+			{Address: types.StringToAddress("2"), StorageKeys: []types.Hash{types.StringToHash("1")}},
+		},
+	}, key)
+	require.NoError(t, err)
+
+	noAccessListTx := tx.Copy()
+	noAccessListTx.AccessList = nil
+	require.Equal(t,
+		crypto.NewSigner(forks, chainID).Hash(noAccessListTx),
+		crypto.NewSigner(forks, chainID).Hash(tx))
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		from: {Balance: 1_000_000},
+	})
+
+	tt := NewTransition(forks, state, newTxn(state))
+	tt.ctx.ChainID = chainID
+	tt.ctx.BaseFee = big.NewInt(0)
+	tt.WithGasPool(TxGas)
+
+	// the sender is recovered and the access list is not paid
+	require.NoError(t, tt.Write(tx))
+	require.Len(t, tt.Receipts(), 1)
+	require.Equal(t, types.ReceiptSuccess, *tt.Receipts()[0].Status)
+	require.Equal(t, TxGas, tt.Receipts()[0].GasUsed)
+	require.Equal(t, uint64(1), tt.state.GetNonce(from))
+}
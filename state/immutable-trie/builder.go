@@ -0,0 +1,69 @@
+package itrie
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// builderFlushThreshold is the number of the leaves inserted before the nodes are written to the storage
+const builderFlushThreshold = 10000
+
+// TrieBuilder builds the trie from its leaves and writes its nodes to the storage.
+// The nodes are flushed periodically so the whole trie is never kept in the memory,
+// it's most efficient when the leaves are inserted in the ascending order of the keys
+type TrieBuilder struct {
+	storage Storage
+	txn     *Txn
+	pending int
+}
+
+// NewTrieBuilder creates the builder of a new trie
+func NewTrieBuilder(storage Storage) *TrieBuilder {
+	return &TrieBuilder{
+		storage: storage,
+		txn:     NewTrie().Txn(storage),
+	}
+}
+
+// Insert adds the leaf to the trie
+func (b *TrieBuilder) Insert(key, value []byte) error {
+	b.txn.Insert(key, value)
+	b.pending++
+
+	if b.pending < builderFlushThreshold {
+		return nil
+	}
+
+	_, err := b.flush()
+
+	return err
+}
+
+// Commit writes the remaining nodes of the trie and returns its root
+func (b *TrieBuilder) Commit() (types.Hash, error) {
+	if b.txn.root == nil {
+		return types.EmptyRootHash, nil
+	}
+
+	return b.flush()
+}
+
+// flush writes the nodes of the trie to the storage and replaces the trie in the memory
+// with the reference to its root
+func (b *TrieBuilder) flush() (types.Hash, error) {
+	batch := b.storage.Batch()
+	b.txn.batch = batch
+
+	root, err := b.txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return types.ZeroHash, err
+	}
+
+	b.txn = NewTrieWithRoot(&ValueNode{hash: true, buf: root}).Txn(b.storage)
+	b.pending = 0
+
+	return types.BytesToHash(root), nil
+}
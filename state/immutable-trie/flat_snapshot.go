@@ -0,0 +1,534 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// DefaultFlatSnapshotDiffLayers is the default number of the diff layers kept in the memory
+	// on top of the disk layer of the flat snapshot
+	DefaultFlatSnapshotDiffLayers = 128
+
+	// flatSnapshotBatchSize is the number of the entries written or removed at once
+	flatSnapshotBatchSize = 1024
+)
+
+var (
+	// snapshotAccountPrefix is the prefix of the flat accounts, keyed by the hash of the address
+	snapshotAccountPrefix = []byte("sa")
+	// snapshotStoragePrefix is the prefix of the flat storage slots, keyed by the hash of the address
+	// and the hash of the slot
+	snapshotStoragePrefix = []byte("ss")
+	// snapshotRootKey is the key of the state root of the flat snapshot persisted on the disk
+	snapshotRootKey = []byte("snapshot-root")
+
+	// ErrFlatSnapshotNotSupported is returned when the storage of the state can't keep the flat snapshot
+	ErrFlatSnapshotNotSupported = errors.New("state storage doesn't support flat snapshot")
+)
+
+// SnapshotStorage is the storage which keeps the flat snapshot of the state next to the trie nodes
+type SnapshotStorage interface {
+	Storage
+
+	// IteratePrefix calls the handler with each key starting with the prefix and its value
+	IteratePrefix(prefix []byte, handler func(key, value []byte) error) error
+	// Delete removes the given keys
+	Delete(keys [][]byte) error
+}
+
+func snapshotAccountKey(accountHash types.Hash) []byte {
+	return concat(snapshotAccountPrefix, accountHash.Bytes())
+}
+
+func snapshotStorageKey(accountHash, slotHash types.Hash) []byte {
+	return concat(concat(snapshotStoragePrefix, accountHash.Bytes()), slotHash.Bytes())
+}
+
+// flatDiff contains the changes of the state made by a commit, the values are encoded as in the trie
+// and the nil value means the removed entry
+type flatDiff struct {
+	accounts map[types.Hash][]byte
+	storage  map[types.Hash]map[types.Hash][]byte
+	// destructs are the accounts whose previous storage is wiped
+	destructs map[types.Hash]struct{}
+}
+
+func newFlatDiff() *flatDiff {
+	return &flatDiff{
+		accounts:  map[types.Hash][]byte{},
+		storage:   map[types.Hash]map[types.Hash][]byte{},
+		destructs: map[types.Hash]struct{}{},
+	}
+}
+
+func (d *flatDiff) setStorage(accountHash, slotHash types.Hash, value []byte) {
+	slots, ok := d.storage[accountHash]
+	if !ok {
+		slots = map[types.Hash][]byte{}
+		d.storage[accountHash] = slots
+	}
+
+	slots[slotHash] = value
+}
+
+// diffLayer is the flat state of a commit kept in the memory on top of its parent layer,
+// the parent is either another diff layer or nil for the disk layer
+type diffLayer struct {
+	root   types.Hash
+	parent *diffLayer
+	diff   *flatDiff
+}
+
+// flatSnapshot is the flat key-value view of the recent states, it consists of the disk layer
+// with the oldest state and the diff layers of the commits on top of it.
+// It's read instead of the trie for the states which have a layer
+type flatSnapshot struct {
+	logger     hclog.Logger
+	storage    SnapshotStorage
+	diffLayers int
+
+	lock sync.RWMutex
+	// diskRoot is the state root of the disk layer
+	diskRoot types.Hash
+	// layers are the diff layers by their state root
+	layers map[types.Hash]*diffLayer
+	// invalid is set when the snapshot failed to be updated, the trie is read instead until it's rebuilt
+	invalid bool
+}
+
+// newFlatSnapshot creates the flat snapshot of the state with the given root, the persisted one
+// is rebuilt from the trie if it doesn't match the root
+func newFlatSnapshot(
+	storage SnapshotStorage,
+	root types.Hash,
+	diffLayers int,
+	logger hclog.Logger,
+) (*flatSnapshot, error) {
+	s := &flatSnapshot{
+		logger:     logger.Named("flat_snapshot"),
+		storage:    storage,
+		diffLayers: diffLayers,
+		layers:     map[types.Hash]*diffLayer{},
+	}
+
+	if diskRoot, ok := storage.Get(snapshotRootKey); ok && types.BytesToHash(diskRoot) == root {
+		s.diskRoot = root
+
+		return s, nil
+	}
+
+	if err := s.rebuild(root); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// rebuild replaces the persisted flat snapshot with the state with the given root read from the trie
+func (s *flatSnapshot) rebuild(root types.Hash) error {
+	s.logger.Info("rebuilding the flat snapshot from the trie", "root", root)
+
+	for _, prefix := range [][]byte{snapshotRootKey, snapshotAccountPrefix, snapshotStoragePrefix} {
+		if err := s.deletePrefix(prefix); err != nil {
+			return err
+		}
+	}
+
+	var (
+		batch    = s.storage.Batch()
+		pending  int
+		accounts int
+	)
+
+	put := func(key, value []byte) error {
+		batch.Put(key, value)
+
+		if pending++; pending >= flatSnapshotBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+
+			batch = s.storage.Batch()
+			pending = 0
+		}
+
+		return nil
+	}
+
+	err := walkLeaves(s.storage, root, func(key, value []byte) error {
+		accountHash := types.BytesToHash(key)
+
+		if err := put(snapshotAccountKey(accountHash), value); err != nil {
+			return err
+		}
+
+		accounts++
+
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return err
+		}
+
+		return walkLeaves(s.storage, account.Root, func(key, value []byte) error {
+			return put(snapshotStorageKey(accountHash, types.BytesToHash(key)), value)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rebuild the flat snapshot: %w", err)
+	}
+
+	batch.Put(snapshotRootKey, root.Bytes())
+
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to rebuild the flat snapshot: %w", err)
+	}
+
+	s.diskRoot = root
+
+	s.logger.Info("flat snapshot rebuilt", "root", root, "accounts", accounts)
+
+	return nil
+}
+
+// update adds the diff layer of the commit on top of the layer of the parent state,
+// the diff is ignored if the parent state has no layer
+func (s *flatSnapshot) update(parentRoot, root types.Hash, diff *flatDiff) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.invalid {
+		return nil
+	}
+
+	if _, ok := s.layers[root]; ok || root == s.diskRoot {
+		// the same state is already committed
+		return nil
+	}
+
+	var parent *diffLayer
+
+	if parentRoot != s.diskRoot {
+		var ok bool
+		if parent, ok = s.layers[parentRoot]; !ok {
+			return nil
+		}
+	}
+
+	layer := &diffLayer{
+		root:   root,
+		parent: parent,
+		diff:   diff,
+	}
+
+	s.layers[root] = layer
+
+	return s.capLayers(layer, s.diffLayers)
+}
+
+// flush writes all the diff layers under the state with the given root to the disk layer
+func (s *flatSnapshot) flush(root types.Hash) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	layer, ok := s.layers[root]
+	if !ok || s.invalid {
+		return nil
+	}
+
+	return s.capLayers(layer, 0)
+}
+
+// invalidate disables the snapshot which failed to be updated, so the trie is read instead,
+// and removes the persisted root, so the snapshot is rebuilt on the next start
+func (s *flatSnapshot) invalidate(reason error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.logger.Error("flat snapshot failed to be updated, the trie is read instead until it's rebuilt", "err", reason)
+
+	s.invalid = true
+	s.layers = map[types.Hash]*diffLayer{}
+
+	if err := s.storage.Delete([][]byte{snapshotRootKey}); err != nil {
+		s.logger.Error("failed to remove the flat snapshot root", "err", err)
+	}
+}
+
+// capLayers writes the diff layers under the given layer to the disk layer, so at most the given number
+// of the diff layers is kept, the layers which are not built on top of the new disk layer are discarded
+func (s *flatSnapshot) capLayers(layer *diffLayer, keep int) error {
+	chain := []*diffLayer{}
+	for l := layer; l != nil; l = l.parent {
+		chain = append(chain, l)
+	}
+
+	if len(chain) <= keep {
+		return nil
+	}
+
+	// write the oldest layers first
+	for i := len(chain) - 1; i >= keep; i-- {
+		if err := s.writeDiskLayer(chain[i]); err != nil {
+			return err
+		}
+	}
+
+	newDisk := chain[keep]
+
+	for root, l := range s.layers {
+		if !l.descendsFrom(newDisk) {
+			delete(s.layers, root)
+		}
+	}
+
+	for _, l := range s.layers {
+		if l.parent == newDisk {
+			l.parent = nil
+		}
+	}
+
+	return nil
+}
+
+// descendsFrom checks if the layer is built on top of the given one
+func (l *diffLayer) descendsFrom(ancestor *diffLayer) bool {
+	for parent := l.parent; parent != nil; parent = parent.parent {
+		if parent == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeDiskLayer writes the diff layer to the disk layer
+func (s *flatSnapshot) writeDiskLayer(layer *diffLayer) error {
+	deleted := [][]byte{}
+
+	for accountHash := range layer.diff.destructs {
+		err := s.storage.IteratePrefix(concat(snapshotStoragePrefix, accountHash.Bytes()), func(key, _ []byte) error {
+			deleted = append(deleted, key)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	batch := s.storage.Batch()
+
+	for accountHash, slots := range layer.diff.storage {
+		for slotHash, value := range slots {
+			key := snapshotStorageKey(accountHash, slotHash)
+
+			if value == nil {
+				deleted = append(deleted, key)
+			} else {
+				batch.Put(key, value)
+			}
+		}
+	}
+
+	for accountHash, value := range layer.diff.accounts {
+		key := snapshotAccountKey(accountHash)
+
+		if value == nil {
+			deleted = append(deleted, key)
+		} else {
+			batch.Put(key, value)
+		}
+	}
+
+	// the persisted root is removed first and written along with the layer, so the partially written
+	// layer is never taken for the complete one and the snapshot is rebuilt on the next start
+	if err := s.storage.Delete([][]byte{snapshotRootKey}); err != nil {
+		return err
+	}
+
+	// the slots of the destructed accounts are removed before the new ones are written
+	if err := s.delete(deleted); err != nil {
+		return err
+	}
+
+	batch.Put(snapshotRootKey, layer.root.Bytes())
+
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to write the flat snapshot layer: %w", err)
+	}
+
+	s.diskRoot = layer.root
+
+	return nil
+}
+
+// account returns the account with the given hash in the state with the given root,
+// the nil data means the account doesn't exist. It returns false if the state has no layer
+func (s *flatSnapshot) account(stateRoot, accountHash types.Hash) ([]byte, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	layer, ok := s.layer(stateRoot)
+	if !ok {
+		return nil, false
+	}
+
+	for ; layer != nil; layer = layer.parent {
+		if data, ok := layer.diff.accounts[accountHash]; ok {
+			return data, true
+		}
+	}
+
+	data, ok := s.storage.Get(snapshotAccountKey(accountHash))
+	if !ok {
+		return nil, true
+	}
+
+	return data, true
+}
+
+// storageSlot returns the storage slot of the account in the state with the given root,
+// the nil value means the slot is empty. It returns false if the state has no layer
+func (s *flatSnapshot) storageSlot(stateRoot, accountHash, slotHash types.Hash) ([]byte, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	layer, ok := s.layer(stateRoot)
+	if !ok {
+		return nil, false
+	}
+
+	for ; layer != nil; layer = layer.parent {
+		if value, ok := layer.diff.storage[accountHash][slotHash]; ok {
+			return value, true
+		}
+
+		if _, ok := layer.diff.destructs[accountHash]; ok {
+			return nil, true
+		}
+	}
+
+	value, ok := s.storage.Get(snapshotStorageKey(accountHash, slotHash))
+	if !ok {
+		return nil, true
+	}
+
+	return value, true
+}
+
+// layer returns the diff layer of the state with the given root, the nil layer is the disk layer
+func (s *flatSnapshot) layer(root types.Hash) (*diffLayer, bool) {
+	if s.invalid {
+		return nil, false
+	}
+
+	if root == s.diskRoot {
+		return nil, true
+	}
+
+	layer, ok := s.layers[root]
+
+	return layer, ok
+}
+
+// deletePrefix removes all the keys starting with the prefix
+func (s *flatSnapshot) deletePrefix(prefix []byte) error {
+	keys := [][]byte{}
+
+	err := s.storage.IteratePrefix(prefix, func(key, _ []byte) error {
+		keys = append(keys, key)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.delete(keys)
+}
+
+// delete removes the keys in the batches
+func (s *flatSnapshot) delete(keys [][]byte) error {
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > flatSnapshotBatchSize {
+			batch = batch[:flatSnapshotBatchSize]
+		}
+
+		if err := s.storage.Delete(batch); err != nil {
+			return err
+		}
+
+		keys = keys[len(batch):]
+	}
+
+	return nil
+}
+
+// walkLeaves calls the handler with the key and the value of each leaf of the trie with the given root
+func walkLeaves(storage Storage, root types.Hash, handler func(key, value []byte) error) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	rootNode, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingTrieNode, root)
+	}
+
+	return walkNodeLeaves(storage, rootNode, nil, handler)
+}
+
+func walkNodeLeaves(storage Storage, node Node, path []byte, handler func(key, value []byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			child, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("%w: %s", ErrMissingTrieNode, hex.EncodeToHex(n.buf))
+			}
+
+			return walkNodeLeaves(storage, child, path, handler)
+		}
+
+		return handler(hexNibblesToBytes(path[:len(path)-1]), append([]byte{}, n.buf...))
+
+	case *ShortNode:
+		return walkNodeLeaves(storage, n.child, concat(path, n.key), handler)
+
+	case *FullNode:
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkNodeLeaves(storage, child, concat(path, []byte{byte(i)}), handler); err != nil {
+				return err
+			}
+		}
+
+		return walkNodeLeaves(storage, n.value, concat(path, []byte{16}), handler)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
@@ -0,0 +1,426 @@
+package itrie
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	flatAddr1 = types.StringToAddress("1")
+	flatAddr2 = types.StringToAddress("2")
+	flatSlot1 = types.StringToHash("1")
+	flatSlot2 = types.StringToHash("2")
+)
+
+func newFlatTestState(t *testing.T, diffLayers int) (*State, SnapshotStorage) {
+	t.Helper()
+
+	storage, ok := NewMemoryStorage().(SnapshotStorage)
+	require.True(t, ok)
+
+	st := NewState(storage)
+	require.NoError(t, st.EnableFlatSnapshot(storage, types.EmptyRootHash, diffLayers, hclog.NewNullLogger()))
+
+	return st, storage
+}
+
+// commitFlatBlock commits the objects on top of the parent state, the storage roots are taken from the parent
+func commitFlatBlock(t *testing.T, st *State, parent types.Hash, objs ...*state.Object) types.Hash {
+	t.Helper()
+
+	snap, err := st.NewSnapshotAt(parent)
+	require.NoError(t, err)
+
+	for _, obj := range objs {
+		if obj.Root != (types.Hash{}) {
+			continue
+		}
+
+		obj.Root = types.EmptyRootHash
+
+		account, err := snap.GetAccount(obj.Address)
+		require.NoError(t, err)
+
+		if account != nil {
+			obj.Root = account.Root
+		}
+	}
+
+	_, root := snap.Commit(objs)
+
+	return types.BytesToHash(root)
+}
+
+func storageObject(slot types.Hash, value int64) *state.StorageObject {
+	if value == 0 {
+		return &state.StorageObject{Key: slot.Bytes(), Deleted: true}
+	}
+
+	return &state.StorageObject{Key: slot.Bytes(), Val: types.BytesToHash(big.NewInt(value).Bytes()).Bytes()}
+}
+
+// requireFlatMatchesTrie checks that the flat snapshot has the state and it's read the same as the trie
+func requireFlatMatchesTrie(t *testing.T, st *State, storage Storage, root types.Hash) {
+	t.Helper()
+
+	_, ok := st.flat.account(root, types.BytesToHash(hashit(flatAddr1.Bytes())))
+	require.True(t, ok)
+
+	flatSnap, err := st.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	trieSnap, err := NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range []types.Address{flatAddr1, flatAddr2} {
+		flatAccount, err := flatSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		trieAccount, err := trieSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		require.Equal(t, trieAccount, flatAccount)
+
+		if trieAccount == nil {
+			continue
+		}
+
+		for _, slot := range []types.Hash{flatSlot1, flatSlot2} {
+			require.Equal(t,
+				trieSnap.GetStorage(addr, trieAccount.Root, slot),
+				flatSnap.GetStorage(addr, flatAccount.Root, slot),
+			)
+		}
+	}
+}
+
+func TestFlatSnapshot_Commit(t *testing.T) {
+	t.Parallel()
+
+	st, storage := newFlatTestState(t, 2)
+
+	blocks := [][]*state.Object{
+		{
+			{
+				Address:  flatAddr1,
+				Balance:  big.NewInt(1),
+				CodeHash: types.EmptyCodeHash,
+				Storage:  []*state.StorageObject{storageObject(flatSlot1, 1), storageObject(flatSlot2, 2)},
+			},
+			{
+				Address:  flatAddr2,
+				Balance:  big.NewInt(1),
+				CodeHash: types.EmptyCodeHash,
+				Storage:  []*state.StorageObject{storageObject(flatSlot1, 3)},
+			},
+		},
+		{
+			{
+				Address:  flatAddr1,
+				Balance:  big.NewInt(2),
+				CodeHash: types.EmptyCodeHash,
+				Storage:  []*state.StorageObject{storageObject(flatSlot1, 0)},
+			},
+			{
+				Address: flatAddr2,
+				Deleted: true,
+			},
+		},
+		{
+			{
+				Address:  flatAddr2,
+				Balance:  big.NewInt(5),
+				CodeHash: types.EmptyCodeHash,
+				Storage:  []*state.StorageObject{storageObject(flatSlot2, 4)},
+			},
+		},
+		{
+			// the account is recreated, so its storage is wiped
+			{
+				Address:  flatAddr1,
+				Balance:  big.NewInt(3),
+				CodeHash: types.EmptyCodeHash,
+				Root:     types.EmptyRootHash,
+				Storage:  []*state.StorageObject{storageObject(flatSlot1, 5)},
+			},
+		},
+		{
+			{
+				Address:  flatAddr2,
+				Balance:  big.NewInt(6),
+				CodeHash: types.EmptyCodeHash,
+			},
+		},
+	}
+
+	root := types.EmptyRootHash
+	roots := []types.Hash{}
+
+	for _, objs := range blocks {
+		root = commitFlatBlock(t, st, root, objs...)
+		roots = append(roots, root)
+
+		requireFlatMatchesTrie(t, st, storage, root)
+	}
+
+	// only the last diff layers are kept in the memory, the older states are written to the disk layer
+	require.Len(t, st.flat.layers, 2)
+	require.Equal(t, roots[len(roots)-3], st.flat.diskRoot)
+
+	_, ok := st.flat.account(roots[0], types.BytesToHash(hashit(flatAddr1.Bytes())))
+	require.False(t, ok)
+
+	require.NoError(t, st.FlushFlatSnapshot(root))
+	require.Empty(t, st.flat.layers)
+	require.Equal(t, root, st.flat.diskRoot)
+
+	// the storage of the recreated account is wiped on the disk
+	_, ok = storage.Get(snapshotStorageKey(
+		types.BytesToHash(hashit(flatAddr1.Bytes())),
+		types.BytesToHash(hashit(flatSlot2.Bytes())),
+	))
+	require.False(t, ok)
+
+	requireFlatMatchesTrie(t, st, storage, root)
+}
+
+func TestFlatSnapshot_Forks(t *testing.T) {
+	t.Parallel()
+
+	st, storage := newFlatTestState(t, 1)
+
+	parent := commitFlatBlock(t, st, types.EmptyRootHash, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(1),
+		CodeHash: types.EmptyCodeHash,
+	})
+
+	fork1 := commitFlatBlock(t, st, parent, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(2),
+		CodeHash: types.EmptyCodeHash,
+	})
+
+	fork2 := commitFlatBlock(t, st, parent, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(3),
+		CodeHash: types.EmptyCodeHash,
+	})
+
+	requireFlatMatchesTrie(t, st, storage, fork1)
+	requireFlatMatchesTrie(t, st, storage, fork2)
+
+	// the layer of the first fork is written to the disk, so the second fork is discarded
+	child := commitFlatBlock(t, st, fork1, &state.Object{
+		Address:  flatAddr2,
+		Balance:  big.NewInt(1),
+		CodeHash: types.EmptyCodeHash,
+	})
+
+	require.Equal(t, fork1, st.flat.diskRoot)
+	requireFlatMatchesTrie(t, st, storage, child)
+
+	_, ok := st.flat.account(fork2, types.BytesToHash(hashit(flatAddr1.Bytes())))
+	require.False(t, ok)
+
+	// the state without the layer is still read from the trie
+	snap, err := st.NewSnapshotAt(fork2)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(flatAddr1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), account.Balance)
+}
+
+func TestFlatSnapshot_Rebuild(t *testing.T) {
+	t.Parallel()
+
+	storage, ok := NewMemoryStorage().(SnapshotStorage)
+	require.True(t, ok)
+
+	// the state is committed without the flat snapshot
+	root := commitFlatBlock(t, NewState(storage), types.EmptyRootHash,
+		&state.Object{
+			Address:  flatAddr1,
+			Balance:  big.NewInt(1),
+			CodeHash: types.EmptyCodeHash,
+			Storage:  []*state.StorageObject{storageObject(flatSlot1, 1), storageObject(flatSlot2, 2)},
+		},
+		&state.Object{
+			Address:  flatAddr2,
+			Balance:  big.NewInt(2),
+			CodeHash: types.EmptyCodeHash,
+		},
+	)
+
+	// a stale entry is removed by the rebuild
+	storage.Put(snapshotStorageKey(types.StringToHash("3"), types.StringToHash("3")), []byte{0x1})
+
+	st := NewState(storage)
+	require.NoError(t, st.EnableFlatSnapshot(storage, root, 1, hclog.NewNullLogger()))
+	require.Equal(t, root, st.flat.diskRoot)
+
+	diskRoot, ok := storage.Get(snapshotRootKey)
+	require.True(t, ok)
+	require.Equal(t, root.Bytes(), diskRoot)
+
+	_, ok = storage.Get(snapshotStorageKey(types.StringToHash("3"), types.StringToHash("3")))
+	require.False(t, ok)
+
+	requireFlatMatchesTrie(t, st, storage, root)
+
+	// the persisted snapshot is reused
+	storage.Put(snapshotAccountKey(types.StringToHash("3")), []byte{0x1})
+
+	st = NewState(storage)
+	require.NoError(t, st.EnableFlatSnapshot(storage, root, 1, hclog.NewNullLogger()))
+
+	_, ok = storage.Get(snapshotAccountKey(types.StringToHash("3")))
+	require.True(t, ok)
+}
+
+// failingDeleteStorage fails the next removal of the keys
+type failingDeleteStorage struct {
+	SnapshotStorage
+	fail bool
+}
+
+func (s *failingDeleteStorage) Delete(keys [][]byte) error {
+	if s.fail {
+		s.fail = false
+
+		return errors.New("delete failed")
+	}
+
+	return s.SnapshotStorage.Delete(keys)
+}
+
+func TestFlatSnapshot_UpdateFailure(t *testing.T) {
+	t.Parallel()
+
+	memStorage, ok := NewMemoryStorage().(SnapshotStorage)
+	require.True(t, ok)
+
+	storage := &failingDeleteStorage{SnapshotStorage: memStorage}
+
+	st := NewState(storage)
+	require.NoError(t, st.EnableFlatSnapshot(storage, types.EmptyRootHash, 0, hclog.NewNullLogger()))
+
+	root1 := commitFlatBlock(t, st, types.EmptyRootHash, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(1),
+		CodeHash: types.EmptyCodeHash,
+		Storage:  []*state.StorageObject{storageObject(flatSlot1, 1)},
+	})
+	requireFlatMatchesTrie(t, st, storage, root1)
+
+	// the removal of the slot from the disk layer fails, so the snapshot is invalidated
+	storage.fail = true
+
+	root2 := commitFlatBlock(t, st, root1, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(2),
+		CodeHash: types.EmptyCodeHash,
+		Storage:  []*state.StorageObject{storageObject(flatSlot1, 0)},
+	})
+
+	_, ok = st.flat.account(root2, types.BytesToHash(hashit(flatAddr1.Bytes())))
+	require.False(t, ok)
+
+	// the persisted root is removed, so the snapshot is rebuilt on the next start
+	_, ok = storage.Get(snapshotRootKey)
+	require.False(t, ok)
+
+	// the trie is read instead
+	snap, err := st.NewSnapshotAt(root2)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(flatAddr1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), account.Balance)
+	require.Equal(t, types.Hash{}, snap.GetStorage(flatAddr1, account.Root, flatSlot1))
+
+	// the next commits don't update the invalidated snapshot
+	root3 := commitFlatBlock(t, st, root2, &state.Object{
+		Address:  flatAddr2,
+		Balance:  big.NewInt(3),
+		CodeHash: types.EmptyCodeHash,
+	})
+
+	_, ok = st.flat.account(root3, types.BytesToHash(hashit(flatAddr2.Bytes())))
+	require.False(t, ok)
+}
+
+// failingLayerWriteStorage fails the next write of the batch which persists the flat snapshot root
+type failingLayerWriteStorage struct {
+	SnapshotStorage
+	fail bool
+}
+
+func (s *failingLayerWriteStorage) Batch() Batch {
+	return &failingLayerWriteBatch{Batch: s.SnapshotStorage.Batch(), storage: s}
+}
+
+type failingLayerWriteBatch struct {
+	Batch
+	storage *failingLayerWriteStorage
+	root    bool
+}
+
+func (b *failingLayerWriteBatch) Put(k, v []byte) {
+	b.root = b.root || string(k) == string(snapshotRootKey)
+	b.Batch.Put(k, v)
+}
+
+func (b *failingLayerWriteBatch) Write() error {
+	if b.root && b.storage.fail {
+		b.storage.fail = false
+
+		return errors.New("write failed")
+	}
+
+	return b.Batch.Write()
+}
+
+func TestFlatSnapshot_LayerWriteFailure(t *testing.T) {
+	t.Parallel()
+
+	memStorage, ok := NewMemoryStorage().(SnapshotStorage)
+	require.True(t, ok)
+
+	storage := &failingLayerWriteStorage{SnapshotStorage: memStorage}
+
+	st := NewState(storage)
+	require.NoError(t, st.EnableFlatSnapshot(storage, types.EmptyRootHash, 0, hclog.NewNullLogger()))
+
+	root1 := commitFlatBlock(t, st, types.EmptyRootHash, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(1),
+		CodeHash: types.EmptyCodeHash,
+	})
+	requireFlatMatchesTrie(t, st, storage, root1)
+
+	// the write of the layer fails, so the snapshot is invalidated instead of moving to the new root
+	storage.fail = true
+
+	root2 := commitFlatBlock(t, st, root1, &state.Object{
+		Address:  flatAddr1,
+		Balance:  big.NewInt(2),
+		CodeHash: types.EmptyCodeHash,
+	})
+
+	_, ok = st.flat.account(root2, types.BytesToHash(hashit(flatAddr1.Bytes())))
+	require.False(t, ok)
+	require.NotEqual(t, root2, st.flat.diskRoot)
+
+	// the persisted root is removed, so the snapshot is rebuilt on the next start
+	_, ok = storage.Get(snapshotRootKey)
+	require.False(t, ok)
+}
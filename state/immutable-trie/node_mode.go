@@ -0,0 +1,80 @@
+package itrie
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// NodeMode defines which historical states are kept in the state storage
+type NodeMode string
+
+const (
+	// ArchiveNodeMode keeps the states of all the blocks
+	ArchiveNodeMode NodeMode = "archive"
+
+	// FullNodeMode keeps the states of the recent blocks and of the checkpoint blocks
+	FullNodeMode NodeMode = "full"
+
+	// PrunedNodeMode keeps only the states of the recent blocks
+	PrunedNodeMode NodeMode = "pruned"
+)
+
+var (
+	// nodeModeKey is the key of the node mode persisted in the state storage
+	nodeModeKey = []byte("node-mode")
+
+	// lowestStateKey is the key of the number of the lowest block whose state was imported in the state storage
+	lowestStateKey = []byte("lowest-state")
+
+	// ErrInvalidNodeMode is returned for the unknown node mode
+	ErrInvalidNodeMode = errors.New("invalid node mode")
+
+	// ErrArchiveModePruned is returned when the archive mode is set on the storage which was already pruned
+	ErrArchiveModePruned = errors.New("archive node mode can't be set, the historical states are already pruned")
+)
+
+// ParseNodeMode parses the node mode from its name
+func ParseNodeMode(name string) (NodeMode, error) {
+	switch mode := NodeMode(name); mode {
+	case ArchiveNodeMode, FullNodeMode, PrunedNodeMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidNodeMode, name)
+	}
+}
+
+// IsPruned returns true if the node mode removes the historical states
+func (m NodeMode) IsPruned() bool {
+	return m != ArchiveNodeMode
+}
+
+// SetNodeMode persists the node mode in the state storage. The archive mode is rejected
+// once the storage was used in another mode, as the states removed since can't be restored
+func SetNodeMode(storage Storage, mode NodeMode) error {
+	if previous, ok := storage.Get(nodeModeKey); ok && mode == ArchiveNodeMode &&
+		NodeMode(previous).IsPruned() {
+		return fmt.Errorf("%w, previous mode %s", ErrArchiveModePruned, previous)
+	}
+
+	storage.Put(nodeModeKey, []byte(mode))
+
+	return nil
+}
+
+// SetLowestState persists the number of the lowest block whose state is in the storage,
+// the states of the blocks before it (except the genesis one) were never imported
+func SetLowestState(storage Storage, number uint64) {
+	storage.Put(lowestStateKey, binary.BigEndian.AppendUint64(nil, number))
+}
+
+// GetLowestState returns the number of the lowest block whose state is in the storage,
+// the zero is returned if all the blocks were executed on top of the genesis state
+func GetLowestState(storage Storage) uint64 {
+	value, ok := storage.Get(lowestStateKey)
+	if !ok || len(value) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
@@ -0,0 +1,75 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeMode(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []NodeMode{ArchiveNodeMode, FullNodeMode, PrunedNodeMode} {
+		parsed, err := ParseNodeMode(string(mode))
+		require.NoError(t, err)
+		require.Equal(t, mode, parsed)
+	}
+
+	_, err := ParseNodeMode("light")
+	require.ErrorIs(t, err, ErrInvalidNodeMode)
+}
+
+func TestSetNodeMode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		previous NodeMode
+		mode     NodeMode
+		err      error
+	}{
+		{"new storage in archive mode", "", ArchiveNodeMode, nil},
+		{"new storage in pruned mode", "", PrunedNodeMode, nil},
+		{"archive storage switched to full mode", ArchiveNodeMode, FullNodeMode, nil},
+		{"full storage switched to pruned mode", FullNodeMode, PrunedNodeMode, nil},
+		{"full storage switched to archive mode", FullNodeMode, ArchiveNodeMode, ErrArchiveModePruned},
+		{"pruned storage switched to archive mode", PrunedNodeMode, ArchiveNodeMode, ErrArchiveModePruned},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := NewMemoryStorage()
+
+			if c.previous != "" {
+				require.NoError(t, SetNodeMode(storage, c.previous))
+			}
+
+			err := SetNodeMode(storage, c.mode)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			mode, ok := storage.Get(nodeModeKey)
+			require.True(t, ok)
+			require.Equal(t, c.mode, NodeMode(mode))
+		})
+	}
+}
+
+func TestLowestState(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	require.Equal(t, uint64(0), GetLowestState(storage))
+
+	SetLowestState(storage, 1024)
+	require.Equal(t, uint64(1024), GetLowestState(storage))
+}
@@ -0,0 +1,167 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrMissingTrieNode is returned when a node on the path of the key can not be resolved
+	ErrMissingTrieNode = errors.New("missing trie node")
+)
+
+// Prove returns the Merkle-Patricia proof of the key in the trie with the given root.
+// The proof is the list of the RLP encoded nodes on the path from the root to the key,
+// nodes embedded in their parents are not part of the list.
+// If the key is not in the trie, the returned proof proves its absence.
+func Prove(root types.Hash, key []byte, storage Storage) ([][]byte, error) {
+	proof := [][]byte{}
+
+	_, err := walkProofPath(root, key, storage.Get, func(node []byte) {
+		proof = append(proof, node)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the proof of the key against the root
+// and returns the value of the key, or nil if the proof proves the absence of the key
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+
+	for _, node := range proof {
+		nodes[types.BytesToHash(hashit(node))] = node
+	}
+
+	getNode := func(hash []byte) ([]byte, bool) {
+		node, ok := nodes[types.BytesToHash(hash)]
+
+		return node, ok
+	}
+
+	return walkProofPath(root, key, getNode, nil)
+}
+
+// walkProofPath walks the path of the key from the root, resolving the referenced nodes
+// with getNode and passing each of them to visit. It returns the value of the key, if any
+func walkProofPath(
+	root types.Hash,
+	key []byte,
+	getNode func(hash []byte) ([]byte, bool),
+	visit func(node []byte),
+) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	p := &fastrlp.Parser{}
+	search := bytesToHexNibbles(key)
+	hash := root.Bytes()
+
+	for {
+		data, ok := getNode(hash)
+		if !ok || len(data) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrMissingTrieNode, hex.EncodeToHex(hash))
+		}
+
+		if visit != nil {
+			visit(data)
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var value []byte
+
+		hash, value, search, err = walkProofNode(v, search)
+		if err != nil || hash == nil {
+			return value, err
+		}
+	}
+}
+
+// walkProofNode follows the search path through the node and the nodes embedded in it.
+// It returns either the hash of the next node to resolve with the rest of the search path,
+// or the value of the key if the path ends in this node
+func walkProofNode(v *fastrlp.Value, search []byte) ([]byte, []byte, []byte, error) {
+	for {
+		if v.Type() != fastrlp.TypeArray {
+			return nil, nil, nil, fmt.Errorf("trie node expected to be an array")
+		}
+
+		var child *fastrlp.Value
+
+		switch v.Elems() {
+		case 2:
+			key := v.Get(0)
+			if key.Type() != fastrlp.TypeBytes {
+				return nil, nil, nil, fmt.Errorf("short key expected to be bytes")
+			}
+
+			nodeKey := decodeCompact(key.Raw())
+
+			if hasTerminator(nodeKey) {
+				// leaf node
+				if !bytes.Equal(nodeKey, search) {
+					return nil, nil, nil, nil
+				}
+
+				return nil, append([]byte{}, v.Get(1).Raw()...), nil, nil
+			}
+
+			// extension node
+			if len(search) < len(nodeKey) || !bytes.Equal(search[:len(nodeKey)], nodeKey) {
+				return nil, nil, nil, nil
+			}
+
+			search = search[len(nodeKey):]
+			child = v.Get(1)
+
+		case 17:
+			// full node
+			if len(search) == 0 {
+				return nil, nil, nil, nil
+			}
+
+			if search[0] == 16 {
+				value := v.Get(16).Raw()
+				if len(value) == 0 {
+					return nil, nil, nil, nil
+				}
+
+				return nil, append([]byte{}, value...), nil, nil
+			}
+
+			child = v.Get(int(search[0]))
+			search = search[1:]
+
+		default:
+			return nil, nil, nil, fmt.Errorf("node has incorrect number of leafs")
+		}
+
+		if child.Type() == fastrlp.TypeArray {
+			// embedded node
+			v = child
+
+			continue
+		}
+
+		if len(child.Raw()) == 0 {
+			// empty child
+			return nil, nil, nil, nil
+		}
+
+		return append([]byte{}, child.Raw()...), nil, search, nil
+	}
+}
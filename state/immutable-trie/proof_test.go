@@ -0,0 +1,132 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestProof_Trie(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = storage
+
+	for i := 0; i < 100; i++ {
+		txn.Insert(hashit([]byte{byte(i)}), []byte{byte(i), 1, 2, 3})
+	}
+
+	rootBytes, err := txn.Hash()
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rootBytes)
+
+	for i := 0; i < 100; i++ {
+		key := hashit([]byte{byte(i)})
+
+		proof, err := Prove(root, key, storage)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+
+		value, err := VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(i), 1, 2, 3}, value)
+	}
+
+	// absence of the key
+	key := hashit([]byte{0xff, 0xff})
+
+	proof, err := Prove(root, key, storage)
+	require.NoError(t, err)
+	require.NotEmpty(t, proof)
+
+	value, err := VerifyProof(root, key, proof)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// proof with missing nodes
+	proof, err = Prove(root, hashit([]byte{1}), storage)
+	require.NoError(t, err)
+
+	_, err = VerifyProof(root, hashit([]byte{1}), proof[:len(proof)-1])
+	require.ErrorIs(t, err, ErrMissingTrieNode)
+
+	// empty trie
+	proof, err = Prove(types.EmptyRootHash, key, storage)
+	require.NoError(t, err)
+	require.Empty(t, proof)
+}
+
+func TestProof_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		slot  = types.StringToHash("1")
+	)
+
+	st := NewState(NewMemoryStorage())
+
+	_, root := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr1,
+			Balance:  big.NewInt(100),
+			Nonce:    1,
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: slot.Bytes(), Val: types.StringToHash("5").Bytes()},
+			},
+		},
+	})
+
+	snap, err := st.NewSnapshotAt(types.BytesToHash(root))
+	require.NoError(t, err)
+
+	prover, ok := snap.(state.Prover)
+	require.True(t, ok)
+
+	// account proof
+	proof, err := prover.GetAccountProof(addr1)
+	require.NoError(t, err)
+
+	data, err := VerifyProof(types.BytesToHash(root), hashit(addr1.Bytes()), proof)
+	require.NoError(t, err)
+
+	var account state.Account
+	require.NoError(t, account.UnmarshalRlp(data))
+	require.Equal(t, big.NewInt(100), account.Balance)
+	require.Equal(t, uint64(1), account.Nonce)
+
+	// storage proof
+	proof, err = prover.GetStorageProof(account.Root, slot)
+	require.NoError(t, err)
+
+	data, err = VerifyProof(account.Root, hashit(slot.Bytes()), proof)
+	require.NoError(t, err)
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	require.NoError(t, err)
+
+	value, err := v.GetBytes(nil)
+	require.NoError(t, err)
+	require.Equal(t, types.StringToHash("5"), types.BytesToHash(value))
+
+	// proof of absence of the account
+	proof, err = prover.GetAccountProof(addr2)
+	require.NoError(t, err)
+
+	data, err = VerifyProof(types.BytesToHash(root), hashit(addr2.Bytes()), proof)
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
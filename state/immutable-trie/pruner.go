@@ -0,0 +1,278 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// pruneBatchSize is the number of the nodes removed at once
+const pruneBatchSize = 1024
+
+var (
+	// ErrPruningNotSupported is returned when the storage of the state can't be pruned
+	ErrPruningNotSupported = errors.New("state storage doesn't support pruning")
+)
+
+// PrunableStorage is the storage whose trie nodes can be enumerated and removed
+type PrunableStorage interface {
+	Storage
+
+	// IterateNodes calls the handler with the key of each trie node in the storage
+	IterateNodes(handler func(key []byte)) error
+	// DeleteNodes removes the trie nodes with the given keys
+	DeleteNodes(keys [][]byte) error
+}
+
+// PruningStorage is the trie storage which removes the nodes unreachable from the retained state roots
+// (mark and sweep). The nodes written since the start of the previous pruning are never removed,
+// which makes the pruning safe to run while the new states are being committed
+type PruningStorage struct {
+	PrunableStorage
+
+	logger hclog.Logger
+
+	// pruneLock allows a single pruning at a time
+	pruneLock sync.Mutex
+
+	// writtenLock guards the sets of the written keys and the removal of the nodes
+	writtenLock sync.Mutex
+	// written is the set of the keys written since the start of the last pruning
+	written map[string]struct{}
+	// previousWritten is the set of the keys written before the start of the running pruning,
+	// since the start of the previous one
+	previousWritten map[string]struct{}
+}
+
+// NewPruningStorage wraps the storage to make it prunable
+func NewPruningStorage(storage PrunableStorage, logger hclog.Logger) *PruningStorage {
+	return &PruningStorage{
+		PrunableStorage: storage,
+		logger:          logger.Named("pruning"),
+		written:         map[string]struct{}{},
+	}
+}
+
+func (s *PruningStorage) Put(k, v []byte) {
+	s.markWritten(k)
+	s.PrunableStorage.Put(k, v)
+}
+
+func (s *PruningStorage) Batch() Batch {
+	return &pruningBatch{
+		Batch:   s.PrunableStorage.Batch(),
+		storage: s,
+	}
+}
+
+// markWritten saves the key as written, it's done before the actual write
+// so the node can't be removed by the running pruning once it's written
+func (s *PruningStorage) markWritten(k []byte) {
+	s.writtenLock.Lock()
+	defer s.writtenLock.Unlock()
+
+	s.written[string(k)] = struct{}{}
+}
+
+// Prune removes the trie nodes which are not reachable from the given state roots,
+// neither from the storage tries of the accounts of these states. It returns the number of the removed nodes
+func (s *PruningStorage) Prune(roots []types.Hash) (int, error) {
+	s.pruneLock.Lock()
+	defer s.pruneLock.Unlock()
+
+	s.writtenLock.Lock()
+	s.previousWritten = s.written
+	s.written = map[string]struct{}{}
+	s.writtenLock.Unlock()
+
+	defer func() {
+		s.writtenLock.Lock()
+		s.previousWritten = nil
+		s.writtenLock.Unlock()
+	}()
+
+	// mark
+	marked := map[types.Hash]struct{}{}
+
+	for _, root := range roots {
+		if _, ok := s.Get(root.Bytes()); !ok && root != types.EmptyRootHash {
+			// the state is not available, there is nothing to retain
+			s.logger.Warn("retained state not found", "root", root)
+
+			continue
+		}
+
+		// the incomplete retained state aborts the pruning, the sweep would remove its reachable nodes
+		if err := s.markTrie(root.Bytes(), true, marked); err != nil {
+			return 0, fmt.Errorf("failed to mark the state %s: %w", root, err)
+		}
+	}
+
+	// sweep
+	var (
+		pruned int
+		keys   = make([][]byte, 0, pruneBatchSize)
+	)
+
+	if err := s.IterateNodes(func(key []byte) {
+		if _, ok := marked[types.BytesToHash(key)]; ok {
+			return
+		}
+
+		keys = append(keys, key)
+		if len(keys) < pruneBatchSize {
+			return
+		}
+
+		pruned += s.deleteNodes(keys)
+		keys = keys[:0]
+	}); err != nil {
+		return pruned, err
+	}
+
+	pruned += s.deleteNodes(keys)
+
+	s.logger.Debug("state pruned", "retained", len(marked), "pruned", pruned)
+
+	return pruned, nil
+}
+
+// deleteNodes removes the nodes which have not been written during the pruning
+func (s *PruningStorage) deleteNodes(keys [][]byte) int {
+	s.writtenLock.Lock()
+	defer s.writtenLock.Unlock()
+
+	unwritten := make([][]byte, 0, len(keys))
+
+	for _, key := range keys {
+		if _, ok := s.written[string(key)]; ok {
+			continue
+		}
+
+		if _, ok := s.previousWritten[string(key)]; ok {
+			continue
+		}
+
+		unwritten = append(unwritten, key)
+	}
+
+	if len(unwritten) == 0 {
+		return 0
+	}
+
+	if err := s.DeleteNodes(unwritten); err != nil {
+		s.logger.Error("failed to remove the trie nodes", "err", err)
+
+		return 0
+	}
+
+	return len(unwritten)
+}
+
+// markTrie marks the node with the given hash and all the nodes reachable from it.
+// The leaves of the account trie lead to the storage tries of the accounts
+func (s *PruningStorage) markTrie(hash []byte, accountTrie bool, marked map[types.Hash]struct{}) error {
+	if _, ok := marked[types.BytesToHash(hash)]; ok {
+		// the subtrie is shared with the already marked one
+		return nil
+	}
+
+	if types.BytesToHash(hash) == types.EmptyRootHash {
+		return nil
+	}
+
+	data, ok := s.Get(hash)
+	if !ok || len(data) == 0 {
+		return fmt.Errorf("%w: %s", ErrMissingTrieNode, hex.EncodeToHex(hash))
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	marked[types.BytesToHash(hash)] = struct{}{}
+
+	return s.markNode(v, accountTrie, marked)
+}
+
+// markNode marks the nodes referenced by the node, including the ones embedded in it
+func (s *PruningStorage) markNode(v *fastrlp.Value, accountTrie bool, marked map[types.Hash]struct{}) error {
+	if v.Type() != fastrlp.TypeArray {
+		return fmt.Errorf("trie node expected to be an array")
+	}
+
+	switch v.Elems() {
+	case 2:
+		key := v.Get(0)
+		if key.Type() != fastrlp.TypeBytes {
+			return fmt.Errorf("short key expected to be bytes")
+		}
+
+		if !hasTerminator(decodeCompact(key.Raw())) {
+			// extension node
+			return s.markChild(v.Get(1), accountTrie, marked)
+		}
+
+		// leaf node
+		if !accountTrie {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(v.Get(1).Raw()); err != nil {
+			return fmt.Errorf("failed to decode the account: %w", err)
+		}
+
+		return s.markTrie(account.Root.Bytes(), false, marked)
+
+	case 17:
+		// full node, the keys have the same length so there are no values in the full nodes
+		for i := 0; i < 16; i++ {
+			if err := s.markChild(v.Get(i), accountTrie, marked); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("node has incorrect number of leafs")
+	}
+}
+
+// markChild marks the child of the node, which is either embedded in the node or referenced by the hash
+func (s *PruningStorage) markChild(child *fastrlp.Value, accountTrie bool, marked map[types.Hash]struct{}) error {
+	if child.Type() == fastrlp.TypeArray {
+		return s.markNode(child, accountTrie, marked)
+	}
+
+	if len(child.Raw()) == 0 {
+		// empty child
+		return nil
+	}
+
+	return s.markTrie(child.Raw(), accountTrie, marked)
+}
+
+// pruningBatch is the batch which marks the written keys of the pruning storage
+type pruningBatch struct {
+	Batch
+
+	storage *PruningStorage
+}
+
+func (b *pruningBatch) Put(k, v []byte) {
+	b.storage.markWritten(k)
+	b.Batch.Put(k, v)
+}
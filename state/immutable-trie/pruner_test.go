@@ -0,0 +1,172 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestPruning_Prune(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		slot  = types.StringToHash("1")
+	)
+
+	storage, ok := NewMemoryStorage().(PrunableStorage)
+	require.True(t, ok)
+
+	pruningStorage := NewPruningStorage(storage, hclog.NewNullLogger())
+	st := NewState(pruningStorage)
+
+	// commitBlock commits the state with the balances and the storage values depending on the block number
+	commitBlock := func(parent types.Hash, i int64) types.Hash {
+		snap, err := st.NewSnapshotAt(parent)
+		require.NoError(t, err)
+
+		objs := []*state.Object{
+			{
+				Address:  addr1,
+				Balance:  big.NewInt(i),
+				CodeHash: types.EmptyCodeHash,
+				Root:     types.EmptyRootHash,
+				Storage: []*state.StorageObject{
+					{Key: slot.Bytes(), Val: types.BytesToHash(big.NewInt(i).Bytes()).Bytes()},
+				},
+			},
+		}
+
+		if parent != types.EmptyRootHash {
+			account, err := snap.GetAccount(addr1)
+			require.NoError(t, err)
+
+			objs[0].Root = account.Root
+		}
+
+		if i == 1 {
+			objs = append(objs, &state.Object{
+				Address:  addr2,
+				Balance:  big.NewInt(1),
+				CodeHash: types.EmptyCodeHash,
+				Root:     types.EmptyRootHash,
+			})
+		}
+
+		_, root := snap.Commit(objs)
+
+		return types.BytesToHash(root)
+	}
+
+	roots := []types.Hash{types.EmptyRootHash}
+	for i := int64(1); i <= 5; i++ {
+		roots = append(roots, commitBlock(roots[len(roots)-1], i))
+	}
+
+	countNodes := func() int {
+		count := 0
+		require.NoError(t, storage.IterateNodes(func(key []byte) { count++ }))
+
+		return count
+	}
+
+	// the nodes written since the previous pruning are kept
+	pruned, err := st.Prune(roots[len(roots)-1:])
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	nodes := countNodes()
+
+	// the unreachable nodes are removed once they are not recently written
+	pruned, err = st.Prune(roots[len(roots)-1:])
+	require.NoError(t, err)
+	require.NotZero(t, pruned)
+	require.Equal(t, nodes-pruned, countNodes())
+
+	// the retained state is complete
+	snap, err := st.NewSnapshotAt(roots[len(roots)-1])
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5), account.Balance)
+	require.Equal(t,
+		types.BytesToHash(big.NewInt(5).Bytes()),
+		snap.GetStorage(addr1, account.Root, slot),
+	)
+
+	account, err = snap.GetAccount(addr2)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), account.Balance)
+
+	// the removed state is not available
+	_, err = st.NewSnapshotAt(roots[1])
+	require.Error(t, err)
+
+	// the nodes written after the start of the pruning are kept
+	root := commitBlock(roots[len(roots)-1], 6)
+
+	_, err = st.Prune(roots[len(roots)-1:])
+	require.NoError(t, err)
+
+	snap, err = st.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err = snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(6), account.Balance)
+	require.Equal(t,
+		types.BytesToHash(big.NewInt(6).Bytes()),
+		snap.GetStorage(addr1, account.Root, slot),
+	)
+}
+
+func TestPruning_MissingRetainedNode(t *testing.T) {
+	t.Parallel()
+
+	storage, ok := NewMemoryStorage().(PrunableStorage)
+	require.True(t, ok)
+
+	st := NewState(NewPruningStorage(storage, hclog.NewNullLogger()))
+
+	objs := make([]*state.Object, 0, 32)
+	for i := 0; i < 32; i++ {
+		objs = append(objs, &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(1),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		})
+	}
+
+	_, rootBytes := st.NewSnapshot().Commit(objs)
+	root := types.BytesToHash(rootBytes)
+
+	// remove an inner node of the retained state
+	var inner []byte
+
+	require.NoError(t, storage.IterateNodes(func(key []byte) {
+		if inner == nil && types.BytesToHash(key) != root {
+			inner = key
+		}
+	}))
+	require.NotNil(t, inner)
+	require.NoError(t, storage.DeleteNodes([][]byte{inner}))
+
+	_, err := st.Prune([]types.Hash{root})
+	require.ErrorIs(t, err, ErrMissingTrieNode)
+
+	// the unknown state is skipped
+	_, err = st.Prune([]types.Hash{types.StringToHash("1")})
+	require.NoError(t, err)
+
+	// the pruning is supported only by the pruning storage
+	_, err = NewState(NewMemoryStorage()).Prune([]types.Hash{root})
+	require.ErrorIs(t, err, ErrPruningNotSupported)
+}
@@ -0,0 +1,557 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrInvalidRangeProof is returned when the range of the leaves doesn't match the root with the proof
+	ErrInvalidRangeProof = errors.New("invalid range proof")
+)
+
+// Lookup returns the value of the key in the trie with the given root, or nil if the key is not in the trie
+func Lookup(root types.Hash, key []byte, storage Storage) ([]byte, error) {
+	return walkProofPath(root, key, storage.Get, nil)
+}
+
+// ProveRange returns the leaves of the trie with the given root whose keys are greater or equal to the origin,
+// in the ascending order of the keys. It stops at the first key which is greater or equal to the limit,
+// or when maxResults leaves are collected. The returned proof consists of the proofs of the origin
+// and of the last returned key, it allows to verify the range with VerifyRangeProof
+func ProveRange(
+	storage Storage,
+	root types.Hash,
+	origin, limit []byte,
+	maxResults int,
+) ([][]byte, [][]byte, [][]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil, nil, nil
+	}
+
+	rootNode, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: %s", ErrMissingTrieNode, root)
+	}
+
+	collector := &rangeCollector{
+		storage:    storage,
+		origin:     bytesToHexNibbles(origin),
+		limit:      limit,
+		maxResults: maxResults,
+	}
+
+	if _, err := collector.walk(rootNode, nil); err != nil {
+		return nil, nil, nil, err
+	}
+
+	proof, err := Prove(root, origin, storage)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(collector.keys) > 0 {
+		lastProof, err := Prove(root, collector.keys[len(collector.keys)-1], storage)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		proof = mergeProofs(proof, lastProof)
+	}
+
+	return collector.keys, collector.values, proof, nil
+}
+
+// rangeCollector collects the leaves of the trie in the ascending order of the keys
+type rangeCollector struct {
+	storage    Storage
+	origin     []byte
+	limit      []byte
+	maxResults int
+
+	keys   [][]byte
+	values [][]byte
+}
+
+// walk visits the leaves under the node in the ascending order of the keys,
+// it returns true once the collection is completed
+func (c *rangeCollector) walk(node Node, path []byte) (bool, error) {
+	// skip the subtries whose keys are all lower than the origin
+	if prefixLen := len(path); prefixLen <= len(c.origin) && bytes.Compare(path, c.origin[:prefixLen]) < 0 {
+		return false, nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return false, nil
+
+	case *ValueNode:
+		if n.hash {
+			child, ok, err := GetNode(n.buf, c.storage)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				return false, fmt.Errorf("%w: %s", ErrMissingTrieNode, hex.EncodeToHex(n.buf))
+			}
+
+			return c.walk(child, path)
+		}
+
+		key := hexNibblesToBytes(path[:len(path)-1])
+
+		c.keys = append(c.keys, key)
+		c.values = append(c.values, append([]byte{}, n.buf...))
+
+		return len(c.keys) >= c.maxResults || bytes.Compare(key, c.limit) >= 0, nil
+
+	case *ShortNode:
+		return c.walk(n.child, concat(path, n.key))
+
+	case *FullNode:
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if done, err := c.walk(child, concat(path, []byte{byte(i)})); done || err != nil {
+				return done, err
+			}
+		}
+
+		return c.walk(n.value, concat(path, []byte{16}))
+
+	default:
+		return false, fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// VerifyRangeProof checks that the keys and the values are all the leaves of the trie with the given root
+// from the origin up to the last key, using the proof returned by ProveRange.
+// The proof may be empty only if the range contains all the leaves of the trie.
+// It returns true if there are more leaves in the trie after the last key
+func VerifyRangeProof(root types.Hash, origin []byte, keys, values [][]byte, proof [][]byte) (bool, error) {
+	if len(keys) != len(values) {
+		return false, fmt.Errorf("%w: keys and values mismatch", ErrInvalidRangeProof)
+	}
+
+	for i, key := range keys {
+		if len(values[i]) == 0 {
+			return false, fmt.Errorf("%w: empty value", ErrInvalidRangeProof)
+		}
+
+		if len(key) != len(origin) {
+			return false, fmt.Errorf("%w: inconsistent key length", ErrInvalidRangeProof)
+		}
+
+		if i == 0 && bytes.Compare(key, origin) < 0 {
+			return false, fmt.Errorf("%w: key lower than the origin", ErrInvalidRangeProof)
+		}
+
+		if i > 0 && bytes.Compare(keys[i-1], key) >= 0 {
+			return false, fmt.Errorf("%w: keys are not in the ascending order", ErrInvalidRangeProof)
+		}
+	}
+
+	// the range without the proof is the whole trie
+	if len(proof) == 0 {
+		txn := NewTrie().Txn(NewMemoryStorage())
+
+		for i, key := range keys {
+			txn.Insert(key, values[i])
+		}
+
+		if err := checkRangeRoot(txn, root); err != nil {
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	proofStorage := NewMemoryStorage()
+	for _, node := range proof {
+		proofStorage.Put(hashit(node), node)
+	}
+
+	// the empty range proves there are no leaves from the origin
+	if len(keys) == 0 {
+		rootNode, value, err := proofToPath(root, nil, origin, proofStorage)
+		if err != nil {
+			return false, err
+		}
+
+		if value != nil || hasRightElement(rootNode, origin) {
+			return false, fmt.Errorf("%w: more leaves available", ErrInvalidRangeProof)
+		}
+
+		return false, nil
+	}
+
+	last := keys[len(keys)-1]
+
+	rootNode, _, err := proofToPath(root, nil, origin, proofStorage)
+	if err != nil {
+		return false, err
+	}
+
+	if !bytes.Equal(origin, last) {
+		if rootNode, _, err = proofToPath(root, rootNode, last, proofStorage); err != nil {
+			return false, err
+		}
+
+		// remove the nodes between the edge paths, they are rebuilt from the leaves
+		empty, err := unsetInternal(rootNode, origin, last)
+		if err != nil {
+			return false, err
+		}
+
+		if empty {
+			rootNode = nil
+		}
+	}
+
+	txn := NewTrieWithRoot(rootNode).Txn(proofStorage)
+
+	for i, key := range keys {
+		txn.Insert(key, values[i])
+	}
+
+	if err := checkRangeRoot(txn, root); err != nil {
+		return false, err
+	}
+
+	return hasRightElement(txn.root, last), nil
+}
+
+// checkRangeRoot checks that the trie built from the range matches the root
+func checkRangeRoot(txn *Txn, root types.Hash) error {
+	hash, err := txn.Hash()
+	if err != nil {
+		return err
+	}
+
+	if types.BytesToHash(hash) != root {
+		return fmt.Errorf("%w: expected root %s, got %s", ErrInvalidRangeProof, root, types.BytesToHash(hash))
+	}
+
+	return nil
+}
+
+// proofToPath resolves the nodes on the path of the key from the proof nodes, starting from the root node
+// (or from the root hash if the root node is nil). The path may end before reaching the key
+// if the key is not in the trie. It returns the root node and the value of the key, if any
+func proofToPath(root types.Hash, rootNode Node, key []byte, proof Storage) (Node, []byte, error) {
+	resolve := func(hash []byte) (Node, error) {
+		node, ok, err := GetNode(hash, proof)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingTrieNode, hex.EncodeToHex(hash))
+		}
+
+		return node, nil
+	}
+
+	if rootNode == nil {
+		var err error
+
+		if rootNode, err = resolve(root.Bytes()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	search := bytesToHexNibbles(key)
+	parent := rootNode
+
+	for {
+		rest, child := nextOnPath(parent, search)
+
+		switch c := child.(type) {
+		case nil:
+			// the key is not in the trie, the path is resolved up to the point it diverges
+			return rootNode, nil, nil
+
+		case *ShortNode, *FullNode:
+			search, parent = rest, child
+
+		case *ValueNode:
+			if !c.hash {
+				return rootNode, c.buf, nil
+			}
+
+			resolved, err := resolve(c.buf)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// link the resolved node to the parent
+			switch p := parent.(type) {
+			case *ShortNode:
+				p.child = resolved
+			case *FullNode:
+				p.setEdge(search[0], resolved)
+			}
+
+			search, parent = rest, resolved
+		}
+	}
+}
+
+// nextOnPath returns the child of the node on the path of the search key, with the rest of the key
+func nextOnPath(node Node, search []byte) ([]byte, Node) {
+	switch n := node.(type) {
+	case *ShortNode:
+		if len(search) < len(n.key) || !bytes.Equal(n.key, search[:len(n.key)]) {
+			return nil, nil
+		}
+
+		return search[len(n.key):], n.child
+
+	case *FullNode:
+		if len(search) == 0 {
+			return nil, nil
+		}
+
+		return search[1:], n.getEdge(search[0])
+
+	default:
+		return nil, nil
+	}
+}
+
+// unsetInternal removes all the references to the nodes between the paths of the left and the right keys,
+// the edge paths have to be resolved. It returns true if the whole trie is in the range
+func unsetInternal(node Node, leftKey, rightKey []byte) (bool, error) {
+	var (
+		left, right                   = bytesToHexNibbles(leftKey), bytesToHexNibbles(rightKey)
+		pos                           int
+		parent                        Node
+		shortForkLeft, shortForkRight int
+	)
+
+findFork:
+	for {
+		switch n := node.(type) {
+		case *ShortNode:
+			n.hash = nil
+
+			shortForkLeft = compareKeyPrefix(left[pos:], n.key)
+			shortForkRight = compareKeyPrefix(right[pos:], n.key)
+
+			// either of the paths diverges from the short node, it's the fork point
+			if shortForkLeft != 0 || shortForkRight != 0 {
+				break findFork
+			}
+
+			parent, node, pos = node, n.child, pos+len(n.key)
+
+		case *FullNode:
+			n.hash = nil
+
+			// either of the paths ends or the paths diverge, it's the fork point
+			leftNode, rightNode := n.getEdge(left[pos]), n.getEdge(right[pos])
+			if leftNode == nil || rightNode == nil || leftNode != rightNode {
+				break findFork
+			}
+
+			parent, node, pos = node, leftNode, pos+1
+
+		default:
+			return false, fmt.Errorf("%w: unexpected node %T on the edge path", ErrInvalidRangeProof, n)
+		}
+	}
+
+	switch n := node.(type) {
+	case *ShortNode:
+		if (shortForkLeft == -1 && shortForkRight == -1) || (shortForkLeft == 1 && shortForkRight == 1) {
+			return false, fmt.Errorf("%w: empty range", ErrInvalidRangeProof)
+		}
+
+		// the short node is between the paths, remove it entirely
+		if shortForkLeft != 0 && shortForkRight != 0 {
+			return unsetChild(parent, left[pos-1]), nil
+		}
+
+		// only the right path diverges, the branch right to the left path is removed
+		if shortForkRight != 0 {
+			if v, ok := n.child.(*ValueNode); ok && !v.hash {
+				return unsetChild(parent, left[pos-1]), nil
+			}
+
+			return false, unset(n, n.child, left[pos:], len(n.key), false)
+		}
+
+		// only the left path diverges, the branch left to the right path is removed
+		if shortForkLeft != 0 {
+			if v, ok := n.child.(*ValueNode); ok && !v.hash {
+				return unsetChild(parent, right[pos-1]), nil
+			}
+
+			return false, unset(n, n.child, right[pos:], len(n.key), true)
+		}
+
+		return false, nil
+
+	case *FullNode:
+		for i := left[pos] + 1; i < right[pos]; i++ {
+			n.children[i] = nil
+		}
+
+		if err := unset(n, n.getEdge(left[pos]), left[pos:], 1, false); err != nil {
+			return false, err
+		}
+
+		return false, unset(n, n.getEdge(right[pos]), right[pos:], 1, true)
+	}
+
+	return false, nil
+}
+
+// unset removes the references to the nodes on one side of the path of the key, in the subtrie of the child.
+// If removeLeft is set the nodes left to the path are removed, otherwise the ones right to it
+func unset(parent Node, child Node, key []byte, pos int, removeLeft bool) error {
+	switch c := child.(type) {
+	case nil:
+		// the path ends in the parent
+		return nil
+
+	case *FullNode:
+		c.hash = nil
+
+		if removeLeft {
+			for i := 0; i < int(key[pos]); i++ {
+				c.children[i] = nil
+			}
+		} else {
+			for i := key[pos] + 1; i < 16; i++ {
+				c.children[i] = nil
+			}
+		}
+
+		return unset(c, c.getEdge(key[pos]), key, pos+1, removeLeft)
+
+	case *ShortNode:
+		c.hash = nil
+
+		if compareKeyPrefix(key[pos:], c.key) != 0 {
+			// the path diverges from the short node, the node is removed if it's on the removed side
+			cmp := bytes.Compare(c.key, key[pos:])
+			if (removeLeft && cmp < 0) || (!removeLeft && cmp > 0) {
+				unsetChild(parent, key[pos-1])
+			}
+
+			return nil
+		}
+
+		if v, ok := c.child.(*ValueNode); ok && !v.hash {
+			// the leaf is on the path, it's rebuilt from the range
+			unsetChild(parent, key[pos-1])
+
+			return nil
+		}
+
+		return unset(c, c.child, key, pos+len(c.key), removeLeft)
+
+	default:
+		return fmt.Errorf("%w: unexpected node %T on the edge path", ErrInvalidRangeProof, c)
+	}
+}
+
+// unsetChild removes the child of the full node, it returns true if there is no parent,
+// which means the whole trie has to be removed
+func unsetChild(parent Node, idx byte) bool {
+	if parent == nil {
+		return true
+	}
+
+	if full, ok := parent.(*FullNode); ok {
+		full.setEdge(idx, nil)
+	}
+
+	return false
+}
+
+// hasRightElement returns true if there is a leaf right to the path of the key
+func hasRightElement(node Node, key []byte) bool {
+	search := bytesToHexNibbles(key)
+	pos := 0
+
+	for node != nil {
+		switch n := node.(type) {
+		case *FullNode:
+			for i := search[pos] + 1; i < 16; i++ {
+				if n.children[i] != nil {
+					return true
+				}
+			}
+
+			node, pos = n.getEdge(search[pos]), pos+1
+
+		case *ShortNode:
+			if compareKeyPrefix(search[pos:], n.key) != 0 {
+				return bytes.Compare(n.key, search[pos:]) > 0
+			}
+
+			node, pos = n.child, pos+len(n.key)
+
+		default:
+			// the whole path is resolved
+			return false
+		}
+	}
+
+	return false
+}
+
+// compareKeyPrefix compares the beginning of the search key with the key of the node
+func compareKeyPrefix(search, key []byte) int {
+	if len(search) < len(key) {
+		return bytes.Compare(search, key)
+	}
+
+	return bytes.Compare(search[:len(key)], key)
+}
+
+// mergeProofs returns the union of the proofs, without the duplicated nodes
+func mergeProofs(proofs ...[][]byte) [][]byte {
+	var (
+		merged = [][]byte{}
+		seen   = map[types.Hash]struct{}{}
+	)
+
+	for _, proof := range proofs {
+		for _, node := range proof {
+			hash := types.BytesToHash(hashit(node))
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+
+			seen[hash] = struct{}{}
+			merged = append(merged, node)
+		}
+	}
+
+	return merged
+}
+
+// hexNibblesToBytes packs the nibbles (without the terminator) into bytes
+func hexNibblesToBytes(nibbles []byte) []byte {
+	key := make([]byte, len(nibbles)/2)
+
+	for i := range key {
+		key[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return key
+}
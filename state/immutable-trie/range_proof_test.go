@@ -0,0 +1,243 @@
+package itrie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var maxRangeKey = bytes.Repeat([]byte{0xff}, types.HashLength)
+
+// buildRangeTrie builds the trie with the hashed keys and returns its root with the sorted keys
+func buildRangeTrie(t *testing.T, storage Storage, count int) (types.Hash, [][]byte) {
+	t.Helper()
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = storage
+
+	keys := make([][]byte, 0, count)
+
+	for i := 0; i < count; i++ {
+		key := hashit(binary.BigEndian.AppendUint64(nil, uint64(i)))
+		keys = append(keys, key)
+
+		txn.Insert(key, rangeValue(key))
+	}
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	return types.BytesToHash(root), keys
+}
+
+func rangeValue(key []byte) []byte {
+	return append([]byte{0x1}, key[:4]...)
+}
+
+func TestRangeProof_Ranges(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys := buildRangeTrie(t, storage, 500)
+
+	for _, maxResults := range []int{1, 7, 100, 1000} {
+		var (
+			origin    = make([]byte, types.HashLength)
+			collected = [][]byte{}
+		)
+
+		for {
+			rangeKeys, values, proof, err := ProveRange(storage, root, origin, maxRangeKey, maxResults)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(rangeKeys), maxResults)
+
+			more, err := VerifyRangeProof(root, origin, rangeKeys, values, proof)
+			require.NoError(t, err)
+
+			for i, key := range rangeKeys {
+				require.Equal(t, rangeValue(key), values[i])
+			}
+
+			collected = append(collected, rangeKeys...)
+
+			if !more {
+				break
+			}
+
+			origin = incrementKey(rangeKeys[len(rangeKeys)-1])
+		}
+
+		require.Equal(t, keys, collected, "max results %d", maxResults)
+	}
+}
+
+func TestRangeProof_Limit(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys := buildRangeTrie(t, storage, 100)
+
+	// the range ends with the first key over the limit
+	rangeKeys, values, proof, err := ProveRange(storage, root, keys[10], keys[19], 1000)
+	require.NoError(t, err)
+	require.Equal(t, keys[10:20], rangeKeys)
+
+	more, err := VerifyRangeProof(root, keys[10], rangeKeys, values, proof)
+	require.NoError(t, err)
+	require.True(t, more)
+
+	// the origin between the keys
+	origin := incrementKey(keys[50])
+
+	rangeKeys, values, proof, err = ProveRange(storage, root, origin, maxRangeKey, 1000)
+	require.NoError(t, err)
+	require.Equal(t, keys[51:], rangeKeys)
+
+	more, err = VerifyRangeProof(root, origin, rangeKeys, values, proof)
+	require.NoError(t, err)
+	require.False(t, more)
+
+	// no keys after the origin
+	origin = incrementKey(keys[99])
+
+	rangeKeys, values, proof, err = ProveRange(storage, root, origin, maxRangeKey, 1000)
+	require.NoError(t, err)
+	require.Empty(t, rangeKeys)
+
+	more, err = VerifyRangeProof(root, origin, rangeKeys, values, proof)
+	require.NoError(t, err)
+	require.False(t, more)
+
+	// the whole trie without the proof
+	more, err = VerifyRangeProof(root, make([]byte, types.HashLength), keys, valuesOf(keys), nil)
+	require.NoError(t, err)
+	require.False(t, more)
+}
+
+func TestRangeProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys := buildRangeTrie(t, storage, 100)
+
+	origin := incrementKey(keys[20])
+
+	rangeKeys, values, proof, err := ProveRange(storage, root, origin, keys[60], 1000)
+	require.NoError(t, err)
+	require.Equal(t, keys[21:61], rangeKeys)
+
+	cases := []struct {
+		name   string
+		keys   [][]byte
+		values [][]byte
+		proof  [][]byte
+	}{
+		{
+			name:   "missing key in the middle",
+			keys:   append(append([][]byte{}, rangeKeys[:10]...), rangeKeys[11:]...),
+			values: append(append([][]byte{}, values[:10]...), values[11:]...),
+			proof:  proof,
+		},
+		{
+			name:   "missing first key",
+			keys:   rangeKeys[1:],
+			values: values[1:],
+			proof:  proof,
+		},
+		{
+			name:   "modified value",
+			keys:   rangeKeys,
+			values: append(append([][]byte{}, values[:5]...), append([][]byte{{0x2}}, values[6:]...)...),
+			proof:  proof,
+		},
+		{
+			name:   "extra key",
+			keys:   append(append([][]byte{}, rangeKeys...), incrementKey(rangeKeys[len(rangeKeys)-1])),
+			values: append(append([][]byte{}, values...), []byte{0x1}),
+			proof:  proof,
+		},
+		{
+			name:   "missing proof node",
+			keys:   rangeKeys,
+			values: values,
+			proof:  proof[1:],
+		},
+		{
+			name:   "unordered keys",
+			keys:   append([][]byte{rangeKeys[1], rangeKeys[0]}, rangeKeys[2:]...),
+			values: append([][]byte{values[1], values[0]}, values[2:]...),
+			proof:  proof,
+		},
+		{
+			name:   "partial range without proof",
+			keys:   rangeKeys,
+			values: values,
+			proof:  nil,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := VerifyRangeProof(root, origin, c.keys, c.values, c.proof)
+		require.Error(t, err, c.name)
+	}
+}
+
+func TestTrieBuilder(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys := buildRangeTrie(t, NewMemoryStorage(), builderFlushThreshold+100)
+
+	builder := NewTrieBuilder(storage)
+
+	for _, key := range keys {
+		require.NoError(t, builder.Insert(key, rangeValue(key)))
+	}
+
+	builtRoot, err := builder.Commit()
+	require.NoError(t, err)
+	require.Equal(t, root, builtRoot)
+
+	// the whole trie is in the storage
+	for _, key := range keys {
+		value, err := Lookup(builtRoot, key, storage)
+		require.NoError(t, err)
+		require.Equal(t, rangeValue(key), value)
+	}
+
+	emptyRoot, err := NewTrieBuilder(storage).Commit()
+	require.NoError(t, err)
+	require.Equal(t, types.EmptyRootHash, emptyRoot)
+}
+
+func valuesOf(keys [][]byte) [][]byte {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = rangeValue(key)
+	}
+
+	return values
+}
+
+// incrementKey returns the next key
+func incrementKey(key []byte) []byte {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
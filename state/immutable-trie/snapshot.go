@@ -12,6 +12,7 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+	root  types.Hash
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
@@ -23,18 +24,21 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 	)
 
 	if root == emptyStateHash {
-		trie = s.state.newTrie()
-	} else {
-		trie, err = s.state.newTrieAt(root)
-		if err != nil {
-			return types.Hash{}
-		}
+		return types.Hash{}
 	}
 
 	key := crypto.Keccak256(rawkey.Bytes())
 
-	val, ok := trie.Get(key, s.state.storage)
+	val, ok := s.getFlatStorage(addr, root, types.BytesToHash(key))
 	if !ok {
+		if trie, err = s.state.newTrieAt(root); err != nil {
+			return types.Hash{}
+		}
+
+		val, ok = trie.Get(key, s.state.storage)
+	}
+
+	if !ok || len(val) == 0 {
 		return types.Hash{}
 	}
 
@@ -53,12 +57,47 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 	return types.BytesToHash(res)
 }
 
+// getFlatStorage reads the storage slot from the flat snapshot, it returns false
+// if the snapshot doesn't have the state or the account storage root doesn't match
+func (s *Snapshot) getFlatStorage(addr types.Address, root types.Hash, slotHash types.Hash) ([]byte, bool) {
+	if s.state.flat == nil {
+		return nil, false
+	}
+
+	accountHash := types.BytesToHash(hashit(addr.Bytes()))
+
+	data, ok := s.state.flat.account(s.root, accountHash)
+	if !ok || data == nil {
+		return nil, false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil || account.Root != root {
+		return nil, false
+	}
+
+	return s.state.flat.storageSlot(s.root, accountHash, slotHash)
+}
+
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	var (
+		data []byte
+		ok   bool
+	)
+
+	if s.state.flat != nil {
+		data, ok = s.state.flat.account(s.root, types.BytesToHash(key))
+		if ok && data == nil {
+			return nil, nil
+		}
+	}
+
 	if !ok {
-		return nil, nil
+		if data, ok = s.trie.Get(key, s.state.storage); !ok {
+			return nil, nil
+		}
 	}
 
 	var account state.Account
@@ -82,10 +121,28 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	arena := stateArenaPool.Get()
 	defer stateArenaPool.Put(arena)
 
+	// diff collects the changes for the flat snapshot
+	var diff *flatDiff
+	if s.state.flat != nil {
+		diff = newFlatDiff()
+	}
+
 	for _, obj := range objs {
+		accountHash := types.BytesToHash(hashit(obj.Address.Bytes()))
+
 		if obj.Deleted {
 			tt.Delete(hashit(obj.Address.Bytes()))
+
+			if diff != nil {
+				diff.accounts[accountHash] = nil
+				diff.destructs[accountHash] = struct{}{}
+			}
 		} else {
+			if diff != nil && obj.Root == types.EmptyRootHash {
+				// the account is created, the storage of the previous one is wiped
+				diff.destructs[accountHash] = struct{}{}
+			}
+
 			account := state.Account{
 				Balance:  obj.Balance,
 				Nonce:    obj.Nonce,
@@ -106,9 +163,18 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 					k := hashit(entry.Key)
 					if entry.Deleted {
 						localTxn.Delete(k)
+
+						if diff != nil {
+							diff.setStorage(accountHash, types.BytesToHash(k), nil)
+						}
 					} else {
 						vv := arena.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
-						localTxn.Insert(k, vv.MarshalTo(nil))
+						value := vv.MarshalTo(nil)
+						localTxn.Insert(k, value)
+
+						if diff != nil {
+							diff.setStorage(accountHash, types.BytesToHash(k), value)
+						}
 					}
 				}
 
@@ -130,6 +196,10 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 
 			tt.Insert(hashit(obj.Address.Bytes()), data)
 			arena.Reset()
+
+			if diff != nil {
+				diff.accounts[accountHash] = data
+			}
 		}
 	}
 
@@ -138,9 +208,26 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	nTrie := tt.Commit()
 
 	// Write all the entries to db
-	batch.Write()
+	_ = batch.Write()
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root
+	if diff != nil {
+		if err := s.state.flat.update(s.root, types.BytesToHash(root), diff); err != nil {
+			s.state.flat.invalidate(err)
+		}
+	}
+
+	return &Snapshot{trie: nTrie, state: s.state, root: types.BytesToHash(root)}, root
+}
+
+// GetAccountProof returns the Merkle-Patricia proof of the account in the state trie (EIP-1186)
+func (s *Snapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	return Prove(s.root, hashit(addr.Bytes()), s.state.storage)
+}
+
+// GetStorageProof returns the Merkle-Patricia proof of the storage slot
+// in the storage trie with the given root (EIP-1186)
+func (s *Snapshot) GetStorageProof(root types.Hash, key types.Hash) ([][]byte, error) {
+	return Prove(root, hashit(key.Bytes()), s.state.storage)
 }
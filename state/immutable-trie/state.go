@@ -1,17 +1,26 @@
 package itrie
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrStateNotFound is returned when the root of the state is not in the storage,
+// the state was either pruned or it's not synced yet
+var ErrStateNotFound = errors.New("state not found")
+
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// flat is the flat snapshot of the recent states, nil if it's not enabled
+	flat *flatSnapshot
 }
 
 func NewState(storage Storage) *State {
@@ -26,7 +35,7 @@ func NewState(storage Storage) *State {
 }
 
 func (s *State) NewSnapshot() state.Snapshot {
-	return &Snapshot{state: s, trie: s.newTrie()}
+	return &Snapshot{state: s, trie: s.newTrie(), root: types.EmptyRootHash}
 }
 
 func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
@@ -35,7 +44,7 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	return &Snapshot{state: s, trie: t, root: root}, nil
 }
 
 func (s *State) newTrie() *Trie {
@@ -77,7 +86,7 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("state not found at hash %s", root)
+		return nil, fmt.Errorf("%w at hash %s", ErrStateNotFound, root)
 	}
 
 	t := &Trie{
@@ -90,3 +99,53 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// Prune removes the trie nodes which are not reachable from the given state roots.
+// The storage of the state has to be the pruning storage
+func (s *State) Prune(roots []types.Hash) (int, error) {
+	storage, ok := s.storage.(*PruningStorage)
+	if !ok {
+		return 0, ErrPruningNotSupported
+	}
+
+	pruned, err := storage.Prune(roots)
+
+	// the cached tries may refer to the removed nodes
+	s.cache.Purge()
+
+	return pruned, err
+}
+
+// EnableFlatSnapshot enables the flat snapshot of the state with the given root, which is read instead of the trie.
+// The snapshot is kept in the given storage, the same one the trie nodes are read from
+func (s *State) EnableFlatSnapshot(
+	storage SnapshotStorage,
+	root types.Hash,
+	diffLayers int,
+	logger hclog.Logger,
+) error {
+	flat, err := newFlatSnapshot(storage, root, diffLayers, logger)
+	if err != nil {
+		return err
+	}
+
+	s.flat = flat
+
+	return nil
+}
+
+// FlushFlatSnapshot writes the diff layers of the flat snapshot up to the state with the given root to the disk,
+// so the snapshot doesn't have to be rebuilt on the next start
+func (s *State) FlushFlatSnapshot(root types.Hash) error {
+	if s.flat == nil {
+		return nil
+	}
+
+	if err := s.flat.flush(root); err != nil {
+		s.flat.invalidate(err)
+
+		return err
+	}
+
+	return nil
+}
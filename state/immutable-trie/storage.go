@@ -1,6 +1,7 @@
 package itrie
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -20,7 +22,7 @@ var (
 
 type Batch interface {
 	Put(k, v []byte)
-	Write()
+	Write() error
 }
 
 // Storage stores the trie
@@ -49,8 +51,8 @@ func (b *KVBatch) Put(k, v []byte) {
 	b.batch.Put(k, v)
 }
 
-func (b *KVBatch) Write() {
-	_ = b.db.Write(b.batch, nil)
+func (b *KVBatch) Write() error {
+	return b.db.Write(b.batch, nil)
 }
 
 func (kv *KVStorage) SetCode(hash types.Hash, code []byte) {
@@ -82,6 +84,46 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+func (kv *KVStorage) IterateNodes(handler func(key []byte)) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		// the codes are stored with the prefix, only the nodes are keyed by the hash
+		if key := iter.Key(); len(key) == types.HashLength {
+			handler(append([]byte{}, key...))
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) DeleteNodes(keys [][]byte) error {
+	batch := &leveldb.Batch{}
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
+func (kv *KVStorage) IteratePrefix(prefix []byte, handler func(key, value []byte) error) error {
+	iter := kv.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := handler(append([]byte{}, iter.Key()...), append([]byte{}, iter.Value()...)); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) Delete(keys [][]byte) error {
+	return kv.DeleteNodes(keys)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -152,6 +194,78 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+func (m *memStorage) IterateNodes(handler func(key []byte)) error {
+	m.l.Lock()
+
+	keys := make([][]byte, 0, len(m.db))
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			m.l.Unlock()
+
+			return err
+		}
+
+		if len(key) == types.HashLength {
+			keys = append(keys, key)
+		}
+	}
+
+	m.l.Unlock()
+
+	for _, key := range keys {
+		handler(key)
+	}
+
+	return nil
+}
+
+func (m *memStorage) DeleteNodes(keys [][]byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	for _, key := range keys {
+		delete(m.db, hex.EncodeToHex(key))
+	}
+
+	return nil
+}
+
+func (m *memStorage) IteratePrefix(prefix []byte, handler func(key, value []byte) error) error {
+	m.l.Lock()
+
+	var keys, values [][]byte
+
+	for k, v := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			m.l.Unlock()
+
+			return err
+		}
+
+		if bytes.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+			values = append(values, v)
+		}
+	}
+
+	m.l.Unlock()
+
+	for i, key := range keys {
+		if err := handler(key, values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) Delete(keys [][]byte) error {
+	return m.DeleteNodes(keys)
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -165,7 +279,8 @@ func (m *memBatch) Put(p, v []byte) {
 	(*m.db)[hex.EncodeToHex(p)] = buf
 }
 
-func (m *memBatch) Write() {
+func (m *memBatch) Write() error {
+	return nil
 }
 
 // GetNode retrieves a node from storage
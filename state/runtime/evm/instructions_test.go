@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -120,6 +121,13 @@ func (m *mockHostForInstructions) GetCode(addr types.Address) []byte {
 	return m.code
 }
 
+func (m *mockHostForInstructions) AddressInAccessList(types.Address) bool {
+	return true
+}
+
+func (m *mockHostForInstructions) AddAddressToAccessList(types.Address) {
+}
+
 var (
 	addr1 = types.StringToAddress("1")
 )
@@ -728,3 +736,324 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+type mockHostForAccessList struct {
+	mockHost
+	addresses map[types.Address]struct{}
+	slots     map[types.Address]map[types.Hash]struct{}
+}
+
+func newMockHostForAccessList() *mockHostForAccessList {
+	return &mockHostForAccessList{
+		addresses: map[types.Address]struct{}{},
+		slots:     map[types.Address]map[types.Hash]struct{}{},
+	}
+}
+
+func (m *mockHostForAccessList) AddressInAccessList(addr types.Address) bool {
+	_, ok := m.addresses[addr]
+
+	return ok
+}
+
+func (m *mockHostForAccessList) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	_, addressOk := m.addresses[addr]
+	_, slotOk := m.slots[addr][slot]
+
+	return addressOk, slotOk
+}
+
+func (m *mockHostForAccessList) AddAddressToAccessList(addr types.Address) {
+	m.addresses[addr] = struct{}{}
+}
+
+func (m *mockHostForAccessList) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.AddAddressToAccessList(addr)
+
+	if _, ok := m.slots[addr]; !ok {
+		m.slots[addr] = map[types.Hash]struct{}{}
+	}
+
+	m.slots[addr][slot] = struct{}{}
+}
+
+func (m *mockHostForAccessList) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostForAccessList) GetBalance(types.Address) *big.Int {
+	return big.NewInt(1)
+}
+
+func Test_AccessListGasCosts(t *testing.T) {
+	t.Parallel()
+
+	berlinForks := chain.AllForksEnabled.At(0)
+	istanbulForks := chain.ForksInTime{EIP150: true, Istanbul: true}
+
+	tests := []struct {
+		name        string
+		op          instruction
+		config      *chain.ForksInTime
+		expectedGas []uint64
+	}{
+		{
+			name:        "SLOAD cold then warm",
+			op:          opSload,
+			config:      &berlinForks,
+			expectedGas: []uint64{coldSloadCost, warmStorageReadCost},
+		},
+		{
+			name:        "SLOAD before berlin",
+			op:          opSload,
+			config:      &istanbulForks,
+			expectedGas: []uint64{800, 800},
+		},
+		{
+			name:        "BALANCE cold then warm",
+			op:          opBalance,
+			config:      &berlinForks,
+			expectedGas: []uint64{coldAccountAccessCost, warmStorageReadCost},
+		},
+		{
+			name:        "BALANCE before berlin",
+			op:          opBalance,
+			config:      &istanbulForks,
+			expectedGas: []uint64{700, 700},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = tt.config
+			s.host = newMockHostForAccessList()
+
+			for _, expectedGas := range tt.expectedGas {
+				s.gas = 10000
+				s.push(big.NewInt(1))
+
+				tt.op(s)
+
+				require.NoError(t, s.err)
+				require.Equal(t, 10000-expectedGas, s.gas)
+
+				s.pop()
+			}
+		})
+	}
+}
+
+func Test_opPush0(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shanghai enabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &allEnabledForks
+
+		opPush0(s)
+
+		require.NoError(t, s.err)
+		require.Equal(t, 1, s.stackSize())
+		require.Equal(t, zero, s.pop())
+	})
+
+	t.Run("shanghai disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{London: true}
+
+		opPush0(s)
+
+		require.ErrorIs(t, s.err, errOpCodeNotFound)
+		require.Equal(t, 0, s.stackSize())
+	})
+}
+
+func Test_opMCopy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		config         *chain.ForksInTime
+		initMemory     []byte
+		dst, src, size uint64
+		resultMemory   []byte
+		gasConsumed    uint64
+		err            error
+	}{
+		{
+			name:       "should copy memory",
+			config:     &allEnabledForks,
+			initMemory: append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+			dst:        32,
+			src:        0,
+			size:       4,
+			resultMemory: append(
+				append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+				append([]byte{1, 2, 3, 4}, make([]byte, 28)...)...,
+			),
+			// memory expansion by one word + copy of one word
+			gasConsumed: 3 + copyGas,
+		},
+		{
+			name:         "should copy overlapping memory",
+			config:       &allEnabledForks,
+			initMemory:   append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+			dst:          1,
+			src:          0,
+			size:         4,
+			resultMemory: append([]byte{1, 1, 2, 3, 4}, make([]byte, 27)...),
+			gasConsumed:  copyGas,
+		},
+		{
+			name:         "should not copy anything if size is zero",
+			config:       &allEnabledForks,
+			initMemory:   append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+			dst:          64,
+			src:          0,
+			size:         0,
+			resultMemory: append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+			gasConsumed:  0,
+		},
+		{
+			name:         "should fail before cancun",
+			config:       &chain.ForksInTime{London: true},
+			initMemory:   append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+			dst:          32,
+			src:          0,
+			size:         4,
+			resultMemory: append([]byte{1, 2, 3, 4}, make([]byte, 28)...),
+			gasConsumed:  0,
+			err:          errOpCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.config = tt.config
+			s.gas = 1000
+			s.memory = append(s.memory, tt.initMemory...)
+			// account the gas of the initial memory
+			s.lastGasCost = 3 * uint64(len(tt.initMemory)/32)
+
+			s.push(new(big.Int).SetUint64(tt.size))
+			s.push(new(big.Int).SetUint64(tt.src))
+			s.push(new(big.Int).SetUint64(tt.dst))
+
+			opMCopy(s)
+
+			if tt.err != nil {
+				require.ErrorIs(t, s.err, tt.err)
+			} else {
+				require.NoError(t, s.err)
+			}
+
+			require.Equal(t, tt.resultMemory, s.memory)
+			require.Equal(t, 1000-tt.gasConsumed, s.gas)
+		})
+	}
+}
+
+type mockHostForTransientStorage struct {
+	mockHost
+	storage map[types.Address]map[types.Hash]types.Hash
+}
+
+func (m *mockHostForTransientStorage) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[addr][key]
+}
+
+func (m *mockHostForTransientStorage) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	if _, ok := m.storage[addr]; !ok {
+		m.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.storage[addr][key] = value
+}
+
+func Test_TransientStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key   = big.NewInt(1)
+		value = big.NewInt(10)
+	)
+
+	t.Run("should store and load the transient value", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &allEnabledForks
+		s.host = &mockHostForTransientStorage{storage: map[types.Address]map[types.Hash]types.Hash{}}
+
+		s.push(value)
+		s.push(key)
+
+		opTstore(s)
+		require.NoError(t, s.err)
+
+		s.push(key)
+
+		opTload(s)
+		require.NoError(t, s.err)
+		require.Equal(t, value, s.pop())
+	})
+
+	t.Run("should fail to store in a static call", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+		s.config = &allEnabledForks
+		s.host = &mockHostForTransientStorage{storage: map[types.Address]map[types.Hash]types.Hash{}}
+
+		s.push(value)
+		s.push(key)
+
+		opTstore(s)
+		require.ErrorIs(t, s.err, errWriteProtection)
+	})
+
+	t.Run("should fail before cancun", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &chain.ForksInTime{London: true}
+		s.host = &mockHostForTransientStorage{storage: map[types.Address]map[types.Hash]types.Hash{}}
+
+		s.push(key)
+
+		opTload(s)
+		require.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
@@ -193,3 +193,33 @@ func (d dummyHost) GetTracer() runtime.VMTracer {
 func (d dummyHost) GetRefund() uint64 {
 	return 0
 }
+
+func (d dummyHost) AddressInAccessList(addr types.Address) bool {
+	d.t.Fatalf("AddressInAccessList is not implemented")
+
+	return false
+}
+
+func (d dummyHost) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	d.t.Fatalf("SlotInAccessList is not implemented")
+
+	return false, false
+}
+
+func (d dummyHost) AddAddressToAccessList(addr types.Address) {
+	d.t.Fatalf("AddAddressToAccessList is not implemented")
+}
+
+func (d dummyHost) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	d.t.Fatalf("AddSlotToAccessList is not implemented")
+}
+
+func (d dummyHost) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	d.t.Fatalf("GetTransientState is not implemented")
+
+	return types.ZeroHash
+}
+
+func (d dummyHost) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	d.t.Fatalf("SetTransientState is not implemented")
+}
@@ -0,0 +1,281 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type Config struct {
+	OnlyTopCall bool // trace only the top-level call
+	WithLog     bool // capture the logs emitted by the calls
+}
+
+// CallLog is a log emitted during the execution of a call
+type CallLog struct {
+	Address types.Address `json:"address"`
+	Topics  []types.Hash  `json:"topics"`
+	Data    string        `json:"data"`
+}
+
+// CallFrame is a single call made during the execution of the transaction
+type CallFrame struct {
+	Type         string         `json:"type"`
+	From         types.Address  `json:"from"`
+	To           *types.Address `json:"to,omitempty"`
+	Value        string         `json:"value,omitempty"`
+	Gas          string         `json:"gas"`
+	GasUsed      string         `json:"gasUsed"`
+	Input        string         `json:"input"`
+	Output       string         `json:"output,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Logs         []CallLog      `json:"logs,omitempty"`
+	Calls        []*CallFrame   `json:"calls,omitempty"`
+}
+
+// CallTracer collects the tree of the calls made during the execution of a transaction,
+// in the format of the geth callTracer
+type CallTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	gasLimit uint64
+
+	// callStack holds the frames which are being executed, the first one is the top-level call
+	callStack []*CallFrame
+	root      *CallFrame
+
+	// depth is the depth of the call being executed, including the calls which are not traced
+	depth int
+}
+
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		Config: config,
+	}
+}
+
+func (t *CallTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *CallTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *CallTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.gasLimit = 0
+	t.callStack = nil
+	t.root = nil
+	t.depth = 0
+}
+
+func (t *CallTracer) TxStart(
+	tx *types.Transaction,
+	coinbase types.Address,
+	host tracer.RuntimeHost,
+) {
+	t.gasLimit = tx.Gas
+}
+
+func (t *CallTracer) TxEnd(gasLeft uint64) {
+	if t.root == nil {
+		return
+	}
+
+	// the top-level call reports the gas of the whole transaction
+	t.root.Gas = hex.EncodeUint64(t.gasLimit)
+	t.root.GasUsed = hex.EncodeUint64(t.gasLimit - gasLeft)
+}
+
+func (t *CallTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	t.depth = depth
+
+	if t.Config.OnlyTopCall && depth > 1 {
+		return
+	}
+
+	frame := &CallFrame{
+		Type:  callTypeToString(runtime.CallType(callType)),
+		From:  from,
+		To:    &to,
+		Gas:   hex.EncodeUint64(gas),
+		Input: hex.EncodeToHex(input),
+	}
+
+	// delegate and static calls don't transfer value
+	if value != nil && callType != int(runtime.DelegateCall) && callType != int(runtime.StaticCall) {
+		frame.Value = hex.EncodeBig(value)
+	}
+
+	if len(t.callStack) == 0 {
+		t.root = frame
+	} else {
+		parent := t.callStack[len(t.callStack)-1]
+		parent.Calls = append(parent.Calls, frame)
+	}
+
+	t.callStack = append(t.callStack, frame)
+}
+
+func (t *CallTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	t.depth = depth - 1
+
+	if (t.Config.OnlyTopCall && depth > 1) || len(t.callStack) == 0 {
+		return
+	}
+
+	frame := t.callStack[len(t.callStack)-1]
+	t.callStack = t.callStack[:len(t.callStack)-1]
+
+	frame.GasUsed = hex.EncodeUint64(gasUsed)
+
+	if len(output) > 0 {
+		frame.Output = hex.EncodeToHex(output)
+	}
+
+	if err == nil {
+		return
+	}
+
+	frame.Error = err.Error()
+
+	if errors.Is(err, runtime.ErrExecutionReverted) {
+		if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+			frame.RevertReason = reason
+		}
+	} else {
+		// the output of a failed call is discarded, unless it was reverted
+		frame.Output = ""
+	}
+
+	// the logs of the failed call and its sub calls are reverted
+	clearLogs(frame)
+}
+
+func (t *CallTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if !t.Config.WithLog || opCode < evm.LOG0 || opCode > evm.LOG4 || len(t.callStack) == 0 {
+		return
+	}
+
+	// the logs of the sub calls are not traced along with the sub calls themselves
+	if t.Config.OnlyTopCall && t.depth > 1 {
+		return
+	}
+
+	topicsCount := opCode - evm.LOG0
+	if sp < 2+topicsCount {
+		return
+	}
+
+	offset, size := stack[sp-1], stack[sp-2]
+
+	log := CallLog{
+		Address: contractAddress,
+		Topics:  make([]types.Hash, topicsCount),
+		Data:    hex.EncodeToHex(tracer.ReadMemory(memory, offset, size)),
+	}
+
+	for i := 0; i < topicsCount; i++ {
+		log.Topics[i] = types.BytesToHash(stack[sp-3-i].Bytes())
+	}
+
+	frame := t.callStack[len(t.callStack)-1]
+	frame.Logs = append(frame.Logs, log)
+}
+
+func (t *CallTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opcode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *CallTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return t.root, nil
+}
+
+func callTypeToString(callType runtime.CallType) string {
+	switch callType {
+	case runtime.Call:
+		return "CALL"
+	case runtime.CallCode:
+		return "CALLCODE"
+	case runtime.DelegateCall:
+		return "DELEGATECALL"
+	case runtime.StaticCall:
+		return "STATICCALL"
+	case runtime.Create:
+		return "CREATE"
+	case runtime.Create2:
+		return "CREATE2"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// clearLogs removes the logs of the frame and all of its sub calls
+func clearLogs(frame *CallFrame) {
+	frame.Logs = nil
+
+	for _, call := range frame.Calls {
+		clearLogs(call)
+	}
+}
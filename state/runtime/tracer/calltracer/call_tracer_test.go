@@ -0,0 +1,242 @@
+package calltracer
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom  = types.StringToAddress("1")
+	testTo    = types.StringToAddress("2")
+	testInner = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+// logStack builds the stack of the LOG1 operation emitting the given data with the given topic
+func logStack(size int, topic types.Hash) []*big.Int {
+	return []*big.Int{
+		new(big.Int).SetBytes(topic.Bytes()), // topic
+		big.NewInt(int64(size)),              // size
+		big.NewInt(0),                        // offset
+	}
+}
+
+func TestCallTracer_NestedCalls(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{WithLog: true})
+
+	topic := types.StringToHash("10")
+	memory := []byte{0x1, 0x2}
+
+	tracer.TxStart(&types.Transaction{Gas: 100000}, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(10), []byte{0x1})
+	tracer.CaptureState(memory, logStack(2, topic), evm.LOG1, testTo, 3, nil, &mockState{})
+
+	// successful inner call
+	tracer.CallStart(2, testTo, testInner, int(runtime.StaticCall), 5000, big.NewInt(0), nil)
+	tracer.CaptureState(memory, logStack(1, topic), evm.LOG1, testInner, 3, nil, &mockState{})
+	tracer.CallEnd(2, []byte{0x5}, 300, nil)
+
+	// failed inner call
+	tracer.CallStart(2, testTo, testInner, int(runtime.DelegateCall), 5000, big.NewInt(0), nil)
+	tracer.CaptureState(memory, logStack(1, topic), evm.LOG1, testInner, 3, nil, &mockState{})
+	tracer.CallEnd(2, nil, 5000, errors.New("out of gas"))
+
+	tracer.CallEnd(1, []byte{0x2}, 8000, nil)
+	tracer.TxEnd(70000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	to := testTo
+	inner := testInner
+
+	assert.Equal(t, &CallFrame{
+		Type:    "CALL",
+		From:    testFrom,
+		To:      &to,
+		Value:   "0xa",
+		Gas:     "0x186a0",
+		GasUsed: "0x7530",
+		Input:   "0x01",
+		Output:  "0x02",
+		Logs: []CallLog{
+			{Address: testTo, Topics: []types.Hash{topic}, Data: "0x0102"},
+		},
+		Calls: []*CallFrame{
+			{
+				Type:    "STATICCALL",
+				From:    testTo,
+				To:      &inner,
+				Gas:     "0x1388",
+				GasUsed: "0x12c",
+				Input:   "0x",
+				Output:  "0x05",
+				Logs: []CallLog{
+					{Address: testInner, Topics: []types.Hash{topic}, Data: "0x01"},
+				},
+			},
+			{
+				Type:    "DELEGATECALL",
+				From:    testTo,
+				To:      &inner,
+				Gas:     "0x1388",
+				GasUsed: "0x1388",
+				Input:   "0x",
+				Error:   "out of gas",
+			},
+		},
+	}, res)
+}
+
+func TestCallTracer_OnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{OnlyTopCall: true})
+
+	tracer.TxStart(&types.Transaction{Gas: 50000}, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 29000, big.NewInt(0), nil)
+	tracer.CallStart(2, testTo, testInner, int(runtime.Call), 5000, big.NewInt(0), nil)
+	tracer.CallEnd(2, nil, 100, nil)
+	tracer.CallEnd(1, nil, 200, nil)
+	tracer.TxEnd(20000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	require.True(t, ok)
+
+	assert.Equal(t, "0x7530", frame.GasUsed)
+	assert.Empty(t, frame.Calls)
+}
+
+func TestCallTracer_OnlyTopCallLogs(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{OnlyTopCall: true, WithLog: true})
+
+	topic := types.StringToHash("10")
+	memory := []byte{0x1, 0x2}
+
+	tracer.TxStart(&types.Transaction{Gas: 50000}, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 29000, big.NewInt(0), nil)
+
+	// the log of the sub call is not traced
+	tracer.CallStart(2, testTo, testInner, int(runtime.Call), 5000, big.NewInt(0), nil)
+	tracer.CaptureState(memory, logStack(1, topic), evm.LOG1, testInner, 3, nil, &mockState{})
+	tracer.CallEnd(2, nil, 100, nil)
+
+	tracer.CaptureState(memory, logStack(2, topic), evm.LOG1, testTo, 3, nil, &mockState{})
+	tracer.CallEnd(1, nil, 200, nil)
+	tracer.TxEnd(20000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	require.True(t, ok)
+
+	assert.Equal(t, []CallLog{{Address: testTo, Topics: []types.Hash{topic}, Data: "0x0102"}}, frame.Logs)
+}
+
+func TestCallTracer_RevertedLogs(t *testing.T) {
+	t.Parallel()
+
+	topic := types.StringToHash("10")
+	memory := []byte{0x1, 0x2}
+
+	for _, onlyTopCall := range []bool{false, true} {
+		tracer := NewCallTracer(Config{OnlyTopCall: onlyTopCall, WithLog: true})
+
+		tracer.TxStart(&types.Transaction{Gas: 50000}, types.ZeroAddress, nil)
+		tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 29000, big.NewInt(0), nil)
+		tracer.CaptureState(memory, logStack(2, topic), evm.LOG1, testTo, 3, nil, &mockState{})
+
+		tracer.CallStart(2, testTo, testInner, int(runtime.Call), 5000, big.NewInt(0), nil)
+		tracer.CaptureState(memory, logStack(1, topic), evm.LOG1, testInner, 3, nil, &mockState{})
+		tracer.CallEnd(2, nil, 100, nil)
+
+		// the logs of the reverted call and its sub calls are discarded
+		tracer.CallEnd(1, nil, 200, runtime.ErrExecutionReverted)
+		tracer.TxEnd(20000)
+
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+
+		frame, ok := res.(*CallFrame)
+		require.True(t, ok)
+
+		assert.Empty(t, frame.Logs)
+
+		for _, call := range frame.Calls {
+			assert.Empty(t, call.Logs)
+		}
+	}
+}
+
+func TestCallTracer_RevertReason(t *testing.T) {
+	t.Parallel()
+
+	// Error(string) with the "not allowed" reason
+	output, err := hex.DecodeString("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000b" +
+		"6e6f7420616c6c6f776564000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(&types.Transaction{Gas: 50000}, types.ZeroAddress, nil)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 29000, big.NewInt(0), nil)
+	tracer.CallEnd(1, output, 200, runtime.ErrExecutionReverted)
+	tracer.TxEnd(20000)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	require.True(t, ok)
+
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), frame.Error)
+	assert.Equal(t, "not allowed", frame.RevertReason)
+	assert.NotEmpty(t, frame.Output)
+}
+
+func TestCallTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+	state := &mockState{}
+
+	tracer.Cancel(errors.New("timeout"))
+	tracer.CaptureState(nil, nil, int(evm.STOP), testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "timeout")
+
+	tracer.Clear()
+
+	_, err = tracer.GetResult()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,29 @@
+package tracer
+
+import "math/big"
+
+// MaxMemorySize is the upper bound of the memory which can be read by the tracers,
+// the expansion of the memory beyond it costs more gas than any block can provide
+const MaxMemorySize = 64 * 1024 * 1024
+
+// ReadMemory returns a copy of the memory area, the part which is not expanded yet is zero filled.
+// It returns nil if the area is beyond the memory which can be expanded
+func ReadMemory(memory []byte, offset, size *big.Int) []byte {
+	if size.Sign() == 0 {
+		return []byte{}
+	}
+
+	if !offset.IsUint64() || !size.IsUint64() ||
+		size.Uint64() > MaxMemorySize || offset.Uint64() > MaxMemorySize-size.Uint64() {
+		// the memory can't be expanded that much, the operation is going to fail
+		return nil
+	}
+
+	res := make([]byte, size.Uint64())
+
+	if offset.Uint64() < uint64(len(memory)) {
+		copy(res, memory[offset.Uint64():])
+	}
+
+	return res
+}
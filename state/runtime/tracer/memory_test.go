@@ -0,0 +1,40 @@
+package tracer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMemory(t *testing.T) {
+	t.Parallel()
+
+	maxUint64 := new(big.Int).SetUint64(^uint64(0))
+
+	tests := []struct {
+		name     string
+		memory   []byte
+		offset   *big.Int
+		size     *big.Int
+		expected []byte
+	}{
+		{"empty", []byte{1, 2}, big.NewInt(0), big.NewInt(0), []byte{}},
+		{"within memory", []byte{1, 2, 3}, big.NewInt(1), big.NewInt(2), []byte{2, 3}},
+		{"not expanded part is zero filled", []byte{1, 2}, big.NewInt(1), big.NewInt(3), []byte{2, 0, 0}},
+		{"size too big", []byte{1}, big.NewInt(0), big.NewInt(MaxMemorySize + 1), nil},
+		{"offset and size too big", []byte{1}, big.NewInt(MaxMemorySize), big.NewInt(1), nil},
+		{"offset and size overflow", []byte{1}, big.NewInt(1), maxUint64, nil},
+		{"size overflow", []byte{1}, big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), 64), nil},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, ReadMemory(test.memory, test.offset, test.size))
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package prestatetracer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type Config struct {
+	DiffMode bool // return the difference between the pre and post state
+}
+
+// Account is the state of the account touched by the transaction
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+
+	// empty is set if the account didn't exist before the transaction
+	empty bool
+}
+
+// State is the set of the accounts touched by the transaction
+type State map[types.Address]*Account
+
+// DiffResult is the result of the tracer in the diff mode
+type DiffResult struct {
+	Pre  State `json:"pre"`
+	Post State `json:"post"`
+}
+
+// PrestateTracer collects the state of the accounts touched by a transaction,
+// in the format of the geth prestateTracer
+type PrestateTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	// host gives access to the state, it's kept from the start of the transaction
+	// to read the post state of the accounts at the end of the transaction
+	host tracer.RuntimeHost
+
+	pre     State
+	post    State
+	created map[types.Address]bool
+	deleted map[types.Address]bool
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:  config,
+		pre:     State{},
+		post:    State{},
+		created: map[types.Address]bool{},
+		deleted: map[types.Address]bool{},
+	}
+}
+
+func (t *PrestateTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *PrestateTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *PrestateTracer) Clear() {
+	t.reason = nil
+	t.interrupt = false
+	t.host = nil
+	t.pre = State{}
+	t.post = State{}
+	t.created = map[types.Address]bool{}
+	t.deleted = map[types.Address]bool{}
+}
+
+func (t *PrestateTracer) TxStart(
+	tx *types.Transaction,
+	coinbase types.Address,
+	host tracer.RuntimeHost,
+) {
+	t.host = host
+
+	t.lookupAccount(tx.From)
+	t.lookupAccount(coinbase)
+
+	if tx.To != nil {
+		t.lookupAccount(*tx.To)
+
+		return
+	}
+
+	to := crypto.CreateAddress(tx.From, host.GetNonce(tx.From))
+	t.lookupAccount(to)
+	t.created[to] = true
+}
+
+func (t *PrestateTracer) TxEnd(gasLeft uint64) {
+	if t.host == nil {
+		return
+	}
+
+	if t.Config.DiffMode {
+		t.processDiffState()
+	}
+
+	// the accounts created by the transaction didn't have any state before
+	for addr := range t.created {
+		if account, ok := t.pre[addr]; ok && account.empty {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+func (t *PrestateTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *PrestateTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+}
+
+func (t *PrestateTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if t.host == nil {
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+
+	case evm.SELFDESTRUCT:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		t.deleted[contractAddress] = true
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp < 2 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+
+	case evm.CREATE:
+		addr := crypto.CreateAddress(contractAddress, t.host.GetNonce(contractAddress))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+
+	case evm.CREATE2:
+		if sp < 4 {
+			return
+		}
+
+		offset, size, salt := stack[sp-2], stack[sp-3], stack[sp-4]
+
+		initCode := tracer.ReadMemory(memory, offset, size)
+		if initCode == nil {
+			// the memory can't be expanded to hold the init code, the creation is going to fail
+			return
+		}
+
+		addr := crypto.CreateAddress2(contractAddress, types.BytesToHash(salt.Bytes()), initCode)
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	}
+}
+
+func (t *PrestateTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opcode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *PrestateTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	if t.Config.DiffMode {
+		return &DiffResult{
+			Pre:  t.pre,
+			Post: t.post,
+		}, nil
+	}
+
+	return t.pre, nil
+}
+
+// lookupAccount saves the state of the account if it's not tracked yet
+func (t *PrestateTracer) lookupAccount(addr types.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+
+	balance := t.host.GetBalance(addr)
+	nonce := t.host.GetNonce(addr)
+	code := t.host.GetCode(addr)
+
+	account := &Account{
+		Balance: hex.EncodeBig(balance),
+		Nonce:   nonce,
+		Storage: map[types.Hash]types.Hash{},
+		empty:   balance.Sign() == 0 && nonce == 0 && len(code) == 0,
+	}
+
+	if len(code) > 0 {
+		account.Code = hex.EncodeToHex(code)
+	}
+
+	t.pre[addr] = account
+}
+
+// lookupStorage saves the value of the storage slot if it's not tracked yet.
+// The slot is accessed for the first time, so its current value is the value before the transaction
+func (t *PrestateTracer) lookupStorage(addr types.Address, slot types.Hash) {
+	t.lookupAccount(addr)
+
+	storage := t.pre[addr].Storage
+	if _, ok := storage[slot]; ok {
+		return
+	}
+
+	storage[slot] = t.host.GetStorage(addr, slot)
+}
+
+// processDiffState fills the post state with the modified fields of the accounts
+// and removes the unmodified fields from the pre state
+func (t *PrestateTracer) processDiffState() {
+	for addr, preAccount := range t.pre {
+		// the deleted accounts don't have any post state
+		if t.deleted[addr] {
+			continue
+		}
+
+		var (
+			modified    bool
+			postAccount = &Account{Storage: map[types.Hash]types.Hash{}}
+		)
+
+		if balance := hex.EncodeBig(t.host.GetBalance(addr)); balance != preAccount.Balance {
+			modified = true
+			postAccount.Balance = balance
+		}
+
+		if nonce := t.host.GetNonce(addr); nonce != preAccount.Nonce {
+			modified = true
+			postAccount.Nonce = nonce
+		}
+
+		if code := t.host.GetCode(addr); len(code) > 0 && hex.EncodeToHex(code) != preAccount.Code {
+			modified = true
+			postAccount.Code = hex.EncodeToHex(code)
+		}
+
+		for slot, preValue := range preAccount.Storage {
+			postValue := t.host.GetStorage(addr, slot)
+			if postValue == preValue {
+				// the unmodified slots are omitted in both states
+				delete(preAccount.Storage, slot)
+
+				continue
+			}
+
+			modified = true
+
+			if postValue != types.ZeroHash {
+				postAccount.Storage[slot] = postValue
+			}
+		}
+
+		if modified {
+			t.post[addr] = postAccount
+		} else {
+			// the unmodified accounts are omitted in both states
+			delete(t.pre, addr)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testTo       = types.StringToAddress("2")
+	testCoinbase = types.StringToAddress("3")
+	testSlot     = types.StringToHash("1")
+
+	errTestCancel = errors.New("timeout")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+// mockHost is the in-memory state which can be modified during the test
+type mockHost struct {
+	accounts map[types.Address]*mockAccount
+}
+
+func (m *mockHost) account(addr types.Address) *mockAccount {
+	account, ok := m.accounts[addr]
+	if !ok {
+		account = &mockAccount{balance: big.NewInt(0), storage: map[types.Hash]types.Hash{}}
+		m.accounts[addr] = account
+	}
+
+	return account
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return m.account(addr).storage[slot]
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return m.account(addr).balance
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.account(addr).nonce
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.account(addr).code
+}
+
+func newTestHost() *mockHost {
+	return &mockHost{
+		accounts: map[types.Address]*mockAccount{
+			testFrom: {balance: big.NewInt(1000), nonce: 1, storage: map[types.Hash]types.Hash{}},
+			testTo: {
+				balance: big.NewInt(0),
+				code:    []byte{0x1},
+				storage: map[types.Hash]types.Hash{testSlot: types.StringToHash("5")},
+			},
+		},
+	}
+}
+
+// runTransaction simulates the transaction calling testTo, which stores a value into testSlot
+func runTransaction(host *mockHost, tracer *PrestateTracer) {
+	tracer.TxStart(&types.Transaction{From: testFrom, To: &testTo}, testCoinbase, host)
+
+	stack := []*big.Int{
+		big.NewInt(7),                           // value
+		new(big.Int).SetBytes(testSlot.Bytes()), // slot
+	}
+	tracer.CaptureState(nil, stack, evm.SSTORE, testTo, 2, host, &mockState{})
+
+	host.account(testFrom).nonce++
+	host.account(testFrom).balance = big.NewInt(900)
+	host.account(testCoinbase).balance = big.NewInt(100)
+	host.account(testTo).storage[testSlot] = types.BytesToHash(stack[0].Bytes())
+
+	tracer.TxEnd(0)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{})
+	runTransaction(newTestHost(), tracer)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(t, State{
+		testFrom: {
+			Balance: "0x3e8",
+			Nonce:   1,
+			Storage: map[types.Hash]types.Hash{},
+		},
+		testTo: {
+			Balance: "0x0",
+			Code:    "0x01",
+			Storage: map[types.Hash]types.Hash{testSlot: types.StringToHash("5")},
+		},
+		testCoinbase: {
+			Balance: "0x0",
+			Storage: map[types.Hash]types.Hash{},
+			empty:   true,
+		},
+	}, res)
+}
+
+func TestPrestateTracer_DiffMode(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+	runTransaction(newTestHost(), tracer)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	assert.Equal(t, &DiffResult{
+		Pre: State{
+			testFrom: {
+				Balance: "0x3e8",
+				Nonce:   1,
+				Storage: map[types.Hash]types.Hash{},
+			},
+			testTo: {
+				Balance: "0x0",
+				Code:    "0x01",
+				Storage: map[types.Hash]types.Hash{testSlot: types.StringToHash("5")},
+			},
+			testCoinbase: {
+				Balance: "0x0",
+				Storage: map[types.Hash]types.Hash{},
+				empty:   true,
+			},
+		},
+		Post: State{
+			testFrom: {
+				Balance: "0x384",
+				Nonce:   2,
+				Storage: map[types.Hash]types.Hash{},
+			},
+			testTo: {
+				Storage: map[types.Hash]types.Hash{testSlot: types.StringToHash("7")},
+			},
+			testCoinbase: {
+				Balance: "0x64",
+				Storage: map[types.Hash]types.Hash{},
+			},
+		},
+	}, res)
+}
+
+func TestPrestateTracer_ContractCreation(t *testing.T) {
+	t.Parallel()
+
+	host := newTestHost()
+	tracer := NewPrestateTracer(Config{})
+
+	created := crypto.CreateAddress(testFrom, 1)
+
+	tracer.TxStart(&types.Transaction{From: testFrom}, testCoinbase, host)
+	host.account(created).code = []byte{0x2}
+	tracer.TxEnd(0)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	state, ok := res.(State)
+	require.True(t, ok)
+
+	assert.Contains(t, state, testFrom)
+	assert.NotContains(t, state, created)
+}
+
+func TestPrestateTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{})
+	state := &mockState{}
+
+	tracer.Cancel(errTestCancel)
+	tracer.CaptureState(nil, nil, evm.SLOAD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, errTestCancel)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestPrestateTracer_Create2(t *testing.T) {
+	t.Parallel()
+
+	salt := types.StringToHash("7")
+
+	// create2 builds the stack of CREATE2 with the init code at the given memory area
+	create2 := func(offset, size *big.Int) []*big.Int {
+		return []*big.Int{new(big.Int).SetBytes(salt.Bytes()), size, offset, big.NewInt(0)}
+	}
+
+	t.Run("init code in memory", func(t *testing.T) {
+		t.Parallel()
+
+		host := newTestHost()
+		tracer := NewPrestateTracer(Config{})
+		tracer.TxStart(&types.Transaction{From: testFrom, To: &testTo}, testCoinbase, host)
+
+		tracer.CaptureState([]byte{0x1, 0x2, 0x3}, create2(big.NewInt(1), big.NewInt(2)), evm.CREATE2,
+			testTo, 4, host, &mockState{})
+
+		assert.True(t, tracer.created[crypto.CreateAddress2(testTo, salt, []byte{0x2, 0x3})])
+	})
+
+	t.Run("init code in unexpanded memory", func(t *testing.T) {
+		t.Parallel()
+
+		host := newTestHost()
+		tracer := NewPrestateTracer(Config{})
+		tracer.TxStart(&types.Transaction{From: testFrom, To: &testTo}, testCoinbase, host)
+
+		// the memory is expanded by the creation, the not expanded part is zero filled
+		tracer.CaptureState([]byte{0x1}, create2(big.NewInt(0), big.NewInt(3)), evm.CREATE2,
+			testTo, 4, host, &mockState{})
+
+		assert.True(t, tracer.created[crypto.CreateAddress2(testTo, salt, []byte{0x1, 0x0, 0x0})])
+	})
+
+	t.Run("huge offset", func(t *testing.T) {
+		t.Parallel()
+
+		host := newTestHost()
+		tracer := NewPrestateTracer(Config{})
+		tracer.TxStart(&types.Transaction{From: testFrom, To: &testTo}, testCoinbase, host)
+
+		// the offset and the size overflow, the creation is going to fail
+		tracer.CaptureState([]byte{0x1}, create2(new(big.Int).SetUint64(^uint64(0)), big.NewInt(1)), evm.CREATE2,
+			testTo, 4, host, &mockState{})
+
+		assert.Empty(t, tracer.created)
+	})
+}
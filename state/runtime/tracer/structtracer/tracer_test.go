@@ -43,6 +43,18 @@ func (m *mockHost) GetStorage(a types.Address, h types.Hash) types.Hash {
 	return m.getStorageFunc(a, h)
 }
 
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
 func TestStructLogErrorString(t *testing.T) {
 	t.Parallel()
 
@@ -182,7 +194,7 @@ func TestStructTracerTxStart(t *testing.T) {
 
 	tracer := NewStructTracer(testEmptyConfig)
 
-	tracer.TxStart(gasLimit)
+	tracer.TxStart(&types.Transaction{Gas: gasLimit}, types.ZeroAddress, nil)
 
 	assert.Equal(
 		t,
@@ -209,7 +221,7 @@ func TestStructTracerTxEnd(t *testing.T) {
 
 	tracer := NewStructTracer(testEmptyConfig)
 
-	tracer.TxStart(gasLimit)
+	tracer.TxStart(&types.Transaction{Gas: gasLimit}, types.ZeroAddress, nil)
 	tracer.TxEnd(gasLeft)
 
 	assert.Equal(
@@ -306,7 +318,7 @@ func TestStructTracerCallEnd(t *testing.T) {
 
 			tracer := NewStructTracer(testEmptyConfig)
 
-			tracer.CallEnd(test.depth, test.output, test.err)
+			tracer.CallEnd(test.depth, test.output, 0, test.err)
 
 			assert.Equal(
 				t,
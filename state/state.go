@@ -23,6 +23,13 @@ type Snapshot interface {
 	Commit(objs []*Object) (Snapshot, []byte)
 }
 
+// Prover is implemented by the snapshots able to generate the Merkle proofs
+// of the accounts and the storage slots (EIP-1186)
+type Prover interface {
+	GetAccountProof(addr types.Address) ([][]byte, error)
+	GetStorageProof(root types.Hash, key types.Hash) ([][]byte, error)
+}
+
 // Account is the account reference in the ethereum state
 type Account struct {
 	Nonce    uint64
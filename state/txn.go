@@ -28,6 +28,12 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the prefix of the access list entries (EIP-2929) in the trie
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
+
+	// transientStorageIndex is the prefix of the transient storage entries (EIP-1153) in the trie
+	transientStorageIndex = types.BytesToHash([]byte{5}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -272,13 +278,19 @@ func (txn *Txn) SetStorage(
 	if original == value {
 		if original == types.ZeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -468,6 +480,89 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64)
 }
 
+// Access list
+
+func accessListKey(addr types.Address, slot *types.Hash) []byte {
+	key := make([]byte, 0, len(accessListIndex)+types.AddressLength+types.HashLength)
+	key = append(key, accessListIndex...)
+	key = append(key, addr.Bytes()...)
+
+	if slot != nil {
+		key = append(key, slot.Bytes()...)
+	}
+
+	return key
+}
+
+// AddressInAccessList returns true if the address is in the access list
+func (txn *Txn) AddressInAccessList(addr types.Address) bool {
+	_, exists := txn.txn.Get(accessListKey(addr, nil))
+
+	return exists
+}
+
+// SlotInAccessList returns whether the address and the (address, slot) pair are in the access list
+func (txn *Txn) SlotInAccessList(addr types.Address, slot types.Hash) (addressOk bool, slotOk bool) {
+	_, addressOk = txn.txn.Get(accessListKey(addr, nil))
+	_, slotOk = txn.txn.Get(accessListKey(addr, &slot))
+
+	return addressOk, slotOk
+}
+
+// AddAddressToAccessList adds the address to the access list
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	txn.txn.Insert(accessListKey(addr, nil), struct{}{})
+}
+
+// AddSlotToAccessList adds the (address, slot) pair to the access list
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.txn.Insert(accessListKey(addr, nil), struct{}{})
+	txn.txn.Insert(accessListKey(addr, &slot), struct{}{})
+}
+
+// ClearAccessList removes all the entries from the access list
+func (txn *Txn) ClearAccessList() {
+	txn.txn.DeletePrefix(accessListIndex)
+}
+
+// Transient storage
+
+func transientStorageKey(addr types.Address, key types.Hash) []byte {
+	k := make([]byte, 0, len(transientStorageIndex)+types.AddressLength+types.HashLength)
+	k = append(k, transientStorageIndex...)
+	k = append(k, addr.Bytes()...)
+	k = append(k, key.Bytes()...)
+
+	return k
+}
+
+// GetTransientState returns the value of the transient storage slot of the address
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	val, exists := txn.txn.Get(transientStorageKey(addr, key))
+	if !exists {
+		return types.Hash{}
+	}
+
+	//nolint:forcetypeassert
+	return val.(types.Hash)
+}
+
+// SetTransientState sets the value of the transient storage slot of the address
+func (txn *Txn) SetTransientState(addr types.Address, key, value types.Hash) {
+	if value == types.ZeroHash {
+		txn.txn.Delete(transientStorageKey(addr, key))
+
+		return
+	}
+
+	txn.txn.Insert(transientStorageKey(addr, key), value)
+}
+
+// ClearTransientStorage removes all the entries from the transient storage
+func (txn *Txn) ClearTransientStorage() {
+	txn.txn.DeletePrefix(transientStorageIndex)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -572,6 +667,12 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 	// delete refunds
 	txn.txn.Delete(refundIndex)
 
+	// delete access list
+	txn.ClearAccessList()
+
+	// delete transient storage
+	txn.ClearTransientStorage()
+
 	return nil
 }
 
@@ -70,3 +70,51 @@ func TestSnapshotUpdateData(t *testing.T) {
 	assert.NoError(t, txn.RevertToSnapshot(ss))
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestAccessListRevertToSnapshot(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.AddAddressToAccessList(addr1)
+
+	ss := txn.Snapshot()
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addressOk, slotOk := txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addressOk)
+	assert.True(t, slotOk)
+
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+
+	addressOk, slotOk = txn.SlotInAccessList(addr2, hash1)
+	assert.False(t, addressOk)
+	assert.False(t, slotOk)
+	assert.True(t, txn.AddressInAccessList(addr1))
+
+	// access list is not a part of the committed state
+	objs, err := txn.Commit(false)
+	assert.NoError(t, err)
+	assert.Empty(t, objs)
+	assert.False(t, txn.AddressInAccessList(addr1))
+}
+
+func TestTransientStorage(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr1, hash1, hash2)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+
+	ss := txn.Snapshot()
+	txn.SetTransientState(addr1, hash1, hash1)
+	txn.SetTransientState(addr2, hash1, hash1)
+
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr2, hash1))
+
+	// transient storage is not a part of the committed state
+	objs, err := txn.Commit(false)
+	assert.NoError(t, err)
+	assert.Empty(t, objs)
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr1, hash1))
+}
@@ -24,6 +24,7 @@ const (
 	SyncPeerClientLoggerName = "sync-peer-client"
 	statusTopicName          = "syncer/status/0.1"
 	defaultTimeoutForStatus  = 10 * time.Second
+	defaultTimeoutForState   = 30 * time.Second
 )
 
 type syncPeerClient struct {
@@ -292,6 +293,11 @@ func (m *syncPeerClient) CloseStream(peerID peer.ID) error {
 	return m.network.CloseProtocolStream(syncerProto, peerID)
 }
 
+// PenalizePeer decreases the reputation score of the peer for its misbehavior
+func (m *syncPeerClient) PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string) {
+	m.network.PenalizePeer(peerID, penalty, reason)
+}
+
 // GetBlocks returns a stream of blocks from given height to peer's latest
 func (m *syncPeerClient) GetBlocks(
 	peerID peer.ID,
@@ -347,6 +353,87 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetAccountRange fetches the accounts of the state from the origin up to the limit with the range proof
+func (m *syncPeerClient) GetAccountRange(
+	peerID peer.ID,
+	root types.Hash,
+	origin, limit []byte,
+) (*TrieRange, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	resp, err := clt.GetAccountRange(timeoutCtx, &proto.GetAccountRangeRequest{
+		Root:       root.Bytes(),
+		Origin:     origin,
+		Limit:      limit,
+		MaxResults: maxRangeResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromProtoTrieRange(resp), nil
+}
+
+// GetStorageRange fetches the storage slots of the account from the origin up to the limit with the range proof
+func (m *syncPeerClient) GetStorageRange(
+	peerID peer.ID,
+	root types.Hash,
+	account, origin, limit []byte,
+) (*TrieRange, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	resp, err := clt.GetStorageRange(timeoutCtx, &proto.GetStorageRangeRequest{
+		Root:       root.Bytes(),
+		Account:    account,
+		Origin:     origin,
+		Limit:      limit,
+		MaxResults: maxRangeResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromProtoTrieRange(resp), nil
+}
+
+// GetBytecodes fetches the bytecodes by their hashes
+func (m *syncPeerClient) GetBytecodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	req := &proto.GetBytecodesRequest{
+		Hashes: make([][]byte, len(hashes)),
+	}
+
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	resp, err := clt.GetBytecodes(timeoutCtx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Codes, nil
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -369,6 +456,15 @@ func fromProto(protoBlock *proto.Block) (*types.Block, error) {
 	return block, nil
 }
 
+// fromProtoTrieRange converts proto.TrieRange -> TrieRange
+func fromProtoTrieRange(protoRange *proto.TrieRange) *TrieRange {
+	return &TrieRange{
+		Keys:   protoRange.Keys,
+		Values: protoRange.Values,
+		Proof:  protoRange.Proof,
+	}
+}
+
 func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
 	blockCh := make(chan *types.Block)
 	errorCh := make(chan error, 1)
@@ -22,6 +22,12 @@ type SyncPeerClient interface {
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns the accounts of the state trie in the range with the range proof
+	GetAccountRange(ctx context.Context, in *GetAccountRangeRequest, opts ...grpc.CallOption) (*TrieRange, error)
+	// Returns the storage slots of the account storage trie in the range with the range proof
+	GetStorageRange(ctx context.Context, in *GetStorageRangeRequest, opts ...grpc.CallOption) (*TrieRange, error)
+	// Returns the bytecodes by their hashes
+	GetBytecodes(ctx context.Context, in *GetBytecodesRequest, opts ...grpc.CallOption) (*Bytecodes, error)
 }
 
 type syncPeerClient struct {
@@ -73,6 +79,33 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetAccountRange(ctx context.Context, in *GetAccountRangeRequest, opts ...grpc.CallOption) (*TrieRange, error) {
+	out := new(TrieRange)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetAccountRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetStorageRange(ctx context.Context, in *GetStorageRangeRequest, opts ...grpc.CallOption) (*TrieRange, error) {
+	out := new(TrieRange)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetStorageRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetBytecodes(ctx context.Context, in *GetBytecodesRequest, opts ...grpc.CallOption) (*Bytecodes, error) {
+	out := new(Bytecodes)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetBytecodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
@@ -81,6 +114,12 @@ type SyncPeerServer interface {
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns the accounts of the state trie in the range with the range proof
+	GetAccountRange(context.Context, *GetAccountRangeRequest) (*TrieRange, error)
+	// Returns the storage slots of the account storage trie in the range with the range proof
+	GetStorageRange(context.Context, *GetStorageRangeRequest) (*TrieRange, error)
+	// Returns the bytecodes by their hashes
+	GetBytecodes(context.Context, *GetBytecodesRequest) (*Bytecodes, error)
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +133,15 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetAccountRange(context.Context, *GetAccountRangeRequest) (*TrieRange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountRange not implemented")
+}
+func (UnimplementedSyncPeerServer) GetStorageRange(context.Context, *GetStorageRangeRequest) (*TrieRange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageRange not implemented")
+}
+func (UnimplementedSyncPeerServer) GetBytecodes(context.Context, *GetBytecodesRequest) (*Bytecodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBytecodes not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -146,6 +194,60 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncPeer_GetAccountRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetAccountRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetAccountRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetAccountRange(ctx, req.(*GetAccountRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetStorageRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetStorageRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetStorageRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetStorageRange(ctx, req.(*GetStorageRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetBytecodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBytecodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetBytecodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetBytecodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetBytecodes(ctx, req.(*GetBytecodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
@@ -154,6 +256,18 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _SyncPeer_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetAccountRange",
+			Handler:    _SyncPeer_GetAccountRange_Handler,
+		},
+		{
+			MethodName: "GetStorageRange",
+			Handler:    _SyncPeer_GetStorageRange_Handler,
+		},
+		{
+			MethodName: "GetBytecodes",
+			Handler:    _SyncPeer_GetBytecodes_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
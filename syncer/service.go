@@ -3,33 +3,49 @@ package syncer
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+const (
+	// maxRangeResults is the maximum number of the trie leaves returned in a range
+	maxRangeResults = 1024
+	// maxBytecodes is the maximum number of the bytecodes returned at once
+	maxBytecodes = 64
+)
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound          = errors.New("block not found")
+	ErrStateStorageNotEnabled = errors.New("state storage is not enabled")
+	ErrInvalidRangeRequest    = errors.New("invalid range request")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain   Blockchain       // reference to the blockchain module
+	network      Network          // reference to the network module
+	stateStorage itrie.Storage    // reference to the state storage
+	stream       *grpc.GrpcStream // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 ) SyncPeerService {
 	return &syncPeerService{
-		blockchain: blockchain,
-		network:    network,
+		blockchain:   blockchain,
+		network:      network,
+		stateStorage: stateStorage,
 	}
 }
 
@@ -91,6 +107,104 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
+// GetAccountRange is a gRPC endpoint to return the accounts of the state in the range with the range proof
+func (s *syncPeerService) GetAccountRange(
+	ctx context.Context,
+	req *proto.GetAccountRangeRequest,
+) (*proto.TrieRange, error) {
+	if s.stateStorage == nil {
+		return nil, ErrStateStorageNotEnabled
+	}
+
+	if len(req.Root) != types.HashLength {
+		return nil, ErrInvalidRangeRequest
+	}
+
+	return s.proveRange(types.BytesToHash(req.Root), req.Origin, req.Limit, req.MaxResults)
+}
+
+// GetStorageRange is a gRPC endpoint to return the storage slots of the account in the range with the range proof
+func (s *syncPeerService) GetStorageRange(
+	ctx context.Context,
+	req *proto.GetStorageRangeRequest,
+) (*proto.TrieRange, error) {
+	if s.stateStorage == nil {
+		return nil, ErrStateStorageNotEnabled
+	}
+
+	if len(req.Root) != types.HashLength || len(req.Account) != types.HashLength {
+		return nil, ErrInvalidRangeRequest
+	}
+
+	data, err := itrie.Lookup(types.BytesToHash(req.Root), req.Account, s.stateStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, fmt.Errorf("account %s not found", hex.EncodeToHex(req.Account))
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, err
+	}
+
+	return s.proveRange(account.Root, req.Origin, req.Limit, req.MaxResults)
+}
+
+// GetBytecodes is a gRPC endpoint to return the bytecodes by their hashes,
+// the bytecode which is not found is returned empty
+func (s *syncPeerService) GetBytecodes(
+	ctx context.Context,
+	req *proto.GetBytecodesRequest,
+) (*proto.Bytecodes, error) {
+	if s.stateStorage == nil {
+		return nil, ErrStateStorageNotEnabled
+	}
+
+	hashes := req.Hashes
+	if len(hashes) > maxBytecodes {
+		hashes = hashes[:maxBytecodes]
+	}
+
+	codes := make([][]byte, len(hashes))
+
+	for i, hash := range hashes {
+		codes[i], _ = s.stateStorage.GetCode(types.BytesToHash(hash))
+	}
+
+	return &proto.Bytecodes{
+		Codes: codes,
+	}, nil
+}
+
+// proveRange returns the leaves of the trie in the range with the range proof
+func (s *syncPeerService) proveRange(
+	root types.Hash,
+	origin, limit []byte,
+	maxResults uint64,
+) (*proto.TrieRange, error) {
+	if len(origin) != types.HashLength || len(limit) != types.HashLength {
+		return nil, ErrInvalidRangeRequest
+	}
+
+	if maxResults == 0 || maxResults > maxRangeResults {
+		maxResults = maxRangeResults
+	}
+
+	keys, values, proof, err := itrie.ProveRange(s.stateStorage, root, origin, limit, int(maxResults))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.TrieRange{
+		Keys:   keys,
+		Values: values,
+		Proof:  proof,
+	}, nil
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
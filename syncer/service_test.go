@@ -4,12 +4,17 @@ import (
 	"context"
 	"io"
 	"log"
+	"math/big"
 	"net"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
@@ -154,3 +159,170 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+// newTestState commits the accounts with the storage and the code to the storage and returns the state root,
+// some accounts share the same storage and code
+func newTestState(t *testing.T, storage itrie.Storage, accounts int) (types.Hash, []*state.Object) {
+	t.Helper()
+
+	objs := make([]*state.Object, accounts)
+
+	for i := range objs {
+		obj := &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		}
+
+		if i%3 == 0 {
+			for j := 0; j < 20+i%2; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash(big.NewInt(int64(j)).Bytes()).Bytes(),
+					Val: big.NewInt(int64(j + 1)).Bytes(),
+				})
+			}
+		}
+
+		if i%5 == 0 {
+			obj.Code = []byte{0x60, byte(i % 2)}
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(obj.Code))
+			obj.DirtyCode = true
+		}
+
+		objs[i] = obj
+	}
+
+	_, root := itrie.NewState(storage).NewSnapshot().Commit(objs)
+
+	return types.BytesToHash(root), objs
+}
+
+func Test_syncPeerService_GetAccountRange(t *testing.T) {
+	t.Parallel()
+
+	storage := itrie.NewMemoryStorage()
+	root, objs := newTestState(t, storage, 30)
+
+	client := newMockGrpcClient(t, &syncPeerService{stateStorage: storage})
+
+	origin := make([]byte, types.HashLength)
+
+	resp, err := client.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin,
+		Limit:  maxRangeLimit,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Keys, len(objs))
+
+	more, err := itrie.VerifyRangeProof(root, origin, resp.Keys, resp.Values, resp.Proof)
+	require.NoError(t, err)
+	require.False(t, more)
+
+	resp, err = client.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root:       root.Bytes(),
+		Origin:     origin,
+		Limit:      maxRangeLimit,
+		MaxResults: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Keys, 10)
+
+	more, err = itrie.VerifyRangeProof(root, origin, resp.Keys, resp.Values, resp.Proof)
+	require.NoError(t, err)
+	require.True(t, more)
+
+	_, err = client.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin[1:],
+		Limit:  maxRangeLimit,
+	})
+	require.ErrorContains(t, err, ErrInvalidRangeRequest.Error())
+
+	_, err = newMockGrpcClient(t, &syncPeerService{}).GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin,
+		Limit:  maxRangeLimit,
+	})
+	require.ErrorContains(t, err, ErrStateStorageNotEnabled.Error())
+}
+
+func Test_syncPeerService_GetStorageRange(t *testing.T) {
+	t.Parallel()
+
+	storage := itrie.NewMemoryStorage()
+	root, objs := newTestState(t, storage, 30)
+
+	client := newMockGrpcClient(t, &syncPeerService{stateStorage: storage})
+
+	snap, err := itrie.NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(objs[3].Address)
+	require.NoError(t, err)
+
+	origin := make([]byte, types.HashLength)
+
+	resp, err := client.GetStorageRange(context.Background(), &proto.GetStorageRangeRequest{
+		Root:    root.Bytes(),
+		Account: crypto.Keccak256(objs[3].Address.Bytes()),
+		Origin:  origin,
+		Limit:   maxRangeLimit,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Keys, len(objs[3].Storage))
+
+	more, err := itrie.VerifyRangeProof(account.Root, origin, resp.Keys, resp.Values, resp.Proof)
+	require.NoError(t, err)
+	require.False(t, more)
+
+	// the account without the storage
+	resp, err = client.GetStorageRange(context.Background(), &proto.GetStorageRangeRequest{
+		Root:    root.Bytes(),
+		Account: crypto.Keccak256(objs[1].Address.Bytes()),
+		Origin:  origin,
+		Limit:   maxRangeLimit,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Keys)
+
+	// the unknown account
+	_, err = client.GetStorageRange(context.Background(), &proto.GetStorageRangeRequest{
+		Root:    root.Bytes(),
+		Account: crypto.Keccak256([]byte{0x1}),
+		Origin:  origin,
+		Limit:   maxRangeLimit,
+	})
+	require.ErrorContains(t, err, "not found")
+}
+
+func Test_syncPeerService_GetBytecodes(t *testing.T) {
+	t.Parallel()
+
+	storage := itrie.NewMemoryStorage()
+	_, objs := newTestState(t, storage, 30)
+
+	client := newMockGrpcClient(t, &syncPeerService{stateStorage: storage})
+
+	resp, err := client.GetBytecodes(context.Background(), &proto.GetBytecodesRequest{
+		Hashes: [][]byte{objs[0].CodeHash.Bytes(), types.ZeroHash.Bytes(), objs[5].CodeHash.Bytes()},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Codes, 3)
+	require.Equal(t, objs[0].Code, resp.Codes[0])
+	require.Empty(t, resp.Codes[1])
+	require.Equal(t, objs[5].Code, resp.Codes[2])
+
+	hashes := make([][]byte, maxBytecodes+10)
+	for i := range hashes {
+		hashes[i] = objs[0].CodeHash.Bytes()
+	}
+
+	resp, err = client.GetBytecodes(context.Background(), &proto.GetBytecodesRequest{
+		Hashes: hashes,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Codes, maxBytecodes)
+}
@@ -0,0 +1,326 @@
+package syncer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// stateSyncHistoryBlocks is the number of the blocks up to the trusted block which are fetched with it,
+// so the hashes of the recent blocks are available for the execution of the next blocks
+const stateSyncHistoryBlocks = 256
+
+var (
+	errSyncerClosed         = errors.New("syncer is closed")
+	errTrustedBlockMismatch = errors.New("trusted block hash mismatch")
+	errInvalidBlockSequence = errors.New("invalid block sequence")
+	errStateRootMismatch    = errors.New("synced state root mismatch")
+	errInvalidBytecode      = errors.New("invalid bytecode")
+	errInvalidBytecodesLen  = errors.New("invalid number of bytecodes")
+
+	// maxRangeLimit is the limit of the range which includes all the keys
+	maxRangeLimit = bytes.Repeat([]byte{0xff}, types.HashLength)
+)
+
+// syncTrustedState downloads the state of the trusted block and writes the blocks up to it,
+// the state sync is done only once, when the local chain contains only the genesis
+func (s *syncer) syncTrustedState() error {
+	if s.stateSync == nil || s.blockchain.Header().Number != 0 {
+		return nil
+	}
+
+	skipList := make(map[peer.ID]bool)
+
+	for {
+		// Wait for a new event to arrive
+		if _, ok := <-s.newStatusCh; !ok {
+			return errSyncerClosed
+		}
+
+		bestPeer := s.peerMap.BestPeer(skipList)
+		if bestPeer == nil {
+			// Empty skipList map if there are no best peers
+			skipList = make(map[peer.ID]bool)
+
+			continue
+		}
+
+		// the best peer doesn't have the trusted block yet
+		if bestPeer.Number < s.stateSync.TrustedBlockNumber {
+			continue
+		}
+
+		if err := s.syncTrustedStateWithPeer(bestPeer.ID); err != nil {
+			s.logger.Warn("failed to sync the trusted state with peer, try to next one", "peer ID", bestPeer.ID, "err", err)
+
+			skipList[bestPeer.ID] = true
+
+			continue
+		}
+
+		return nil
+	}
+}
+
+// syncTrustedStateWithPeer fetches the trusted block with the blocks before it and its state from the given peer
+func (s *syncer) syncTrustedStateWithPeer(peerID peer.ID) error {
+	blocks, err := s.fetchTrustedBlocks(peerID)
+	if err != nil {
+		return err
+	}
+
+	trusted := blocks[len(blocks)-1].Header
+
+	s.logger.Info("syncing the state of the trusted block",
+		"peer ID", peerID, "number", trusted.Number, "state root", trusted.StateRoot)
+
+	if err := s.syncState(peerID, trusted.StateRoot); err != nil {
+		return err
+	}
+
+	if err := s.blockchain.WriteTrustedBlocks(blocks, syncerName); err != nil {
+		return err
+	}
+
+	// the states of the blocks before the trusted one are not available on the node
+	itrie.SetLowestState(s.stateStorage, trusted.Number)
+
+	return nil
+}
+
+// fetchTrustedBlocks fetches the trusted block and the history blocks before it,
+// the blocks are linked by their hashes to the trusted block hash
+func (s *syncer) fetchTrustedBlocks(peerID peer.ID) ([]*types.Block, error) {
+	number := s.stateSync.TrustedBlockNumber
+
+	from := uint64(1)
+	if number > stateSyncHistoryBlocks {
+		from = number - stateSyncHistoryBlocks + 1
+	}
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, from, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+
+		// the peer streams the blocks up to its latest one
+		go func() {
+			for range blockCh {
+			}
+		}()
+	}()
+
+	blocks := make([]*types.Block, 0, number-from+1)
+
+	for uint64(len(blocks)) < number-from+1 {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				return nil, fmt.Errorf("stream closed before the trusted block, last block %d", from+uint64(len(blocks))-1)
+			}
+
+			if block.Number() != from+uint64(len(blocks)) {
+				return nil, errInvalidBlockSequence
+			}
+
+			if len(blocks) > 0 && block.ParentHash() != blocks[len(blocks)-1].Hash() {
+				return nil, errInvalidBlockSequence
+			}
+
+			blocks = append(blocks, block)
+		case <-time.After(s.blockTimeout):
+			return nil, errTimeout
+		}
+	}
+
+	if trusted := blocks[len(blocks)-1]; trusted.Hash() != s.stateSync.TrustedBlockHash {
+		return nil, fmt.Errorf("%w: expected %s, got %s",
+			errTrustedBlockMismatch, s.stateSync.TrustedBlockHash, trusted.Hash())
+	}
+
+	return blocks, nil
+}
+
+// syncState downloads the state with the given root, the accounts and the storage slots are verified
+// with the range proofs and the bytecodes with their hashes
+func (s *syncer) syncState(peerID peer.ID, root types.Hash) error {
+	var (
+		builder = itrie.NewTrieBuilder(s.stateStorage)
+		origin  = make([]byte, types.HashLength)
+
+		// the storage tries and the bytecodes are shared by the accounts
+		syncedStorage = make(map[types.Hash]struct{})
+		codeHashes    = make(map[types.Hash]struct{})
+
+		accounts int
+	)
+
+	for {
+		accountRange, err := s.syncPeerClient.GetAccountRange(peerID, root, origin, maxRangeLimit)
+		if err != nil {
+			return err
+		}
+
+		more, err := itrie.VerifyRangeProof(root, origin, accountRange.Keys, accountRange.Values, accountRange.Proof)
+		if err != nil {
+			return err
+		}
+
+		for i, key := range accountRange.Keys {
+			var account state.Account
+			if err := account.UnmarshalRlp(accountRange.Values[i]); err != nil {
+				return err
+			}
+
+			if _, ok := syncedStorage[account.Root]; !ok && account.Root != types.EmptyRootHash {
+				if err := s.syncStorage(peerID, root, key, account.Root); err != nil {
+					return err
+				}
+
+				syncedStorage[account.Root] = struct{}{}
+			}
+
+			if codeHash := types.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash {
+				codeHashes[codeHash] = struct{}{}
+			}
+
+			if err := builder.Insert(key, accountRange.Values[i]); err != nil {
+				return err
+			}
+		}
+
+		accounts += len(accountRange.Keys)
+
+		s.logger.Debug("accounts synced", "peer ID", peerID, "accounts", accounts)
+
+		if !more || len(accountRange.Keys) == 0 {
+			break
+		}
+
+		origin = nextRangeKey(accountRange.Keys[len(accountRange.Keys)-1])
+	}
+
+	syncedRoot, err := builder.Commit()
+	if err != nil {
+		return err
+	}
+
+	if syncedRoot != root {
+		return fmt.Errorf("%w: expected %s, got %s", errStateRootMismatch, root, syncedRoot)
+	}
+
+	if err := s.syncBytecodes(peerID, codeHashes); err != nil {
+		return err
+	}
+
+	s.logger.Info("state synced", "peer ID", peerID, "root", root, "accounts", accounts,
+		"storage tries", len(syncedStorage), "bytecodes", len(codeHashes))
+
+	return nil
+}
+
+// syncStorage downloads the storage trie of the account
+func (s *syncer) syncStorage(peerID peer.ID, stateRoot types.Hash, account []byte, root types.Hash) error {
+	var (
+		builder = itrie.NewTrieBuilder(s.stateStorage)
+		origin  = make([]byte, types.HashLength)
+	)
+
+	for {
+		storageRange, err := s.syncPeerClient.GetStorageRange(peerID, stateRoot, account, origin, maxRangeLimit)
+		if err != nil {
+			return err
+		}
+
+		more, err := itrie.VerifyRangeProof(root, origin, storageRange.Keys, storageRange.Values, storageRange.Proof)
+		if err != nil {
+			return err
+		}
+
+		for i, key := range storageRange.Keys {
+			if err := builder.Insert(key, storageRange.Values[i]); err != nil {
+				return err
+			}
+		}
+
+		if !more || len(storageRange.Keys) == 0 {
+			break
+		}
+
+		origin = nextRangeKey(storageRange.Keys[len(storageRange.Keys)-1])
+	}
+
+	syncedRoot, err := builder.Commit()
+	if err != nil {
+		return err
+	}
+
+	if syncedRoot != root {
+		return fmt.Errorf("%w: storage of account %s, expected %s, got %s",
+			errStateRootMismatch, types.BytesToHash(account), root, syncedRoot)
+	}
+
+	return nil
+}
+
+// syncBytecodes downloads the bytecodes by their hashes
+func (s *syncer) syncBytecodes(peerID peer.ID, codeHashes map[types.Hash]struct{}) error {
+	hashes := make([]types.Hash, 0, len(codeHashes))
+	for hash := range codeHashes {
+		hashes = append(hashes, hash)
+	}
+
+	for len(hashes) > 0 {
+		batch := hashes
+		if len(batch) > maxBytecodes {
+			batch = batch[:maxBytecodes]
+		}
+
+		codes, err := s.syncPeerClient.GetBytecodes(peerID, batch)
+		if err != nil {
+			return err
+		}
+
+		if len(codes) != len(batch) {
+			return fmt.Errorf("%w: expected %d, got %d", errInvalidBytecodesLen, len(batch), len(codes))
+		}
+
+		for i, code := range codes {
+			if hash := types.BytesToHash(crypto.Keccak256(code)); hash != batch[i] {
+				return fmt.Errorf("%w: expected %s, got %s", errInvalidBytecode, batch[i], hash)
+			}
+
+			s.stateStorage.SetCode(batch[i], code)
+		}
+
+		hashes = hashes[len(batch):]
+	}
+
+	return nil
+}
+
+// nextRangeKey returns the key following the given one
+func nextRangeKey(key []byte) []byte {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
@@ -0,0 +1,303 @@
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// createLinkedBlocks creates the chain of the blocks, the state root is set to the last one
+func createLinkedBlocks(num int, stateRoot types.Hash) []*types.Block {
+	blocks := make([]*types.Block, num)
+	parentHash := types.ZeroHash
+
+	for i := range blocks {
+		header := &types.Header{
+			Number:     uint64(i + 1),
+			ParentHash: parentHash,
+			TxRoot:     types.EmptyRootHash,
+			Sha3Uncles: types.EmptyUncleHash,
+		}
+
+		if i == num-1 {
+			header.StateRoot = stateRoot
+		}
+
+		header.ComputeHash()
+
+		blocks[i] = &types.Block{Header: header}
+		parentHash = header.Hash
+	}
+
+	return blocks
+}
+
+// newStateSyncPeerClient creates the sync peer client serving the blocks and the state from the given storage
+func newStateSyncPeerClient(blocks []*types.Block, storage itrie.Storage) *mockSyncPeerClient {
+	service := &syncPeerService{stateStorage: storage}
+
+	return &mockSyncPeerClient{
+		getBlocksHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.Block, error) {
+			blockCh := make(chan *types.Block, len(blocks))
+
+			for _, block := range blocks[from-1:] {
+				blockCh <- block
+			}
+
+			close(blockCh)
+
+			return blockCh, nil
+		},
+		getAccountRangeHandler: func(_ peer.ID, root types.Hash, origin, limit []byte) (*TrieRange, error) {
+			resp, err := service.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+				Root:       root.Bytes(),
+				Origin:     origin,
+				Limit:      limit,
+				MaxResults: 4,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return fromProtoTrieRange(resp), nil
+		},
+		getStorageRangeHandler: func(_ peer.ID, root types.Hash, account, origin, limit []byte) (*TrieRange, error) {
+			resp, err := service.GetStorageRange(context.Background(), &proto.GetStorageRangeRequest{
+				Root:       root.Bytes(),
+				Account:    account,
+				Origin:     origin,
+				Limit:      limit,
+				MaxResults: 8,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return fromProtoTrieRange(resp), nil
+		},
+		getBytecodesHandler: func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+			req := &proto.GetBytecodesRequest{}
+			for _, hash := range hashes {
+				req.Hashes = append(req.Hashes, hash.Bytes())
+			}
+
+			resp, err := service.GetBytecodes(context.Background(), req)
+			if err != nil {
+				return nil, err
+			}
+
+			return resp.Codes, nil
+		},
+	}
+}
+
+func Test_syncTrustedStateWithPeer(t *testing.T) {
+	t.Parallel()
+
+	remoteStorage := itrie.NewMemoryStorage()
+	root, objs := newTestState(t, remoteStorage, 30)
+
+	blocks := createLinkedBlocks(300, root)
+	trusted := blocks[len(blocks)-1]
+
+	t.Run("should sync the state of the trusted block", func(t *testing.T) {
+		t.Parallel()
+
+		var written []*types.Block
+
+		localStorage := itrie.NewMemoryStorage()
+		syncer := NewTestSyncer(
+			nil,
+			&mockBlockchain{
+				writeTrustedBlocksHandler: func(blocks []*types.Block) error {
+					written = blocks
+
+					return nil
+				},
+			},
+			time.Second,
+			newStateSyncPeerClient(blocks, remoteStorage),
+			&mockProgression{},
+		)
+
+		syncer.stateStorage = localStorage
+		syncer.stateSync = &StateSyncConfig{
+			TrustedBlockNumber: trusted.Number(),
+			TrustedBlockHash:   trusted.Hash(),
+		}
+
+		require.NoError(t, syncer.syncTrustedStateWithPeer(peer.ID("A")))
+
+		require.Len(t, written, stateSyncHistoryBlocks)
+		require.Equal(t, trusted.Number()-stateSyncHistoryBlocks+1, written[0].Number())
+		require.Equal(t, trusted.Hash(), written[len(written)-1].Hash())
+		require.Equal(t, trusted.Number(), itrie.GetLowestState(localStorage))
+
+		remote, err := itrie.NewState(remoteStorage).NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		local, err := itrie.NewState(localStorage).NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		for _, obj := range objs {
+			account, err := local.GetAccount(obj.Address)
+			require.NoError(t, err)
+			require.Equal(t, obj.Nonce, account.Nonce)
+
+			for _, slot := range obj.Storage {
+				key := types.BytesToHash(slot.Key)
+				require.Equal(t, remote.GetStorage(obj.Address, account.Root, key),
+					local.GetStorage(obj.Address, account.Root, key))
+			}
+
+			if obj.DirtyCode {
+				code, ok := local.GetCode(obj.CodeHash)
+				require.True(t, ok)
+				require.Equal(t, obj.Code, code)
+			}
+		}
+	})
+
+	t.Run("should reject the blocks not matching the trusted block", func(t *testing.T) {
+		t.Parallel()
+
+		client := newStateSyncPeerClient(blocks, remoteStorage)
+		syncer := NewTestSyncer(nil, &mockBlockchain{}, time.Second, client, &mockProgression{})
+
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.stateSync = &StateSyncConfig{
+			TrustedBlockNumber: trusted.Number(),
+			TrustedBlockHash:   blocks[0].Hash(),
+		}
+
+		require.ErrorIs(t, syncer.syncTrustedStateWithPeer(peer.ID("A")), errTrustedBlockMismatch)
+	})
+
+	t.Run("should reject the incomplete account range", func(t *testing.T) {
+		t.Parallel()
+
+		client := newStateSyncPeerClient(blocks, remoteStorage)
+		getAccountRange := client.getAccountRangeHandler
+		client.getAccountRangeHandler = func(id peer.ID, root types.Hash, origin, limit []byte) (*TrieRange, error) {
+			accountRange, err := getAccountRange(id, root, origin, limit)
+			if err != nil {
+				return nil, err
+			}
+
+			accountRange.Keys = accountRange.Keys[1:]
+			accountRange.Values = accountRange.Values[1:]
+
+			return accountRange, nil
+		}
+
+		syncer := NewTestSyncer(nil, &mockBlockchain{}, time.Second, client, &mockProgression{})
+
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.stateSync = &StateSyncConfig{
+			TrustedBlockNumber: trusted.Number(),
+			TrustedBlockHash:   trusted.Hash(),
+		}
+
+		require.ErrorIs(t, syncer.syncTrustedStateWithPeer(peer.ID("A")), itrie.ErrInvalidRangeProof)
+	})
+
+	t.Run("should reject the invalid bytecode", func(t *testing.T) {
+		t.Parallel()
+
+		client := newStateSyncPeerClient(blocks, remoteStorage)
+		client.getBytecodesHandler = func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+			return make([][]byte, len(hashes)), nil
+		}
+
+		syncer := NewTestSyncer(nil, &mockBlockchain{}, time.Second, client, &mockProgression{})
+
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.stateSync = &StateSyncConfig{
+			TrustedBlockNumber: trusted.Number(),
+			TrustedBlockHash:   trusted.Hash(),
+		}
+
+		require.ErrorIs(t, syncer.syncTrustedStateWithPeer(peer.ID("A")), errInvalidBytecode)
+	})
+}
+
+func Test_syncTrustedState(t *testing.T) {
+	t.Parallel()
+
+	remoteStorage := itrie.NewMemoryStorage()
+	root, _ := newTestState(t, remoteStorage, 10)
+
+	blocks := createLinkedBlocks(20, root)
+	trusted := blocks[len(blocks)-1]
+
+	t.Run("should skip the state sync if the chain isn't empty", func(t *testing.T) {
+		t.Parallel()
+
+		syncer := NewTestSyncer(
+			nil,
+			&mockBlockchain{headerHandler: newSimpleHeaderHandler(1)},
+			time.Second,
+			&mockSyncPeerClient{},
+			&mockProgression{},
+		)
+
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.stateSync = &StateSyncConfig{
+			TrustedBlockNumber: trusted.Number(),
+			TrustedBlockHash:   trusted.Hash(),
+		}
+
+		require.NoError(t, syncer.syncTrustedState())
+	})
+
+	t.Run("should sync the state with the peer having the trusted block", func(t *testing.T) {
+		t.Parallel()
+
+		var written []*types.Block
+
+		syncer := NewTestSyncer(
+			nil,
+			&mockBlockchain{
+				headerHandler: newSimpleHeaderHandler(0),
+				writeTrustedBlocksHandler: func(blocks []*types.Block) error {
+					written = blocks
+
+					return nil
+				},
+			},
+			time.Second,
+			newStateSyncPeerClient(blocks, remoteStorage),
+			&mockProgression{},
+		)
+
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.stateSync = &StateSyncConfig{
+			TrustedBlockNumber: trusted.Number(),
+			TrustedBlockHash:   trusted.Hash(),
+		}
+
+		syncer.peerMap.Put(&NoForkPeer{ID: peer.ID("A"), Number: trusted.Number() - 1})
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- syncer.syncTrustedState()
+		}()
+
+		// the peer doesn't have the trusted block yet
+		syncer.newStatusCh <- struct{}{}
+
+		syncer.peerMap.Put(&NoForkPeer{ID: peer.ID("A"), Number: trusted.Number()})
+		syncer.newStatusCh <- struct{}{}
+
+		require.NoError(t, <-errCh)
+		require.Len(t, written, len(blocks))
+		require.Equal(t, trusted.Hash(), written[len(written)-1].Hash())
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
@@ -36,6 +38,11 @@ type syncer struct {
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
+	// Storage of the synced state and the configuration of the state sync,
+	// the state sync is disabled if the configuration is nil
+	stateStorage itrie.Storage
+	stateSync    *StateSyncConfig
+
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
 }
@@ -44,15 +51,19 @@ func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
+	stateSync *StateSyncConfig,
 	blockTimeout time.Duration,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, stateStorage),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
 		blockTimeout:    blockTimeout,
+		stateStorage:    stateStorage,
+		stateSync:       stateSync,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
@@ -159,8 +170,13 @@ func (s *syncer) HasSyncPeer() bool {
 	return bestPeer != nil && bestPeer.Number > header.Number
 }
 
-// Sync syncs block with the best peer until callback returns true
+// Sync syncs block with the best peer until callback returns true,
+// the state of the trusted block is synced first if the state sync is enabled
 func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
+	if err := s.syncTrustedState(); err != nil {
+		return err
+	}
+
 	localLatest := s.blockchain.Header().Number
 	skipList := make(map[peer.ID]bool)
 
@@ -242,6 +258,7 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.F
 			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
 			if err != nil {
 				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+				s.syncPeerClient.PenalizePeer(peerID, network.PenaltyInvalidBlock, "invalid block")
 
 				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
 			}
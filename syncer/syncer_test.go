@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -42,6 +43,7 @@ type mockBlockchain struct {
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	writeTrustedBlocksHandler   func([]*types.Block) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -68,6 +70,10 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) WriteTrustedBlocks(blocks []*types.Block, s string) error {
+	return m.writeTrustedBlocksHandler(blocks)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -92,6 +98,11 @@ type mockSyncPeerClient struct {
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+	getAccountRangeHandler                func(peer.ID, types.Hash, []byte, []byte) (*TrieRange, error)
+	getStorageRangeHandler                func(peer.ID, types.Hash, []byte, []byte, []byte) (*TrieRange, error)
+	getBytecodesHandler                   func(peer.ID, []types.Hash) ([][]byte, error)
+
+	penalizedPeers []peer.ID
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -132,6 +143,30 @@ func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
 
+func (m *mockSyncPeerClient) PenalizePeer(peerID peer.ID, _ network.Penalty, _ string) {
+	m.penalizedPeers = append(m.penalizedPeers, peerID)
+}
+
+func (m *mockSyncPeerClient) GetAccountRange(
+	id peer.ID,
+	root types.Hash,
+	origin, limit []byte,
+) (*TrieRange, error) {
+	return m.getAccountRangeHandler(id, root, origin, limit)
+}
+
+func (m *mockSyncPeerClient) GetStorageRange(
+	id peer.ID,
+	root types.Hash,
+	account, origin, limit []byte,
+) (*TrieRange, error) {
+	return m.getStorageRangeHandler(id, root, account, origin, limit)
+}
+
+func (m *mockSyncPeerClient) GetBytecodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getBytecodesHandler(id, hashes)
+}
+
 func GetAllElementsFromPeerMap(t *testing.T, p *PeerMap) []*NoForkPeer {
 	t.Helper()
 
@@ -702,6 +737,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		lastSyncedBlockNumber uint64
 		shouldTerminate       bool
 		err                   error
+		penalized             bool
 	}{
 		{
 			name:            "should sync blocks to the latest successfully",
@@ -769,6 +805,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			lastSyncedBlockNumber: 5,
 			shouldTerminate:       false,
 			err:                   errInvalidBlock,
+			penalized:             true,
 		},
 		{
 			name:            "should return error if block insertion is failed",
@@ -827,6 +864,10 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			var (
 				syncedBlocks = make([]*types.Block, 0, len(test.blocks))
 
+				syncPeerClient = &mockSyncPeerClient{
+					getBlocksHandler: test.getBlocksHandler,
+				}
+
 				syncer = NewTestSyncer(
 					nil,
 					&mockBlockchain{
@@ -843,9 +884,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 						},
 					},
 					test.blockTimeout,
-					&mockSyncPeerClient{
-						getBlocksHandler: test.getBlocksHandler,
-					},
+					syncPeerClient,
 					&mockProgression{},
 				)
 			)
@@ -856,6 +895,13 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 			assert.Equal(t, test.shouldTerminate, shouldTerminate)
 			assert.ErrorIs(t, err, test.err)
 			assert.Equal(t, test.blocks, syncedBlocks)
+
+			// the peer sending the invalid block is penalized
+			if test.penalized {
+				assert.Equal(t, []peer.ID{peer.ID("X")}, syncPeerClient.penalizedPeers)
+			} else {
+				assert.Empty(t, syncPeerClient.penalizedPeers)
+			}
 		})
 	}
 }
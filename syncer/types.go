@@ -31,6 +31,8 @@ type Blockchain interface {
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// WriteTrustedBlocks writes the given blocks on top of the genesis without executing them
+	WriteTrustedBlocks([]*types.Block, string) error
 }
 
 type Network interface {
@@ -55,6 +57,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// PenalizePeer decreases the reputation score of the peer for its misbehavior
+	PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string)
 }
 
 type Syncer interface {
@@ -105,8 +109,32 @@ type SyncPeerClient interface {
 	GetPeerConnectionUpdateEventCh() <-chan *event.PeerEvent
 	// CloseStream close a stream
 	CloseStream(peerID peer.ID) error
+	// PenalizePeer decreases the reputation score of the peer for its misbehavior
+	PenalizePeer(peerID peer.ID, penalty network.Penalty, reason string)
 	// DisablePublishingPeerStatus disables publishing status in syncer topic
 	DisablePublishingPeerStatus()
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
 	EnablePublishingPeerStatus()
+	// GetAccountRange fetches the accounts of the state from the origin up to the limit with the range proof
+	GetAccountRange(id peer.ID, root types.Hash, origin, limit []byte) (*TrieRange, error)
+	// GetStorageRange fetches the storage slots of the account from the origin up to the limit with the range proof
+	GetStorageRange(id peer.ID, root types.Hash, account, origin, limit []byte) (*TrieRange, error)
+	// GetBytecodes fetches the bytecodes by their hashes
+	GetBytecodes(id peer.ID, hashes []types.Hash) ([][]byte, error)
+}
+
+// StateSyncConfig is the configuration of the state sync,
+// the state of the trusted block is downloaded instead of executing all the blocks up to it
+type StateSyncConfig struct {
+	// TrustedBlockNumber is the number of the trusted block
+	TrustedBlockNumber uint64
+	// TrustedBlockHash is the hash of the trusted block
+	TrustedBlockHash types.Hash
+}
+
+// TrieRange contains the consecutive leaves of a trie with the range proof
+type TrieRange struct {
+	Keys   [][]byte
+	Values [][]byte
+	Proof  [][]byte
 }
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -151,6 +152,10 @@ func (m *nonceToTxLookup) lock() {
 	m.mutex.Lock()
 }
 
+func (m *nonceToTxLookup) tryLock() bool {
+	return m.mutex.TryLock()
+}
+
 func (m *nonceToTxLookup) unlock() {
 	m.mutex.Unlock()
 }
@@ -384,3 +389,95 @@ func (a *account) getLowestTx() *types.Transaction {
 
 	return nil
 }
+
+// evictableTxs returns the transactions which can be evicted from the account without creating
+// a nonce gap, ordered by nonce (descending). The lookup stops at the first protected transaction,
+// as the transactions with the lower nonces are required for its execution.
+// Nothing is returned if the account is locked by another operation.
+func (a *account) evictableTxs(protected func(*types.Transaction) bool) []*types.Transaction {
+	if !a.tryLock() {
+		return nil
+	}
+
+	defer a.unlockAll()
+
+	txs := make([]*types.Transaction, 0, a.promoted.length()+a.enqueued.length())
+	txs = append(txs, a.promoted.queue...)
+	txs = append(txs, a.enqueued.queue...)
+
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce > txs[j].Nonce
+	})
+
+	for i, tx := range txs {
+		if protected(tx) {
+			return txs[:i]
+		}
+	}
+
+	return txs
+}
+
+// evictHighestTx removes the given transaction if it is still the one with the highest nonce
+// of the account. Evicting a promoted transaction reverts the next (expected) nonce.
+// Returns whether the transaction was evicted and whether it was promoted.
+// Nothing is evicted if the account is locked by another operation.
+func (a *account) evictHighestTx(tx *types.Transaction) (evicted bool, promoted bool) {
+	if !a.tryLock() {
+		return false, false
+	}
+
+	defer a.unlockAll()
+
+	if a.enqueued.length() > 0 {
+		if a.enqueued.last() != tx {
+			return false, false
+		}
+
+		a.enqueued.popLast()
+	} else {
+		if a.promoted.last() != tx {
+			return false, false
+		}
+
+		a.promoted.popLast()
+		a.setNonce(tx.Nonce)
+
+		promoted = true
+	}
+
+	a.nonceToTx.remove(tx)
+
+	return true, promoted
+}
+
+// tryLock acquires all the locks of the account if none of them is held, and reports whether they were acquired.
+// The eviction runs while the locks of the account of the new transaction are held,
+// so the locks of the other accounts are never waited for, otherwise two evictions could deadlock
+func (a *account) tryLock() bool {
+	if !a.promoted.tryLock() {
+		return false
+	}
+
+	if !a.enqueued.tryLock() {
+		a.promoted.unlock()
+
+		return false
+	}
+
+	if !a.nonceToTx.tryLock() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+
+		return false
+	}
+
+	return true
+}
+
+// unlockAll releases all the locks of the account acquired by tryLock
+func (a *account) unlockAll() {
+	a.nonceToTx.unlock()
+	a.enqueued.unlock()
+	a.promoted.unlock()
+}
@@ -0,0 +1,298 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// topicNameV2 is the gossip topic of the transaction hash announcements
+	topicNameV2 = "txpool/0.2"
+
+	// txFetchProto is the protocol used to fetch the announced transactions from a peer
+	txFetchProto = "/txpool/fetch/0.1"
+
+	// maxAnnouncedHashes is the maximum number of hashes in an announcement or in a fetch request
+	maxAnnouncedHashes = 256
+
+	// txFetchTimeout is the timeout of fetching the announced transactions from a peer
+	txFetchTimeout = 10 * time.Second
+)
+
+var (
+	errTooManyHashes = errors.New("too many transaction hashes")
+	errUnrequestedTx = errors.New("unrequested transaction")
+	errMalformedTx   = errors.New("malformed transaction")
+)
+
+// txFetchService serves the transactions of the pool requested by the peers
+type txFetchService struct {
+	proto.UnimplementedTxnFetcherServer
+
+	pool *TxPool
+}
+
+// GetTxns is a gRPC endpoint to return the transactions of the pool by their hashes,
+// the transactions which are not in the pool are skipped
+func (s *txFetchService) GetTxns(_ context.Context, req *proto.TxnHashes) (*proto.Txns, error) {
+	if len(req.Hashes) > maxAnnouncedHashes {
+		return nil, errTooManyHashes
+	}
+
+	resp := &proto.Txns{
+		Raw: make([][]byte, 0, len(req.Hashes)),
+	}
+
+	for _, hash := range req.Hashes {
+		if tx, ok := s.pool.index.get(types.BytesToHash(hash)); ok {
+			resp.Raw = append(resp.Raw, tx.MarshalRLP())
+		}
+	}
+
+	return resp, nil
+}
+
+// setupAnnouncements subscribes to the transaction hash announcements
+// and registers the protocol serving the announced transactions to the peers
+func (p *TxPool) setupAnnouncements(server *network.Server) error {
+	topic, err := server.NewTopic(topicNameV2, &proto.TxnHashes{})
+	if err != nil {
+		return err
+	}
+
+	if err := topic.Subscribe(p.addAnnouncedHashes); err != nil {
+		return fmt.Errorf("unable to subscribe to announcement topic, %w", err)
+	}
+
+	p.announceTopic = topic
+	p.network = server
+	p.fetchStream = grpc.NewGrpcStream()
+
+	proto.RegisterTxnFetcherServer(p.fetchStream.GrpcServer(), &txFetchService{pool: p})
+	p.fetchStream.Serve()
+	server.RegisterProtocol(txFetchProto, p.fetchStream)
+
+	return nil
+}
+
+// announce publishes the hashes of the given transactions. The full transactions
+// are published on the v1 topic as well while some of its peers don't receive the announcements
+func (p *TxPool) announce(txs ...*types.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+
+	if p.announceTopic != nil {
+		if err := p.announceTopic.Publish(&proto.TxnHashes{Hashes: toHashBytes(txs)}); err != nil {
+			p.logger.Error("failed to announce txs", "err", err)
+		}
+	}
+
+	// broadcast the transactions only if a topic
+	// subscription is present
+	if p.topic == nil || !p.hasLegacyPeers() {
+		return
+	}
+
+	for _, tx := range txs {
+		if err := p.topic.Publish(&proto.Txn{Raw: &any.Any{Value: tx.MarshalRLP()}}); err != nil {
+			p.logger.Error("failed to topic tx", "err", err)
+		}
+	}
+}
+
+// hasLegacyPeers returns true if some of the peers subscribed to the v1 topic
+// are not subscribed to the announcements
+func (p *TxPool) hasLegacyPeers() bool {
+	if p.announceTopic == nil {
+		return true
+	}
+
+	announced := make(map[peer.ID]struct{})
+	for _, id := range p.announceTopic.ListPeers() {
+		announced[id] = struct{}{}
+	}
+
+	for _, id := range p.topic.ListPeers() {
+		if _, ok := announced[id]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addAnnouncedHashes handles the transaction hashes announced by the network,
+// the unknown transactions are fetched from the announcing peer.
+// The announcements of the peers which are not connected are skipped, the transactions
+// are announced again by the connected peers once they fetch them
+func (p *TxPool) addAnnouncedHashes(obj interface{}, from peer.ID) {
+	if !p.acceptsGossip() {
+		return
+	}
+
+	raw, ok := obj.(*proto.TxnHashes)
+	if !ok {
+		p.logger.Error("failed to cast announcement message to txn hashes")
+
+		return
+	}
+
+	if len(raw.Hashes) > maxAnnouncedHashes {
+		p.logger.Debug("announcement with too many hashes", "peer", from, "num", len(raw.Hashes))
+
+		return
+	}
+
+	if !p.network.IsConnected(from) {
+		return
+	}
+
+	hashes := p.markFetching(raw.Hashes)
+	if len(hashes) == 0 {
+		return
+	}
+
+	defer p.unmarkFetching(hashes)
+
+	txs, err := p.requestTxs(from, hashes)
+	if err != nil {
+		p.logger.Debug("failed to fetch announced txs", "peer", from, "err", err)
+
+		if errors.Is(err, errUnrequestedTx) || errors.Is(err, errMalformedTx) {
+			p.penalizePeer(from, network.PenaltyMalformedMessage, err.Error())
+		}
+
+		return
+	}
+
+	added := make([]*types.Transaction, 0, len(txs))
+
+	for _, tx := range txs {
+		if err := p.addTx(gossip, tx); err != nil {
+			if errors.Is(err, ErrAlreadyKnown) {
+				continue
+			}
+
+			p.logger.Error("failed to add fetched tx", "err", err, "hash", tx.Hash.String())
+
+			if isInvalidTxErr(err) {
+				p.penalizePeer(from, network.PenaltyInvalidTx, err.Error())
+			}
+
+			continue
+		}
+
+		added = append(added, tx)
+	}
+
+	// the peers which are not connected to the origin fetch the transactions from this node
+	p.announce(added...)
+}
+
+// markFetching marks the announced hashes which are neither in the pool
+// nor being fetched already, and returns them
+func (p *TxPool) markFetching(announced [][]byte) []types.Hash {
+	p.fetchingLock.Lock()
+	defer p.fetchingLock.Unlock()
+
+	hashes := make([]types.Hash, 0, len(announced))
+
+	for _, raw := range announced {
+		if len(raw) != types.HashLength {
+			continue
+		}
+
+		hash := types.BytesToHash(raw)
+
+		if _, fetching := p.fetching[hash]; fetching {
+			continue
+		}
+
+		if _, known := p.index.get(hash); known {
+			continue
+		}
+
+		p.fetching[hash] = struct{}{}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes
+}
+
+// unmarkFetching removes the fetched hashes from the ones being fetched
+func (p *TxPool) unmarkFetching(hashes []types.Hash) {
+	p.fetchingLock.Lock()
+	defer p.fetchingLock.Unlock()
+
+	for _, hash := range hashes {
+		delete(p.fetching, hash)
+	}
+}
+
+// requestTxs requests the transactions by their hashes from the peer
+func (p *TxPool) requestTxs(from peer.ID, hashes []types.Hash) ([]*types.Transaction, error) {
+	conn, err := p.network.NewProtoConnection(txFetchProto, from)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), txFetchTimeout)
+	defer cancel()
+
+	req := &proto.TxnHashes{
+		Hashes: make([][]byte, len(hashes)),
+	}
+
+	requested := make(map[types.Hash]struct{}, len(hashes))
+
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+		requested[hash] = struct{}{}
+	}
+
+	resp, err := proto.NewTxnFetcherClient(conn).GetTxns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*types.Transaction, 0, len(resp.Raw))
+
+	for _, raw := range resp.Raw {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode fetched tx: %v", errMalformedTx, err)
+		}
+
+		tx.ComputeHash()
+
+		if _, ok := requested[tx.Hash]; !ok {
+			return nil, fmt.Errorf("%w %s", errUnrequestedTx, tx.Hash)
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// toHashBytes returns the hashes of the transactions as bytes
+func toHashBytes(txs []*types.Transaction) [][]byte {
+	hashes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash.Bytes()
+	}
+
+	return hashes
+}
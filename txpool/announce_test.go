@@ -0,0 +1,146 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newAnnounceTestNetwork(t *testing.T) *network.Server {
+	t.Helper()
+
+	srv, err := network.CreateServer(&network.CreateServerParams{
+		ConfigCallback: func(c *network.Config) {
+			c.NoDiscover = true
+		},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+
+	return srv
+}
+
+func newAnnounceTestPool(t *testing.T, srv *network.Server, poolSigner signer) *TxPool {
+	t.Helper()
+
+	pool, err := NewTxPool(
+		hclog.NewNullLogger(),
+		forks.At(0),
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		srv,
+		&Config{
+			PriceLimit:         defaultPriceLimit,
+			MaxSlots:           defaultMaxSlots,
+			MaxAccountEnqueued: defaultMaxAccountEnqueued,
+		},
+	)
+	require.NoError(t, err)
+
+	pool.SetSigner(poolSigner)
+	pool.SetSealing(true)
+	pool.Start()
+
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// waitForTopicPeers waits until the peer is subscribed to the topic
+func waitForTopicPeers(t *testing.T, topic *network.Topic, id peer.ID) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		for _, subscribed := range topic.ListPeers() {
+			if subscribed == id {
+				return true
+			}
+		}
+
+		return false
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestTxFetchService_GetTxns(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	tx := newTx(addr1, 0, 1)
+	tx.ComputeHash()
+
+	require.True(t, pool.index.add(tx, gossip))
+
+	service := &txFetchService{pool: pool}
+
+	// the unknown transactions are skipped
+	resp, err := service.GetTxns(context.Background(), &proto.TxnHashes{
+		Hashes: [][]byte{types.StringToHash("1").Bytes(), tx.Hash.Bytes()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{tx.MarshalRLP()}, resp.Raw)
+
+	_, err = service.GetTxns(context.Background(), &proto.TxnHashes{
+		Hashes: make([][]byte, maxAnnouncedHashes+1),
+	})
+	require.ErrorIs(t, err, errTooManyHashes)
+}
+
+func TestTxPool_Announcements(t *testing.T) {
+	t.Parallel()
+
+	poolSigner := crypto.NewEIP155Signer(100, true)
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	srv1, srv2 := newAnnounceTestNetwork(t), newAnnounceTestNetwork(t)
+	pool1, pool2 := newAnnounceTestPool(t, srv1, poolSigner), newAnnounceTestPool(t, srv2, poolSigner)
+
+	require.NoError(t, network.JoinAndWait(srv1, srv2, network.DefaultBufferTimeout, network.DefaultJoinTimeout))
+
+	waitForTopicPeers(t, pool1.announceTopic, srv2.AddrInfo().ID)
+	waitForTopicPeers(t, pool1.topic, srv2.AddrInfo().ID)
+
+	// all the peers receive the announcements
+	require.False(t, pool1.hasLegacyPeers())
+
+	tx, err := poolSigner.SignTx(newTx(addr, 0, 1), key)
+	require.NoError(t, err)
+	require.NoError(t, pool1.AddTx(tx))
+
+	// the announced transaction is fetched by the peer
+	require.Eventually(t, func() bool {
+		_, ok := pool2.index.get(tx.Hash)
+
+		return ok
+	}, 10*time.Second, 100*time.Millisecond)
+
+	pool2.fetchingLock.Lock()
+	require.Empty(t, pool2.fetching)
+	pool2.fetchingLock.Unlock()
+
+	// the peer which is only subscribed to the v1 topic still needs the full transactions
+	srv3 := newAnnounceTestNetwork(t)
+
+	legacyTopic, err := srv3.NewTopic(topicNameV1, &proto.Txn{})
+	require.NoError(t, err)
+	require.NoError(t, legacyTopic.Subscribe(func(interface{}, peer.ID) {}))
+
+	require.NoError(t, network.JoinAndWait(srv1, srv3, network.DefaultBufferTimeout, network.DefaultJoinTimeout))
+
+	waitForTopicPeers(t, pool1.topic, srv3.AddrInfo().ID)
+	require.True(t, pool1.hasLegacyPeers())
+}
@@ -186,3 +186,38 @@ func TestEventManager_SignalEventOrder(t *testing.T) {
 
 	assert.Equal(t, totalEvents, eventsProcessed)
 }
+
+func TestTxPool_SubscribePendingTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	hashCh, cancel := pool.SubscribePendingTxs()
+
+	hash := types.StringToHash("1")
+
+	// only the promotions are received
+	pool.eventManager.signalEvent(proto.EventType_ENQUEUED, hash)
+	pool.eventManager.signalEvent(proto.EventType_PROMOTED, hash)
+
+	select {
+	case received := <-hashCh:
+		assert.Equal(t, hash, received)
+	case <-time.After(time.Second * 5):
+		t.Fatal("promoted transaction hash not received in 5 seconds")
+	}
+
+	cancel()
+	cancel()
+
+	// the channel is closed once the subscription is canceled
+	select {
+	case _, more := <-hashCh:
+		assert.False(t, more)
+	case <-time.After(time.Second * 5):
+		t.Fatal("channel not closed in 5 seconds")
+	}
+
+	assert.Zero(t, pool.eventManager.numSubscriptions)
+}
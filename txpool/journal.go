@@ -0,0 +1,216 @@
+package txpool
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxJournalLineSize is the maximum size of the line of the journal, the hex encoded transaction
+const maxJournalLineSize = 2*txMaxSize + 3
+
+var errJournalClosed = errors.New("journal is closed")
+
+// txJournal is the append-only file of the local transactions, which are added back to the pool
+// on the start of the pool. Each line of the file is the hex encoded RLP of a transaction
+type txJournal struct {
+	path string
+
+	lock sync.Mutex
+	// writer is the journal file opened for appending, nil until the first write
+	writer *os.File
+	// txs are the journaled transactions by their hash
+	txs map[types.Hash]*types.Transaction
+	// closed is set once the journal is closed
+	closed bool
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{
+		path: path,
+		txs:  make(map[types.Hash]*types.Transaction),
+	}
+}
+
+// load reads the transactions from the journal, the entries which can't be decoded
+// (e.g. partially written on a crash) are skipped
+func (j *txJournal) load() ([]*types.Transaction, int, error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer file.Close()
+
+	var (
+		txs     []*types.Transaction
+		invalid int
+	)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJournalLineSize)
+
+	for scanner.Scan() {
+		raw, err := hex.DecodeHex(scanner.Text())
+		if err != nil {
+			invalid++
+
+			continue
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			invalid++
+
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read the txpool journal: %w", err)
+	}
+
+	return txs, invalid, nil
+}
+
+// insert appends the transaction to the journal
+func (j *txJournal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.closed {
+		return errJournalClosed
+	}
+
+	if j.writer == nil {
+		writer, err := j.open(j.path, os.O_APPEND)
+		if err != nil {
+			return err
+		}
+
+		j.writer = writer
+	}
+
+	if _, err := j.writer.WriteString(hex.EncodeToHex(tx.MarshalRLP()) + "\n"); err != nil {
+		return err
+	}
+
+	j.txs[tx.Hash] = tx
+
+	return nil
+}
+
+// track adds the transaction loaded from the journal to the journaled ones, so it's kept on the rotation
+func (j *txJournal) track(tx *types.Transaction) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.txs[tx.Hash] = tx
+}
+
+// rotate rewrites the journal with the journaled transactions which are still in the pool,
+// the mined and the dropped ones are removed. It returns the number of the kept transactions
+func (j *txJournal) rotate(inPool func(hash types.Hash) bool) (int, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.closed {
+		return 0, errJournalClosed
+	}
+
+	kept := make([]*types.Transaction, 0, len(j.txs))
+
+	for hash, tx := range j.txs {
+		if inPool(hash) {
+			kept = append(kept, tx)
+		} else {
+			delete(j.txs, hash)
+		}
+	}
+
+	// the transactions of the account are replayed in the order of their nonces
+	sort.Slice(kept, func(i, k int) bool {
+		if kept[i].From != kept[k].From {
+			return kept[i].From.String() < kept[k].From.String()
+		}
+
+		return kept[i].Nonce < kept[k].Nonce
+	})
+
+	rotated, err := j.open(j.path+".new", os.O_TRUNC)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, tx := range kept {
+		if _, err := rotated.WriteString(hex.EncodeToHex(tx.MarshalRLP()) + "\n"); err != nil {
+			rotated.Close()
+
+			return 0, err
+		}
+	}
+
+	if err := rotated.Close(); err != nil {
+		return 0, err
+	}
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return 0, err
+		}
+
+		j.writer = nil
+	}
+
+	if err := os.Rename(j.path+".new", j.path); err != nil {
+		return 0, err
+	}
+
+	writer, err := j.open(j.path, os.O_APPEND)
+	if err != nil {
+		return 0, err
+	}
+
+	j.writer = writer
+
+	return len(kept), nil
+}
+
+// close closes the journal file
+func (j *txJournal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.closed = true
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+// open opens the file for writing with the given flag, creating it and its directory if needed
+func (j *txJournal) open(path string, flag int) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|flag, 0600)
+}
@@ -0,0 +1,159 @@
+package txpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newJournalTestTx(nonce uint64) *types.Transaction {
+	tx := newTx(addr1, nonce, 1)
+	tx.ComputeHash()
+
+	return tx
+}
+
+func TestTxJournal_InsertLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "txpool", "journal")
+	journal := newTxJournal(path)
+
+	// the journal doesn't exist yet
+	txs, invalid, err := journal.load()
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	require.Zero(t, invalid)
+
+	tx1, tx2 := newJournalTestTx(0), newJournalTestTx(1)
+
+	require.NoError(t, journal.insert(tx1))
+	require.NoError(t, journal.insert(tx2))
+	require.NoError(t, journal.close())
+
+	require.ErrorIs(t, journal.insert(tx1), errJournalClosed)
+
+	// the partially written entry is skipped
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+
+	_, err = file.WriteString("0xf8")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	txs, invalid, err = newTxJournal(path).load()
+	require.NoError(t, err)
+	require.Equal(t, 1, invalid)
+	require.Len(t, txs, 2)
+
+	for i, tx := range []*types.Transaction{tx1, tx2} {
+		txs[i].ComputeHash()
+		require.Equal(t, tx.Hash, txs[i].Hash)
+	}
+}
+
+func TestTxJournal_Rotate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal")
+	journal := newTxJournal(path)
+
+	tx1, tx2, tx3 := newJournalTestTx(0), newJournalTestTx(1), newJournalTestTx(2)
+
+	for _, tx := range []*types.Transaction{tx3, tx1, tx2} {
+		require.NoError(t, journal.insert(tx))
+	}
+
+	// the transaction which is no longer in the pool is removed
+	kept, err := journal.rotate(func(hash types.Hash) bool {
+		return hash != tx2.Hash
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, kept)
+
+	// the journal is still appendable after the rotation
+	tx4 := newJournalTestTx(3)
+	require.NoError(t, journal.insert(tx4))
+	require.NoError(t, journal.close())
+
+	txs, _, err := newTxJournal(path).load()
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+
+	// the kept transactions are ordered by the nonce
+	for i, tx := range []*types.Transaction{tx1, tx3, tx4} {
+		txs[i].ComputeHash()
+		require.Equal(t, tx.Hash, txs[i].Hash)
+	}
+}
+
+func TestTxPool_Journal(t *testing.T) {
+	t.Parallel()
+
+	poolSigner := crypto.NewEIP155Signer(100, true)
+	key, addr := tests.GenerateKeyAndAddr(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	newJournalPool := func() *TxPool {
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				JournalPath:        path,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(poolSigner)
+
+		return pool
+	}
+
+	pool := newJournalPool()
+	pool.Start()
+
+	txs := make([]*types.Transaction, 2)
+
+	for i := range txs {
+		tx, err := poolSigner.SignTx(newTx(addr, uint64(i), 1), key)
+		require.NoError(t, err)
+
+		require.NoError(t, pool.AddTx(tx))
+
+		txs[i] = tx
+	}
+
+	// the gossiped transactions are not journaled
+	gossipTx, err := poolSigner.SignTx(newTx(addr, 2, 1), key)
+	require.NoError(t, err)
+	require.NoError(t, pool.addTx(gossip, gossipTx))
+
+	pool.Close()
+
+	// the local transactions are added back after the restart
+	pool = newJournalPool()
+	pool.Start()
+
+	defer pool.Close()
+
+	for _, tx := range txs {
+		_, ok := pool.index.get(tx.Hash)
+		require.True(t, ok)
+	}
+
+	_, ok := pool.index.get(gossipTx.Hash)
+	require.False(t, ok)
+}
@@ -10,11 +10,13 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+	// locals are the hashes of the transactions sent from json-RPC/gRPC endpoints
+	locals map[types.Hash]struct{}
 }
 
 // add inserts the given transaction into the map. Returns false
 // if it already exists. [thread-safe]
-func (m *lookupMap) add(tx *types.Transaction) bool {
+func (m *lookupMap) add(tx *types.Transaction, origin txOrigin) bool {
 	m.Lock()
 	defer m.Unlock()
 
@@ -24,6 +26,10 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 
 	m.all[tx.Hash] = tx
 
+	if origin == local {
+		m.locals[tx.Hash] = struct{}{}
+	}
+
 	return true
 }
 
@@ -34,6 +40,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.locals, tx.Hash)
 	}
 }
 
@@ -49,3 +56,13 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// isLocal returns true if the transaction was sent from json-RPC/gRPC endpoints. [thread-safe]
+func (m *lookupMap) isLocal(hash types.Hash) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	_, ok := m.locals[hash]
+
+	return ok
+}
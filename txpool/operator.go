@@ -3,6 +3,7 @@ package txpool
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -82,3 +83,36 @@ func (p *TxPool) Subscribe(
 		}
 	}
 }
+
+// SubscribePendingTxs subscribes for the hashes of the transactions promoted in the pool.
+// The returned function cancels the subscription, the channel is closed afterwards
+func (p *TxPool) SubscribePendingTxs() (<-chan types.Hash, func()) {
+	subscription := p.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+	var (
+		hashCh    = make(chan types.Hash)
+		doneCh    = make(chan struct{})
+		closeOnce sync.Once
+	)
+
+	go func() {
+		defer close(hashCh)
+
+		for event := range subscription.subscriptionChannel {
+			select {
+			case hashCh <- types.StringToHash(event.TxHash):
+			case <-doneCh:
+				return
+			}
+		}
+	}()
+
+	cancel := func() {
+		closeOnce.Do(func() {
+			close(doneCh)
+			p.eventManager.cancelSubscription(subscription.subscriptionID)
+		})
+	}
+
+	return hashCh, cancel
+}
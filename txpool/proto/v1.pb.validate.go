@@ -161,3 +161,200 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TxnValidationError{}
+
+// Validate checks the field values on TxnHashes with the rules defined in the
+// proto definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *TxnHashes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxnHashes with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in TxnHashesMultiError, or nil if none
+// found.
+func (m *TxnHashes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxnHashes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TxnHashesMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxnHashesMultiError is an error wrapping multiple validation errors returned
+// by TxnHashes.ValidateAll() if the designated constraints aren't met.
+type TxnHashesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxnHashesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxnHashesMultiError) AllErrors() []error { return m }
+
+// TxnHashesValidationError is the validation error returned by
+// TxnHashes.Validate if the designated constraints aren't met.
+type TxnHashesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxnHashesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxnHashesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxnHashesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxnHashesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxnHashesValidationError) ErrorName() string { return "TxnHashesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TxnHashesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxnHashes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxnHashesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxnHashesValidationError{}
+
+// Validate checks the field values on Txns with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Txns) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Txns with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in TxnsMultiError, or nil if none found.
+func (m *Txns) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Txns) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return TxnsMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxnsMultiError is an error wrapping multiple validation errors returned by
+// Txns.ValidateAll() if the designated constraints aren't met.
+type TxnsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxnsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxnsMultiError) AllErrors() []error { return m }
+
+// TxnsValidationError is the validation error returned by Txns.Validate if the
+// designated constraints aren't met.
+type TxnsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxnsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxnsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxnsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxnsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxnsValidationError) ErrorName() string { return "TxnsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TxnsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxns.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxnsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxnsValidationError{}
@@ -36,6 +36,17 @@ func (q *accountQueue) lock(write bool) {
 	q.wLock.Store(write)
 }
 
+// tryLock acquires the write lock if it is not held, and reports whether it was acquired
+func (q *accountQueue) tryLock() bool {
+	if !q.TryLock() {
+		return false
+	}
+
+	q.wLock.Store(true)
+
+	return true
+}
+
 func (q *accountQueue) unlock() {
 	if q.wLock.Swap(false) {
 		q.Unlock()
@@ -99,6 +110,43 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// last returns the transaction with the highest nonce from the queue without removing it.
+func (q *accountQueue) last() *types.Transaction {
+	if i := q.lastIndex(); i >= 0 {
+		return q.queue[i]
+	}
+
+	return nil
+}
+
+// popLast removes the transaction with the highest nonce from the queue and returns it.
+func (q *accountQueue) popLast() *types.Transaction {
+	i := q.lastIndex()
+	if i < 0 {
+		return nil
+	}
+
+	transaction, ok := heap.Remove(&q.queue, i).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	return transaction
+}
+
+// lastIndex returns the index of the transaction with the highest nonce, -1 if the queue is empty.
+func (q *accountQueue) lastIndex() int {
+	last := -1
+
+	for i, tx := range q.queue {
+		if last < 0 || tx.Nonce > q.queue[last].Nonce {
+			last = i
+		}
+	}
+
+	return last
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
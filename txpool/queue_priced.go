@@ -25,6 +25,40 @@ func newPricesQueue(baseFee uint64, initialTxs []*types.Transaction) *pricedQueu
 	return q
 }
 
+// OrderByPrice returns at most limit transactions of the accounts in the order the block is built in:
+// the highest priced one among the lowest nonce transactions of the accounts is taken first,
+// followed by the next transaction of its account. The transactions of each account have to be sorted by nonce
+func OrderByPrice(accountTxs map[types.Address][]*types.Transaction, baseFee uint64, limit int) []*types.Transaction {
+	primaries := make([]*types.Transaction, 0, len(accountTxs))
+	nextIndex := make(map[types.Address]int, len(accountTxs))
+
+	for addr, txs := range accountTxs {
+		if len(txs) > 0 {
+			primaries = append(primaries, txs[0])
+			nextIndex[addr] = 1
+		}
+	}
+
+	queue := newPricesQueue(baseFee, primaries)
+	ordered := make([]*types.Transaction, 0)
+
+	for len(ordered) < limit {
+		tx := queue.pop()
+		if tx == nil {
+			break
+		}
+
+		ordered = append(ordered, tx)
+
+		if idx := nextIndex[tx.From]; idx < len(accountTxs[tx.From]) {
+			queue.push(accountTxs[tx.From][idx])
+			nextIndex[tx.From] = idx + 1
+		}
+	}
+
+	return ordered
+}
+
 // Pushes the given transactions onto the queue.
 func (q *pricedQueue) push(tx *types.Transaction) {
 	heap.Push(q.queue, tx)
@@ -105,6 +139,15 @@ func (q *maxPriceQueue) Less(i, j int) bool {
 	}
 }
 
+// transactions sorted by gas price (ascending), the cheapest ones are evicted first when the pool is full
+type minPriceQueue struct {
+	maxPriceQueue
+}
+
+func (q *minPriceQueue) Less(i, j int) bool {
+	return cmp(q.txs[i], q.txs[j], q.baseFee) < 0
+}
+
 func cmp(a, b *types.Transaction, baseFee *big.Int) int {
 	if baseFee.BitLen() > 0 {
 		// Compare effective tips if baseFee is specified
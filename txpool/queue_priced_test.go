@@ -246,6 +246,40 @@ func Test_maxPriceQueue(t *testing.T) {
 	}
 }
 
+func Test_OrderByPrice(t *testing.T) {
+	t.Parallel()
+
+	addr1, addr2 := types.StringToAddress("1"), types.StringToAddress("2")
+
+	newTx := func(from types.Address, nonce uint64, gasPrice int64) *types.Transaction {
+		return &types.Transaction{
+			Type:     types.LegacyTx,
+			From:     from,
+			Nonce:    nonce,
+			GasPrice: big.NewInt(gasPrice),
+		}
+	}
+
+	accountTxs := map[types.Address][]*types.Transaction{
+		addr1: {newTx(addr1, 0, 10), newTx(addr1, 1, 30)},
+		addr2: {newTx(addr2, 0, 20), newTx(addr2, 1, 5)},
+	}
+
+	// the next transaction of the account competes once the previous one is taken
+	assert.Equal(t, []*types.Transaction{
+		accountTxs[addr2][0],
+		accountTxs[addr1][0],
+		accountTxs[addr1][1],
+		accountTxs[addr2][1],
+	}, OrderByPrice(accountTxs, 0, 10))
+
+	// the number of the transactions is limited
+	assert.Equal(t, []*types.Transaction{
+		accountTxs[addr2][0],
+		accountTxs[addr1][0],
+	}, OrderByPrice(accountTxs, 0, 2))
+}
+
 func Benchmark_pricedQueue(t *testing.B) {
 	testTable := []struct {
 		name        string
@@ -1,21 +1,23 @@
 package txpool
 
 import (
+	"container/heap"
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/network"
+	libp2pGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
@@ -98,6 +100,11 @@ type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
+
+	// JournalPath is the path of the journal of the local transactions, empty path disables the journal
+	JournalPath string
+	// JournalRotation is the interval of the rewriting of the journal without the mined and the dropped transactions
+	JournalRotation time.Duration
 }
 
 /* All requests are passed to the main loop
@@ -156,6 +163,17 @@ type TxPool struct {
 	// networking stack
 	topic *network.Topic
 
+	// announceTopic is the gossip topic of the transaction hash announcements
+	announceTopic *network.Topic
+	// network is used to fetch the announced transactions from the peers
+	network *network.Server
+	// fetchStream serves the announced transactions to the peers
+	fetchStream *libp2pGrpc.GrpcStream
+
+	// fetching are the hashes of the announced transactions being fetched
+	fetching     map[types.Hash]struct{}
+	fetchingLock sync.Mutex
+
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
@@ -187,6 +205,10 @@ type TxPool struct {
 	// pending is the list of pending and ready transactions. This variable
 	// is accessed with atomics
 	pending int64
+
+	// journal keeps the local transactions across the restarts, nil if it's disabled
+	journal         *txJournal
+	journalRotation time.Duration
 }
 
 // NewTxPool returns a new pool for processing incoming transactions.
@@ -204,9 +226,13 @@ func NewTxPool(
 		store:       store,
 		executables: newPricesQueue(0, nil),
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
+		index: lookupMap{
+			all:    make(map[types.Hash]*types.Transaction),
+			locals: make(map[types.Hash]struct{}),
+		},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		fetching:   make(map[types.Hash]struct{}),
+		priceLimit: config.PriceLimit,
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -217,6 +243,11 @@ func NewTxPool(
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
+	if config.JournalPath != "" {
+		pool.journal = newTxJournal(config.JournalPath)
+		pool.journalRotation = config.JournalRotation
+	}
+
 	if network != nil {
 		// subscribe to the gossip protocol
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
@@ -229,6 +260,10 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+
+		if err := pool.setupAnnouncements(network); err != nil {
+			return nil, err
+		}
 	}
 
 	if grpcServer != nil {
@@ -277,12 +312,96 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.journal != nil {
+		p.loadJournal()
+
+		go p.runJournalRotation()
+	}
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
 	close(p.shutdownCh)
+
+	if p.fetchStream != nil {
+		if err := p.fetchStream.Close(); err != nil {
+			p.logger.Error("failed to close the fetch stream", "err", err)
+		}
+	}
+
+	if p.journal != nil {
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the journal", "err", err)
+		}
+	}
+}
+
+// loadJournal adds the local transactions from the journal back to the pool,
+// and rewrites the journal with the accepted ones
+func (p *TxPool) loadJournal() {
+	txs, invalid, err := p.journal.load()
+	if err != nil {
+		p.logger.Error("failed to load the journal", "err", err)
+
+		return
+	}
+
+	added := 0
+
+	for _, tx := range txs {
+		if err := p.addTx(local, tx); err != nil {
+			if p.logger.IsDebug() {
+				p.logger.Debug("journaled tx rejected", "hash", tx.Hash.String(), "err", err)
+			}
+
+			continue
+		}
+
+		p.journal.track(tx)
+
+		added++
+	}
+
+	p.logger.Info("loaded the journal", "transactions", len(txs), "added", added, "invalid", invalid)
+
+	p.rotateJournal()
+}
+
+// runJournalRotation rotates the journal periodically until the pool is closed
+func (p *TxPool) runJournalRotation() {
+	if p.journalRotation <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.journalRotation)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.rotateJournal()
+		}
+	}
+}
+
+// rotateJournal rewrites the journal with the local transactions which are still in the pool
+func (p *TxPool) rotateJournal() {
+	kept, err := p.journal.rotate(func(hash types.Hash) bool {
+		_, ok := p.index.get(hash)
+
+		return ok
+	})
+	if err != nil {
+		p.logger.Error("failed to rotate the journal", "err", err)
+
+		return
+	}
+
+	p.logger.Debug("journal rotated", "transactions", kept)
 }
 
 // SetSigner sets the signer the pool will use
@@ -296,6 +415,12 @@ func (p *TxPool) SetSealing(sealing bool) {
 	p.sealing.CompareAndSwap(p.sealing.Load(), sealing)
 }
 
+// acceptsGossip checks if the transactions gossiped by the network are added to the pool.
+// Besides the sealing nodes, the sentries accept them to relay them to their private validators
+func (p *TxPool) acceptsGossip() bool {
+	return p.sealing.Load() || (p.network != nil && p.network.HasPrivatePeers())
+}
+
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TxPool) AddTx(tx *types.Transaction) error {
@@ -305,20 +430,14 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
-
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
+	if p.journal != nil {
+		if err := p.journal.insert(tx); err != nil {
+			p.logger.Error("failed to journal tx", "hash", tx.Hash.String(), "err", err)
 		}
 	}
 
+	p.announce(tx)
+
 	return nil
 }
 
@@ -363,6 +482,11 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 		account.promoted.unlock()
 	}()
 
+	// the transaction might have been evicted in the meantime
+	if first := account.promoted.peek(); first == nil || first.Hash != tx.Hash {
+		return
+	}
+
 	// pop the top most promoted tx
 	account.promoted.pop()
 
@@ -589,6 +713,13 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return runtime.ErrMaxCodeSizeExceeded
 	}
 
+	// Reject access list tx if berlin hardfork is not enabled
+	if tx.Type == types.AccessListTx && !p.forks.Berlin {
+		metrics.IncrCounter([]string{txPoolMetrics, "invalid_tx_type"}, 1)
+
+		return ErrInvalidTxType
+	}
+
 	if tx.Type == types.DynamicFeeTx {
 		// Reject dynamic fee tx if london hardfork is not enabled
 		if !p.forks.London {
@@ -662,7 +793,8 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(
+		tx, p.forks.Homestead, p.forks.Istanbul, p.forks.Shanghai, p.forks.Berlin)
 	if err != nil {
 		metrics.IncrCounter([]string{txPoolMetrics, "invalid_intrinsic_gas_tx"}, 1)
 
@@ -726,6 +858,100 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 	)
 }
 
+// evictUnderpriced evicts the transactions paying less than the given one until the given number
+// of slots fits into the slots limit. Only the transactions with the highest nonces of the other accounts
+// are evicted, so no nonce gaps are created, and the local transactions are never evicted.
+// Nothing is evicted if not enough slots can be freed. Returns true if the slots fit.
+// It is called while the locks of the account of the given transaction are held.
+func (p *TxPool) evictUnderpriced(tx *types.Transaction, slots, slotsLimit uint64) bool {
+	if p.gauge.read()+slots <= slotsLimit {
+		return true
+	}
+
+	if _, known := p.index.get(tx.Hash); known {
+		return false
+	}
+
+	baseFee := new(big.Int).SetUint64(atomic.LoadUint64(&p.baseFee))
+	candidates := &minPriceQueue{maxPriceQueue{baseFee: baseFee}}
+
+	// the remaining evictable transactions of each account (nonce descending),
+	// only the highest one of an account is a candidate at a time
+	evictable := make(map[types.Address][]*types.Transaction)
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account, _ := value.(*account)
+
+		if addr == tx.From || account == nil {
+			return true
+		}
+
+		txs := account.evictableTxs(func(tx *types.Transaction) bool {
+			return p.index.isLocal(tx.Hash)
+		})
+
+		if len(txs) > 0 {
+			heap.Push(candidates, txs[0])
+			evictable[addr] = txs[1:]
+		}
+
+		return true
+	})
+
+	// select the cheapest transactions first, nothing is evicted until it is known
+	// that enough slots can be freed
+	var (
+		victims []*types.Transaction
+		freed   uint64
+	)
+
+	for p.gauge.read()+slots > slotsLimit+freed {
+		if candidates.Len() == 0 {
+			return false
+		}
+
+		cheapest, _ := heap.Pop(candidates).(*types.Transaction)
+		if cmp(cheapest, tx, baseFee) >= 0 {
+			// the remaining transactions pay at least as much as the new one
+			return false
+		}
+
+		victims = append(victims, cheapest)
+		freed += slotsRequired(cheapest)
+
+		if next := evictable[cheapest.From]; len(next) > 0 {
+			heap.Push(candidates, next[0])
+			evictable[cheapest.From] = next[1:]
+		}
+	}
+
+	for _, victim := range victims {
+		evicted, promoted := p.accounts.get(victim.From).evictHighestTx(victim)
+		if !evicted {
+			// the account has changed in the meantime
+			continue
+		}
+
+		p.index.remove(victim)
+		p.gauge.decrease(slotsRequired(victim))
+
+		if promoted {
+			p.updatePending(-1)
+		}
+
+		metrics.IncrCounter([]string{txPoolMetrics, "evicted_tx"}, 1)
+
+		p.eventManager.signalEvent(proto.EventType_DROPPED, victim.Hash)
+	}
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("evicted underpriced txs", "num", len(victims), "hash", tx.Hash.String())
+	}
+
+	return p.gauge.read()+slots <= slotsLimit
+}
+
 // addTx is the main entry point to the pool
 // for all new transactions. If the call is
 // successful, an account is created for this address
@@ -745,8 +971,6 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 	// initialize account for this address once or retrieve existing one
 	account := p.getOrCreateAccount(tx.From)
-	// populate currently free slots
-	slotsFree := p.gauge.freeSlots()
 
 	account.promoted.lock(true)
 	account.enqueued.lock(true)
@@ -783,7 +1007,6 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 			return ErrUnderpriced
 		}
 
-		slotsFree += slotsRequired(oldTxWithSameNonce) // add old tx slots
 	} else {
 		if account.enqueued.length() == account.maxEnqueued {
 			return ErrMaxEnqueuedLimitReached
@@ -795,13 +1018,32 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		}
 	}
 
+	// the replaced tx frees its slots
+	slotsNeeded := slotsRequired(tx)
+	if oldTxWithSameNonce != nil {
+		if oldSlots := slotsRequired(oldTxWithSameNonce); oldSlots < slotsNeeded {
+			slotsNeeded -= oldSlots
+		} else {
+			slotsNeeded = 0
+		}
+	}
+
+	// make room for the transaction by evicting the cheaper ones, only once it is known
+	// the transaction is not rejected. The future transactions have to fit below the high pressure mark
+	slotsLimit := p.gauge.max
+	if tx.Nonce > accountNonce {
+		slotsLimit = (highPressureMark * p.gauge.max) / 100
+	}
+
+	p.evictUnderpriced(tx, slotsNeeded, slotsLimit)
+
 	// check for overflow
-	if slotsRequired(tx) > slotsFree {
+	if slotsNeeded > p.gauge.freeSlots() {
 		return ErrTxPoolOverflow
 	}
 
 	// add to index
-	if ok := p.index.add(tx); !ok {
+	if ok := p.index.add(tx, origin); !ok {
 		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
 
 		return ErrAlreadyKnown
@@ -863,8 +1105,8 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
-	if !p.sealing.Load() {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
+	if !p.acceptsGossip() {
 		return
 	}
 
@@ -887,6 +1129,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.penalizePeer(from, network.PenaltyMalformedMessage, "malformed gossiped tx")
 
 		return
 	}
@@ -902,7 +1145,41 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		if isInvalidTxErr(err) {
+			p.penalizePeer(from, network.PenaltyInvalidTx, err.Error())
+		}
+	}
+}
+
+// isInvalidTxErr checks if the transaction is rejected for a reason which doesn't depend
+// on the state of the chain or of the pool, the peers sending such transactions are penalized
+func isInvalidTxErr(err error) bool {
+	for _, invalidErr := range []error{
+		ErrIntrinsicGas,
+		ErrNegativeValue,
+		ErrExtractSignature,
+		ErrInvalidSender,
+		ErrOversizedData,
+		ErrTipAboveFeeCap,
+		ErrTipVeryHigh,
+		ErrFeeCapVeryHigh,
+	} {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// penalizePeer decreases the reputation score of the peer which sent an invalid transaction
+func (p *TxPool) penalizePeer(from peer.ID, penalty network.Penalty, reason string) {
+	if p.network == nil {
+		return
 	}
+
+	p.network.PenalizePeer(from, penalty, reason)
 }
 
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
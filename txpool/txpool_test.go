@@ -557,6 +557,194 @@ func TestAddTxHighPressure(t *testing.T) {
 	)
 }
 
+func TestEvictUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, nonce, price uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(price)
+		// the sender is not part of the hash, the input makes the txs of the accounts distinct
+		tx.Input = addr.Bytes()
+
+		return tx
+	}
+
+	// setupFullPool fills the pool of 4 slots with
+	// the gossiped transactions of addr1 and addr2 and the local one of addr3
+	setupFullPool := func(t *testing.T) (*TxPool, []*types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPoolWithSlots(4)
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		txs := []*types.Transaction{
+			newPricedTx(addr1, 0, 1),
+			newPricedTx(addr1, 1, 1),
+			newPricedTx(addr2, 0, 2),
+		}
+
+		for _, tx := range txs {
+			require.NoError(t, pool.addTx(gossip, tx))
+		}
+
+		localTx := newPricedTx(addr3, 0, 1)
+		require.NoError(t, pool.addTx(local, localTx))
+
+		require.Equal(t, uint64(4), pool.gauge.read())
+
+		return pool, append(txs, localTx)
+	}
+
+	t.Run("cheapest tx with the highest nonce is evicted", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t)
+
+		tx := newPricedTx(addr4, 0, 3)
+		require.NoError(t, pool.addTx(gossip, tx))
+
+		_, exists := pool.index.get(tx.Hash)
+		assert.True(t, exists)
+
+		// the tx with the lower nonce of the same account is kept
+		for i, kept := range []bool{true, false, true, true} {
+			_, exists := pool.index.get(txs[i].Hash)
+			assert.Equal(t, kept, exists)
+		}
+
+		assert.Equal(t, uint64(4), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Nil(t, pool.accounts.get(addr1).nonceToTx.get(1))
+	})
+
+	t.Run("underpriced tx is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t)
+
+		assert.ErrorIs(t,
+			pool.addTx(gossip, newPricedTx(addr4, 0, 1)),
+			ErrTxPoolOverflow,
+		)
+
+		for _, tx := range txs {
+			_, exists := pool.index.get(tx.Hash)
+			assert.True(t, exists)
+		}
+	})
+
+	t.Run("rejected tx evicts nothing", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t)
+
+		// same nonce, same price
+		underpriced := newPricedTx(addr2, 0, 2)
+		underpriced.Input = []byte{1}
+
+		pool.getOrCreateAccount(addr4).setNonce(5)
+
+		for _, test := range []struct {
+			tx  *types.Transaction
+			err error
+		}{
+			{txs[2], ErrAlreadyKnown},
+			{underpriced, ErrUnderpriced},
+			{newPricedTx(addr4, 0, 10), ErrNonceTooLow},
+		} {
+			assert.ErrorIs(t, pool.addTx(gossip, test.tx), test.err)
+		}
+
+		for _, tx := range txs {
+			_, exists := pool.index.get(tx.Hash)
+			assert.True(t, exists)
+		}
+
+		assert.Equal(t, uint64(4), pool.gauge.read())
+	})
+
+	t.Run("fee bump evicts nothing", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t)
+
+		bumped := newPricedTx(addr2, 0, 10)
+		bumped.Input = []byte{1}
+		require.NoError(t, pool.addTx(gossip, bumped))
+
+		_, exists := pool.index.get(bumped.Hash)
+		assert.True(t, exists)
+
+		// only the replaced tx is removed
+		for i, tx := range txs {
+			_, exists := pool.index.get(tx.Hash)
+			assert.Equal(t, i != 2, exists)
+		}
+
+		assert.Equal(t, uint64(4), pool.gauge.read())
+	})
+
+	t.Run("nothing is evicted when not enough slots can be freed", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t)
+
+		// only the txs of addr1 are cheaper
+		tx := newPricedTx(addr4, 0, 2)
+		tx.Input = make([]byte, 2*txSlotSize)
+
+		assert.False(t, pool.evictUnderpriced(tx, slotsRequired(tx), pool.gauge.max))
+
+		for _, tx := range txs {
+			_, exists := pool.index.get(tx.Hash)
+			assert.True(t, exists)
+		}
+	})
+
+	t.Run("local txs are not evicted", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(2)
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		require.NoError(t, pool.addTx(local, newPricedTx(addr1, 0, 1)))
+		require.NoError(t, pool.addTx(local, newPricedTx(addr2, 0, 1)))
+
+		assert.ErrorIs(t,
+			pool.addTx(gossip, newPricedTx(addr3, 0, 10)),
+			ErrTxPoolOverflow,
+		)
+	})
+
+	t.Run("evicting promoted tx reverts the account nonce", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPoolWithSlots(1)
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		evicted := newPricedTx(addr1, 0, 1)
+		require.NoError(t, pool.addTx(gossip, evicted))
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		require.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+		require.Equal(t, uint64(1), pool.accounts.promoted())
+
+		require.NoError(t, pool.addTx(gossip, newPricedTx(addr2, 0, 2)))
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(0), acc.getNonce())
+		assert.Equal(t, uint64(0), acc.promoted.length())
+		assert.Equal(t, int64(0), pool.pending)
+
+		// the evicted primary is skipped by the block building
+		pool.Pop(evicted)
+		assert.Equal(t, uint64(1), pool.gauge.read())
+	})
+}
+
 func TestAddGossipTx(t *testing.T) {
 	t.Parallel()
 
@@ -2208,6 +2396,27 @@ func Test_TxPool_validateTx(t *testing.T) {
 			ErrInvalidTxType,
 		)
 	})
+
+	t.Run("eip-2930 tx placed without berlin fork enabled", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.forks.Berlin = false
+
+		berlinSigner := crypto.NewBerlinSigner(100, true, signer)
+		pool.SetSigner(berlinSigner)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.AccessList = types.TxAccessList{{Address: addr1}}
+
+		signedTx, err := berlinSigner.SignTx(tx, defaultKey)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t,
+			pool.validateTx(signedTx),
+			ErrInvalidTxType,
+		)
+	})
 }
 
 /* "Integrated" tests */
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+)
+
+// AccessTuple is the element type of an access list (EIP-2930)
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is an EIP-2930 access list, a list of addresses
+// and storage keys that the transaction plans to access
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+
+	return sum
+}
+
+// Copy makes a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	newAccessList := make(TxAccessList, len(al))
+
+	for i, item := range al {
+		newAccessList[i] = AccessTuple{
+			Address:     item.Address,
+			StorageKeys: append([]Hash{}, item.StorageKeys...),
+		}
+	}
+
+	return newAccessList
+}
+
+// MarshalRLPWith marshals the access list to RLP with a specific fastrlp.Arena
+func (al TxAccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if len(al) == 0 {
+		return arena.NewNullArray()
+	}
+
+	accessListVV := arena.NewArray()
+
+	for _, accessTuple := range al {
+		accessTupleVV := arena.NewArray()
+		accessTupleVV.Set(arena.NewCopyBytes(accessTuple.Address.Bytes()))
+
+		storageKeysVV := arena.NewArray()
+		for _, storageKey := range accessTuple.StorageKeys {
+			storageKeysVV.Set(arena.NewCopyBytes(storageKey.Bytes()))
+		}
+
+		accessTupleVV.Set(storageKeysVV)
+		accessListVV.Set(accessTupleVV)
+	}
+
+	return accessListVV
+}
+
+// unmarshalRLPFrom unmarshals an access list in RLP format
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	accessListVV, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, accessTupleVV := range accessListVV {
+		accessTupleElems, err := accessTupleVV.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(accessTupleElems) != 2 {
+			return fmt.Errorf("incorrect number of elements to decode access tuple, expected 2 but found %d",
+				len(accessTupleElems))
+		}
+
+		accessTuple := AccessTuple{}
+
+		// address
+		if err = accessTupleElems[0].GetAddr(accessTuple.Address[:]); err != nil {
+			return err
+		}
+
+		// storage keys
+		storageKeysElems, err := accessTupleElems[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		accessTuple.StorageKeys = make([]Hash, len(storageKeysElems))
+
+		for i, storageKeyVV := range storageKeysElems {
+			if err = storageKeyVV.GetHash(accessTuple.StorageKeys[i][:]); err != nil {
+				return err
+			}
+		}
+
+		*al = append(*al, accessTuple)
+	}
+
+	return nil
+}
@@ -196,7 +196,8 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	// This is needed to have the same format as other EVM chains do.
 	// There is no chain ID in the TX object, so it is always 0 here just to be compatible.
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	// and TransactionPayload of EIP-2930 https://eips.ethereum.org/EIPS/eip-2930#specification
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
 		vv.Set(arena.NewBigInt(big.NewInt(0)))
 	}
 
@@ -224,11 +225,9 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewCopyBytes(t.Input))
 
 	// Specify access list as per spec.
-	// This is needed to have the same format as other EVM chains do.
-	// There is no access list feature here, so it is always empty just to be compatible.
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
-		vv.Set(arena.NewArray())
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
+		vv.Set(t.AccessList.MarshalRLPWith(arena))
 	}
 
 	// signature values
@@ -398,6 +398,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 9
 	case StateTx:
 		num = 10
+	case AccessListTx:
+		num = 11
 	case DynamicFeeTx:
 		num = 12
 	default:
@@ -413,7 +415,7 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	// Skipping Chain ID field since we don't support it (yet)
 	// This is needed to be compatible with other EVM chains and have the same format.
 	// Since we don't have a chain ID, just skip it here.
-	if t.Type == DynamicFeeTx {
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
 		_ = getElem()
 	}
 
@@ -468,11 +470,12 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	// Skipping Access List field since we don't support it.
-	// This is needed to be compatible with other EVM chains and have the same format.
-	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
-		_ = getElem()
+	// access list
+	if t.Type == DynamicFeeTx || t.Type == AccessListTx {
+		t.AccessList = nil
+		if err = t.AccessList.unmarshalRLPFrom(p, getElem()); err != nil {
+			return err
+		}
 	}
 
 	// V
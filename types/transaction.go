@@ -21,6 +21,7 @@ type TxType byte
 const (
 	LegacyTx     TxType = 0x0
 	StateTx      TxType = 0x7f
+	AccessListTx TxType = 0x01
 	DynamicFeeTx TxType = 0x02
 )
 
@@ -28,7 +29,7 @@ func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, AccessListTx, DynamicFeeTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -42,6 +43,8 @@ func (t TxType) String() (s string) {
 		return "LegacyTx"
 	case StateTx:
 		return "StateTx"
+	case AccessListTx:
+		return "AccessListTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
 	}
@@ -64,6 +67,8 @@ type Transaction struct {
 
 	Type TxType
 
+	AccessList TxAccessList
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
@@ -124,6 +129,8 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
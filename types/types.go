@@ -202,3 +202,12 @@ type OverrideAccount struct {
 }
 
 type StateOverride map[Address]OverrideAccount
+
+// BlockOverride is the set of the block context fields overridden for a call
+type BlockOverride struct {
+	Number    *uint64
+	Timestamp *uint64
+	Coinbase  *Address
+	GasLimit  *uint64
+	BaseFee   *big.Int
+}